@@ -11,9 +11,10 @@ import (
 
 // Chain tracks its blocks and a block's transactions.
 type Chain struct {
-	db     *sql.DB
-	id     int64
-	single singleflight.Group
+	db      *sql.DB
+	dialect dialect
+	id      int64
+	single  singleflight.Group
 }
 
 type transactions []Tx
@@ -30,52 +31,61 @@ func (txs transactions) Hash() []byte {
 // This method is idempotent and can be safely called multiple times with the same arguments.
 // The txs should be human-readable.
 func (chain *Chain) SaveBlock(ctx context.Context, height int64, txs []Tx) error {
-	k := fmt.Sprintf("%d-%x", height, transactions(txs).Hash())
+	return chain.SaveFullBlock(ctx, Block{Height: height, Txs: txs})
+}
+
+// SaveFullBlock tracks a block with its header, transactions, block-level events, and commit signatures.
+// This method is idempotent and can be safely called multiple times with the same arguments.
+// The txs should be human-readable.
+func (chain *Chain) SaveFullBlock(ctx context.Context, block Block) error {
+	k := fmt.Sprintf("%d-%s-%x", block.Height, block.Header.Hash, transactions(block.Txs).Hash())
 	_, err, _ := chain.single.Do(k, func() (any, error) {
-		return nil, chain.saveBlock(ctx, height, txs)
+		return nil, chain.saveBlock(ctx, block)
 	})
 	return err
 }
 
-func (chain *Chain) saveBlock(ctx context.Context, height int64, txs transactions) error {
+func (chain *Chain) saveBlock(ctx context.Context, block Block) error {
 	dbTx, err := chain.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = dbTx.Rollback() }()
 
-	res, err := dbTx.ExecContext(ctx, `INSERT OR REPLACE INTO block(height, fk_chain_id, created_at) VALUES (?, ?, ?)`, height, chain.id, nowRFC3339())
+	// Replace any previously saved block at the same height, along with its child rows.
+	_, err = dbTx.ExecContext(ctx, chain.dialect.rebind(`DELETE FROM block WHERE height = ? AND fk_chain_id = ?`), block.Height, chain.id)
 	if err != nil {
-		return fmt.Errorf("insert into block: %w", err)
+		return fmt.Errorf("delete from block: %w", err)
 	}
 
-	blockID, err := res.LastInsertId()
+	h := block.Header
+	var blockID int64
+	err = dbTx.QueryRowContext(ctx, chain.dialect.rebind(`INSERT INTO block(
+    height, fk_chain_id, created_at, hash, block_time, proposer_address, app_hash, gas_used, gas_wanted
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`),
+		block.Height, chain.id, nowRFC3339(),
+		nullString(h.Hash), nullTime(h.Time), nullString(h.ProposerAddress), nullString(h.AppHash),
+		h.GasUsed, h.GasWanted,
+	).Scan(&blockID)
 	if err != nil {
-		return err
+		return fmt.Errorf("insert into block: %w", err)
 	}
-	for _, tx := range txs {
-		txRes, err := dbTx.ExecContext(ctx, `INSERT INTO tx(data, fk_block_id) VALUES (?, ?)`, string(tx.Data), blockID)
+	for _, tx := range block.Txs {
+		var txID int64
+		err := dbTx.QueryRowContext(ctx, chain.dialect.rebind(`INSERT INTO tx(data, fk_block_id) VALUES (?, ?) RETURNING id`), string(tx.Data), blockID).Scan(&txID)
 		if err != nil {
 			return fmt.Errorf("insert into tx: %w", err)
 		}
-		txID, err := txRes.LastInsertId()
-		if err != nil {
-			return err
-		}
 
 		for _, e := range tx.Events {
-			eventRes, err := dbTx.ExecContext(ctx, `INSERT INTO tendermint_event(type, fk_tx_id) VALUES (?, ?)`, e.Type, txID)
+			var eventID int64
+			err := dbTx.QueryRowContext(ctx, chain.dialect.rebind(`INSERT INTO tendermint_event(type, fk_tx_id) VALUES (?, ?) RETURNING id`), e.Type, txID).Scan(&eventID)
 			if err != nil {
 				return fmt.Errorf("insert into tendermint_event: %w", err)
 			}
 
-			eventID, err := eventRes.LastInsertId()
-			if err != nil {
-				return err
-			}
-
 			for _, attr := range e.Attributes {
-				_, err := dbTx.ExecContext(ctx, `INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES (?, ?, ?)`, attr.Key, attr.Value, eventID)
+				_, err := dbTx.ExecContext(ctx, chain.dialect.rebind(`INSERT INTO tendermint_event_attr(key, value, fk_event_id) VALUES (?, ?, ?)`), attr.Key, attr.Value, eventID)
 				if err != nil {
 					return fmt.Errorf("insert into tendermint_event_attr: %w", err)
 				}
@@ -83,5 +93,28 @@ func (chain *Chain) saveBlock(ctx context.Context, height int64, txs transaction
 		}
 	}
 
+	for _, e := range block.Events {
+		var eventID int64
+		err := dbTx.QueryRowContext(ctx, chain.dialect.rebind(`INSERT INTO block_event(type, fk_block_id) VALUES (?, ?) RETURNING id`), e.Type, blockID).Scan(&eventID)
+		if err != nil {
+			return fmt.Errorf("insert into block_event: %w", err)
+		}
+
+		for _, attr := range e.Attributes {
+			_, err := dbTx.ExecContext(ctx, chain.dialect.rebind(`INSERT INTO block_event_attr(key, value, fk_event_id) VALUES (?, ?, ?)`), attr.Key, attr.Value, eventID)
+			if err != nil {
+				return fmt.Errorf("insert into block_event_attr: %w", err)
+			}
+		}
+	}
+
+	for _, sig := range block.Signatures {
+		_, err := dbTx.ExecContext(ctx, chain.dialect.rebind(`INSERT INTO block_signature(validator_address, flag, timestamp, fk_block_id) VALUES (?, ?, ?, ?)`),
+			sig.ValidatorAddress, sig.Flag, nullTime(sig.Timestamp), blockID)
+		if err != nil {
+			return fmt.Errorf("insert into block_signature: %w", err)
+		}
+	}
+
 	return dbTx.Commit()
 }
@@ -0,0 +1,93 @@
+package blockdb
+
+// The types in this file are the formats of Tx.Data for chains that are not cosmos based.
+// A chain's TxFinder decodes its native transactions into one of these types, encoded as JSON,
+// so that the transactions can be decoded into messages by the database views.
+
+// SubstrateExtrinsic is the Tx.Data format for polkadot chains.
+// Each extrinsic in a block is a separate Tx.
+type SubstrateExtrinsic struct {
+	// Index is the extrinsic's position within the block.
+	Index int `json:"index"`
+
+	// Section and Method are the pallet and call names, e.g. Balances and transfer_keep_alive.
+	Section string `json:"section"`
+	Method  string `json:"method"`
+
+	// Signer is the SS58 address of the signer, empty for inherents.
+	Signer string `json:"signer,omitempty"`
+	Nonce  uint64 `json:"nonce,omitempty"`
+	Tip    string `json:"tip,omitempty"`
+
+	// Args is the hex-encoded SCALE arguments of the call.
+	Args string `json:"args"`
+}
+
+// EVMTx is the Tx.Data format for ethereum chains, combining a transaction with its receipt.
+type EVMTx struct {
+	Hash  string `json:"hash"`
+	From  string `json:"from"`
+	To    string `json:"to,omitempty"` // Empty for contract creation.
+	Value string `json:"value"`        // In wei.
+	Nonce uint64 `json:"nonce"`
+
+	Gas      uint64 `json:"gas"`
+	GasPrice string `json:"gas_price"`
+	GasUsed  uint64 `json:"gas_used"`
+
+	// Input is the hex-encoded call data.
+	Input string `json:"input"`
+
+	// Status is 1 for success and 0 for failure.
+	Status          uint64 `json:"status"`
+	ContractAddress string `json:"contract_address,omitempty"`
+
+	// Logs must not be nil.
+	Logs []EVMLog `json:"logs"`
+}
+
+// EVMLog is a log emitted by an EVMTx.
+type EVMLog struct {
+	// Index is the log's position within the block.
+	Index   uint     `json:"index"`
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// UTXOTx is the Tx.Data format for utxo chains, such as bitcoin.
+type UTXOTx struct {
+	TxID string `json:"txid"`
+
+	// Inputs and Outputs must not be nil.
+	Inputs  []UTXOInput  `json:"inputs"`
+	Outputs []UTXOOutput `json:"outputs"`
+}
+
+// UTXOInput spends a previous output, or is the coinbase of a block.
+type UTXOInput struct {
+	TxID     string `json:"txid,omitempty"`
+	Vout     uint32 `json:"vout"`
+	Coinbase string `json:"coinbase,omitempty"`
+}
+
+// UTXOOutput is an output of a UTXOTx.
+type UTXOOutput struct {
+	N       uint32 `json:"n"`
+	Value   string `json:"value"` // In whole coins, e.g. 0.5 BTC.
+	Address string `json:"address,omitempty"`
+	Type    string `json:"type"` // Script type, e.g. witness_v0_keyhash.
+}
+
+// chainMessageTypes are the chain types with transactions in the formats above.
+var chainMessageTypes = map[string]bool{
+	"polkadot": true,
+	"ethereum": true,
+	"utxo":     true,
+}
+
+// HasChainMessages returns true if Query.ChainMessages decodes the messages of chains of chainType.
+// Use Query.CosmosMessages for cosmos based chains.
+func HasChainMessages(chainType string) bool {
+	return chainMessageTypes[chainType]
+}
@@ -151,6 +151,73 @@ ORDER BY tendermint_event_attr.id`)
 		require.Equal(t, 3, count)
 	})
 
+	t.Run("full block", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		chain := validChain(t, db)
+
+		blockTime := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+		block := Block{
+			Height: 7,
+			Header: BlockHeader{
+				Hash:            "ABC",
+				Time:            blockTime,
+				ProposerAddress: "PROPOSER",
+				AppHash:         "DEF",
+				GasUsed:         100,
+				GasWanted:       200,
+			},
+			Txs: []Tx{tx2},
+			Events: []Event{
+				{Type: "begin", Attributes: []EventAttribute{{Key: "mode", Value: "BeginBlock"}}},
+			},
+			Signatures: []CommitSig{
+				{ValidatorAddress: "VAL1", Flag: CommitFlagCommit, Timestamp: blockTime},
+				{ValidatorAddress: "VAL2", Flag: CommitFlagAbsent},
+			},
+		}
+		require.NoError(t, chain.SaveFullBlock(ctx, block))
+		// Tests idempotency.
+		require.NoError(t, chain.SaveFullBlock(ctx, block))
+
+		row := db.QueryRow(`SELECT block_height, block_hash, block_time, proposer_address, app_hash, gas_used, gas_wanted,
+       tx_total, last_commit_signed, last_commit_missed FROM v_block_header`)
+		var (
+			gotHeight, gotGasUsed, gotGasWanted, gotTxs, gotSigned, gotMissed int64
+			gotHash, gotTime, gotProposer, gotAppHash                         string
+		)
+		require.NoError(t, row.Scan(&gotHeight, &gotHash, &gotTime, &gotProposer, &gotAppHash, &gotGasUsed, &gotGasWanted,
+			&gotTxs, &gotSigned, &gotMissed))
+		require.EqualValues(t, 7, gotHeight)
+		require.Equal(t, "ABC", gotHash)
+		require.Equal(t, "2024-01-02T03:04:05.000000006Z", gotTime)
+		require.Equal(t, "PROPOSER", gotProposer)
+		require.Equal(t, "DEF", gotAppHash)
+		require.EqualValues(t, 100, gotGasUsed)
+		require.EqualValues(t, 200, gotGasWanted)
+		require.EqualValues(t, 1, gotTxs)
+		require.EqualValues(t, 1, gotSigned)
+		require.EqualValues(t, 1, gotMissed)
+
+		row = db.QueryRow(`SELECT block_height, event_type, event_key, event_value FROM v_block_events`)
+		var gotType, gotKey, gotValue string
+		require.NoError(t, row.Scan(&gotHeight, &gotType, &gotKey, &gotValue))
+		require.EqualValues(t, 7, gotHeight)
+		require.Equal(t, "begin", gotType)
+		require.Equal(t, "mode", gotKey)
+		require.Equal(t, "BeginBlock", gotValue)
+
+		var count int
+		row = db.QueryRow(`SELECT count(*) FROM block_event`)
+		require.NoError(t, row.Scan(&count))
+		require.Equal(t, 1, count)
+
+		row = db.QueryRow(`SELECT count(*) FROM block_signature`)
+		require.NoError(t, row.Scan(&count))
+		require.Equal(t, 2, count)
+	})
+
 	t.Run("zero state", func(t *testing.T) {
 		db := migratedDB()
 		defer db.Close()
@@ -2,6 +2,7 @@ package blockdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -34,16 +35,79 @@ type EventAttribute struct {
 	Key, Value string
 }
 
+// Block is a block with its header and block-level data.
+type Block struct {
+	Height int64
+	Header BlockHeader
+
+	// Transactions in the block.
+	Txs []Tx
+
+	// Events not associated with a transaction, e.g. from FinalizeBlock,
+	// or BeginBlock and EndBlock prior to CometBFT v0.38.
+	Events []Event
+
+	// Signatures from the block's last commit.
+	// Notably, these are the validator votes for the previous block, not for this block.
+	Signatures []CommitSig
+}
+
+// BlockHeader is the subset of a tendermint block header and block results useful for debugging.
+type BlockHeader struct {
+	Hash            string
+	Time            time.Time
+	ProposerAddress string
+	AppHash         string
+
+	// Totals across all transactions in the block.
+	GasUsed, GasWanted int64
+}
+
+// CommitSig is a validator's vote from a block's last commit.
+type CommitSig struct {
+	ValidatorAddress string
+
+	// One of CommitFlagCommit, CommitFlagNil, or CommitFlagAbsent.
+	Flag string
+
+	// Zero if the validator was absent.
+	Timestamp time.Time
+}
+
+// Values of CommitSig.Flag, mirroring tendermint's BlockIDFlag.
+const (
+	CommitFlagCommit = "commit" // Voted for the block.
+	CommitFlagNil    = "nil"    // Voted for nil.
+	CommitFlagAbsent = "absent" // No vote was received.
+)
+
 // TxFinder finds transactions given block at height.
+// If the chain has not reached height yet, FindTxs should return an error wrapping ErrHeightNotReached.
 type TxFinder interface {
 	FindTxs(ctx context.Context, height int64) ([]Tx, error)
 }
 
+// ErrHeightNotReached is returned by a TxFinder for a height that the chain has not produced yet.
+// The Collector retries the height without logging the error.
+var ErrHeightNotReached = errors.New("height not reached")
+
+// BlockFinder finds a block, including its header and block-level data, at height.
+// A Collector uses it in place of TxFinder if the finder implements both.
+type BlockFinder interface {
+	FindBlock(ctx context.Context, height int64) (Block, error)
+}
+
 // BlockSaver saves transactions for block at height.
 type BlockSaver interface {
 	SaveBlock(ctx context.Context, height int64, txs []Tx) error
 }
 
+// FullBlockSaver saves a block including its header and block-level data.
+// A Collector uses it in place of BlockSaver if the saver implements both.
+type FullBlockSaver interface {
+	SaveFullBlock(ctx context.Context, block Block) error
+}
+
 // Collector saves block transactions at regular intervals.
 type Collector struct {
 	finder TxFinder
@@ -80,7 +144,8 @@ func (p *Collector) Collect(ctx context.Context) {
 			return
 		case <-tick.C:
 			if err := p.saveTxsForHeight(ctx, height); err != nil {
-				if strings.Contains(err.Error(), "must be less than or equal to the current blockchain height") {
+				if errors.Is(err, ErrHeightNotReached) ||
+					strings.Contains(err.Error(), "must be less than or equal to the current blockchain height") {
 					// (I could not find a more precise way to match this error.)
 					// Don't log because it happens frequently and is expected.
 					continue
@@ -103,6 +168,19 @@ func (p *Collector) Stop() {
 }
 
 func (p *Collector) saveTxsForHeight(ctx context.Context, height int64) error {
+	blockFinder, okFinder := p.finder.(BlockFinder)
+	fullSaver, okSaver := p.saver.(FullBlockSaver)
+	if okFinder && okSaver {
+		block, err := blockFinder.FindBlock(ctx, height)
+		if err != nil {
+			return fmt.Errorf("find block: %w", err)
+		}
+		if err := fullSaver.SaveFullBlock(ctx, block); err != nil {
+			return fmt.Errorf("save block: %w", err)
+		}
+		return nil
+	}
+
 	txs, err := p.finder.FindTxs(ctx, height)
 	if err != nil {
 		return fmt.Errorf("find txs: %w", err)
@@ -27,6 +27,24 @@ func (f mockBlockSaver) SaveBlock(ctx context.Context, height int64, txs []Tx) e
 	return f(ctx, height, txs)
 }
 
+type mockFullFinder struct {
+	mockTxFinder
+	findBlock func(ctx context.Context, height int64) (Block, error)
+}
+
+func (f mockFullFinder) FindBlock(ctx context.Context, height int64) (Block, error) {
+	return f.findBlock(ctx, height)
+}
+
+type mockFullSaver struct {
+	mockBlockSaver
+	saveFullBlock func(ctx context.Context, block Block) error
+}
+
+func (f mockFullSaver) SaveFullBlock(ctx context.Context, block Block) error {
+	return f.saveFullBlock(ctx, block)
+}
+
 func TestCollector_Collect(t *testing.T) {
 	nopLog := zap.NewNop()
 
@@ -87,6 +105,46 @@ func TestCollector_Collect(t *testing.T) {
 		require.Equal(t, "3", string(savedTxs[2][0].Data))
 	})
 
+	t.Run("full blocks", func(t *testing.T) {
+		finder := mockFullFinder{
+			mockTxFinder: func(ctx context.Context, height int64) ([]Tx, error) {
+				panic("FindTxs called")
+			},
+			findBlock: func(ctx context.Context, height int64) (Block, error) {
+				return Block{Height: height, Header: BlockHeader{Hash: strconv.FormatInt(height, 10)}}, nil
+			},
+		}
+		ch := make(chan Block)
+		saver := mockFullSaver{
+			mockBlockSaver: func(ctx context.Context, height int64, txs []Tx) error {
+				panic("SaveBlock called")
+			},
+			saveFullBlock: func(ctx context.Context, block Block) error {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case ch <- block:
+					return nil
+				}
+			},
+		}
+
+		collector := NewCollector(nopLog, finder, saver, time.Nanosecond)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			collector.Collect(ctx)
+		}()
+
+		require.Equal(t, "1", (<-ch).Header.Hash)
+		require.Equal(t, "2", (<-ch).Header.Hash)
+
+		// Wait for the collector to exit so it does not skew goroutine counts in other tests.
+		cancel()
+		<-done
+	})
+
 	t.Run("find error", func(t *testing.T) {
 		ch := make(chan int)
 		finder := mockTxFinder(func(ctx context.Context, height int64) ([]Tx, error) {
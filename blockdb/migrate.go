@@ -1,6 +1,7 @@
 package blockdb
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -21,50 +22,41 @@ import (
 //	│                    │          │                    │         │                    │          │                    │
 //	└────────────────────┘          └────────────────────┘         └────────────────────┘          └────────────────────┘
 //
+// Each block additionally has block-level events and the commit signatures from its header.
+//
 // The gitSha ensures we can trace back to the version of the codebase that produced the schema.
 // Warning: Typical best practice wraps each migration step into its own transaction. For simplicity given
 // this is an embedded database, we omit transactions.
+//
+// Statements are written for sqlite, and translated when db is a PostgreSQL database.
 func Migrate(db *sql.DB, gitSha string) error {
-	// If a timeout is encountered, sleep and try again,
-	// up until the provided number of milliseconds.
-	// A 3000ms timeout worked fine on my workstation but failed the concurrency test on CI.
-	//
-	// Setting this makes it practical to have multiple test instances
-	// writing to the same database file.
-	//
-	// https://www.sqlite.org/pragma.html#pragma_busy_timeout
-	_, err := db.Exec(`PRAGMA busy_timeout = 4000`)
-	if err != nil {
-		return fmt.Errorf("pragma busy_timeout: %w", err)
-	}
-
-	// Only setting the busy_timeout pragma is insufficient to get the concurrency test to pass.
-	// The WAL journal mode, supported by SQLite version 3.7.0 (2010-07-21) or later,
-	// is more forgiving about concurrent reads and writes:
-	// "WAL provides more concurrency as readers do not block writers and a writer does not block readers."
-	//
-	// https://www.sqlite.org/pragma.html#pragma_journal_mode
-	_, err = db.Exec(`PRAGMA journal_mode = WAL`)
-	if err != nil {
-		return fmt.Errorf("pragma journal_mode: %w", err)
-	}
-
-	// TODO(nix 05-27-2022): Appropriate indexes?
-	_, err = db.Exec(`PRAGMA foreign_keys = ON`)
-	if err != nil {
-		return fmt.Errorf("pragma foreign_keys: %w", err)
+	d := dialectOf(db)
+	if d == dialectSQLite {
+		if err := migrateSQLitePragmas(db); err != nil {
+			return err
+		}
 	}
 
-	tx, err := db.Begin()
+	sqlTx, err := db.Begin()
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
 	}
 	defer func() {
 		// If commit succeeded, rollback will return nil;
 		// if a step failed, the returned error is more meaningful.
-		_ = tx.Rollback()
+		_ = sqlTx.Rollback()
 	}()
 
+	if d == dialectPostgres {
+		// Many test processes may migrate a shared database at the same time.
+		// Serialize migrations with a lock held until the transaction ends.
+		if _, err := sqlTx.Exec(`SELECT pg_advisory_xact_lock($1)`, migrationLockID); err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+	}
+
+	tx := migrationTx{Tx: sqlTx, d: d}
+
 	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS schema_version(
     id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
     created_at TEXT NOT NULL CHECK (length(created_at) > 0),
@@ -76,7 +68,7 @@ func Migrate(db *sql.DB, gitSha string) error {
 	}
 
 	_, err = tx.Exec(`INSERT INTO schema_version(created_at, git_sha) VALUES (?, ?)
-ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
+ON CONFLICT(git_sha) DO NOTHING`, nowRFC3339(), gitSha)
 	if err != nil {
 		return fmt.Errorf("upsert schema_version with git sha %s: %w", gitSha, err)
 	}
@@ -122,7 +114,7 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tx: %w", err)
 	}
 
-	_, err = tx.Exec(`ALTER TABLE chain ADD COLUMN chain_type TEXT NOT NULL check(length(chain_type) > 0) DEFAULT "unknown"`)
+	_, err = tx.Exec(`ALTER TABLE chain ADD COLUMN chain_type TEXT NOT NULL check(length(chain_type) > 0) DEFAULT 'unknown'`)
 	if errIgnoreDuplicateColumn(err, "chain_type") != nil {
 		return fmt.Errorf("alter table chain add chain_type: %w", err)
 	}
@@ -148,23 +140,180 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tendermint_event: %w", err)
 	}
 
+	for _, col := range []struct{ name, def string }{
+		{"hash", "TEXT"},
+		{"block_time", "TEXT"},
+		{"proposer_address", "TEXT"},
+		{"app_hash", "TEXT"},
+		{"gas_used", "INTEGER NOT NULL DEFAULT 0"},
+		{"gas_wanted", "INTEGER NOT NULL DEFAULT 0"},
+	} {
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE block ADD COLUMN %s %s`, col.name, col.def))
+		if errIgnoreDuplicateColumn(err, col.name) != nil {
+			return fmt.Errorf("alter table block add %s: %w", col.name, err)
+		}
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS block_event (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL CHECK (length(type) > 0),
+    fk_block_id INTEGER,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table block_event: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS block_event_attr (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    key TEXT NOT NULL CHECK (length(key) > 0),
+    value TEXT NOT NULL,
+    fk_event_id INTEGER,
+    FOREIGN KEY(fk_event_id) REFERENCES block_event(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table block_event_attr: %w", err)
+	}
+
+	// Signatures are from the block's last commit, so they are votes for the block at height-1.
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS block_signature (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    validator_address TEXT NOT NULL,
+    flag TEXT NOT NULL CHECK (length(flag) > 0),
+    timestamp TEXT,
+    fk_block_id INTEGER,
+    FOREIGN KEY(fk_block_id) REFERENCES block(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table block_signature: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS exec (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK (length(kind) > 0),
+    container_name TEXT,
+    command TEXT NOT NULL,
+    stdout TEXT NOT NULL,
+    stderr TEXT NOT NULL,
+    exit_code INTEGER NOT NULL,
+    error TEXT,
+    started_at TEXT NOT NULL CHECK (length(started_at) > 0),
+    finished_at TEXT NOT NULL CHECK (length(finished_at) > 0),
+    fk_test_id INTEGER,
+    FOREIGN KEY(fk_test_id) REFERENCES test_case(id) ON DELETE CASCADE,
+    UNIQUE(container_name)
+)`)
+	if err != nil {
+		return fmt.Errorf("create table exec: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS test_event (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL CHECK (length(type) > 0),
+    name TEXT NOT NULL,
+    message TEXT NOT NULL,
+    created_at TEXT NOT NULL CHECK (length(created_at) > 0),
+    fk_test_id INTEGER,
+    FOREIGN KEY(fk_test_id) REFERENCES test_case(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table test_event: %w", err)
+	}
+
+	// Indexes for searching event attributes, and for joining events to their transactions and blocks.
+	// Attribute values may be larger than a PostgreSQL btree index entry allows, so values use a hash index instead.
+	for _, idx := range []struct{ name, sqlite, postgres string }{
+		{
+			"idx_tendermint_event_attr_key_value",
+			`CREATE INDEX IF NOT EXISTS idx_tendermint_event_attr_key_value ON tendermint_event_attr(key, value)`,
+			`CREATE INDEX IF NOT EXISTS idx_tendermint_event_attr_key_value ON tendermint_event_attr USING hash (value)`,
+		},
+		{
+			"idx_block_event_attr_key_value",
+			`CREATE INDEX IF NOT EXISTS idx_block_event_attr_key_value ON block_event_attr(key, value)`,
+			`CREATE INDEX IF NOT EXISTS idx_block_event_attr_key_value ON block_event_attr USING hash (value)`,
+		},
+		{"idx_tendermint_event_attr_key", `CREATE INDEX IF NOT EXISTS idx_tendermint_event_attr_key ON tendermint_event_attr(key)`, ""},
+		{"idx_block_event_attr_key", `CREATE INDEX IF NOT EXISTS idx_block_event_attr_key ON block_event_attr(key)`, ""},
+		{"idx_tendermint_event_attr_fk_event_id", `CREATE INDEX IF NOT EXISTS idx_tendermint_event_attr_fk_event_id ON tendermint_event_attr(fk_event_id)`, ""},
+		{"idx_block_event_attr_fk_event_id", `CREATE INDEX IF NOT EXISTS idx_block_event_attr_fk_event_id ON block_event_attr(fk_event_id)`, ""},
+		{"idx_tendermint_event_fk_tx_id", `CREATE INDEX IF NOT EXISTS idx_tendermint_event_fk_tx_id ON tendermint_event(fk_tx_id)`, ""},
+		{"idx_block_event_fk_block_id", `CREATE INDEX IF NOT EXISTS idx_block_event_fk_block_id ON block_event(fk_block_id)`, ""},
+		{"idx_tx_fk_block_id", `CREATE INDEX IF NOT EXISTS idx_tx_fk_block_id ON tx(fk_block_id)`, ""},
+	} {
+		create := idx.sqlite
+		if tx.d == dialectPostgres && idx.postgres != "" {
+			create = idx.postgres
+		}
+		_, err = tx.Exec(create)
+		if err != nil {
+			return fmt.Errorf("create index %s: %w", idx.name, err)
+		}
+	}
+
 	// Creating views should be last migration step.
 	if err := upsertViews(tx); err != nil {
 		// Error already wrapped.
 		return err
 	}
 
-	if err := tx.Commit(); err != nil {
+	if err := sqlTx.Commit(); err != nil {
 		return fmt.Errorf("committing migrations: %w", err)
 	}
 
 	return nil
 }
 
+// migrationLockID is an arbitrary PostgreSQL advisory lock key, unique to blockdb migrations.
+const migrationLockID = 4_201_775_019
+
+// migrationTx translates migration statements from sqlite to the dialect of the database.
+type migrationTx struct {
+	*sql.Tx
+	d dialect
+}
+
+func (tx migrationTx) Exec(query string, args ...any) (sql.Result, error) {
+	return tx.Tx.Exec(tx.d.translate(query), args...)
+}
+
+func migrateSQLitePragmas(db *sql.DB) error {
+	// If a timeout is encountered, sleep and try again,
+	// up until the provided number of milliseconds.
+	// A 3000ms timeout worked fine on my workstation but failed the concurrency test on CI.
+	//
+	// Setting this makes it practical to have multiple test instances
+	// writing to the same database file.
+	//
+	// https://www.sqlite.org/pragma.html#pragma_busy_timeout
+	_, err := db.Exec(`PRAGMA busy_timeout = 4000`)
+	if err != nil {
+		return fmt.Errorf("pragma busy_timeout: %w", err)
+	}
+
+	// Only setting the busy_timeout pragma is insufficient to get the concurrency test to pass.
+	// The WAL journal mode, supported by SQLite version 3.7.0 (2010-07-21) or later,
+	// is more forgiving about concurrent reads and writes:
+	// "WAL provides more concurrency as readers do not block writers and a writer does not block readers."
+	//
+	// https://www.sqlite.org/pragma.html#pragma_journal_mode
+	_, err = db.Exec(`PRAGMA journal_mode = WAL`)
+	if err != nil {
+		return fmt.Errorf("pragma journal_mode: %w", err)
+	}
+
+	// TODO(nix 05-27-2022): Appropriate indexes?
+	_, err = db.Exec(`PRAGMA foreign_keys = ON`)
+	if err != nil {
+		return fmt.Errorf("pragma foreign_keys: %w", err)
+	}
+	return nil
+}
+
 // upsertViews should be idempotent by dropping/re-creating the view. The drop/re-create makes view authoring simpler
 // in case table columns are altered, added, or dropped.
 // Performance impact is negligible since views are essentially stored queries.
-func upsertViews(tx *sql.Tx) error {
+func upsertViews(tx migrationTx) error {
 	// Drop and recreate views because it's performant and allows changing columns in earlier migration steps.
 
 	_, err := tx.Exec(`DROP VIEW IF EXISTS v_tx_flattened`)
@@ -198,7 +347,7 @@ LEFT JOIN test_case ON chain.fk_test_id = test_case.id
 	if err != nil {
 		return fmt.Errorf("drop old v_cosmos_messages view: %w", err)
 	}
-	_, err = tx.Exec(`CREATE VIEW v_cosmos_messages AS
+	createCosmosMessages := `CREATE VIEW v_cosmos_messages AS
 SELECT
   test_case_id
   , test_case_name
@@ -238,7 +387,11 @@ SELECT
     ) as counterparty_channel_id
   , value as raw
 FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
-`)
+`
+	if tx.d == dialectPostgres {
+		createCosmosMessages = postgresCosmosMessagesView
+	}
+	_, err = tx.Exec(createCosmosMessages)
 	if err != nil {
 		return fmt.Errorf("create v_cosmos_messages view: %w", err)
 	}
@@ -269,6 +422,239 @@ FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
 		return fmt.Errorf("create v_tx_agg view: %w", err)
 	}
 
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_block_header`)
+	if err != nil {
+		return fmt.Errorf("drop old v_block_header view: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE VIEW v_block_header AS
+SELECT
+  test_case.id AS test_case_id
+  , test_case.name AS test_case_name
+  , chain.id AS chain_kid
+  , chain.chain_id AS chain_id
+  , block.id AS block_id
+  , block.height AS block_height
+  , block.hash AS block_hash
+  , block.block_time AS block_time
+  , block.proposer_address AS proposer_address
+  , block.app_hash AS app_hash
+  , block.gas_used AS gas_used
+  , block.gas_wanted AS gas_wanted
+  , (SELECT COUNT(*) FROM tx WHERE tx.fk_block_id = block.id) AS tx_total
+  , (SELECT COUNT(*) FROM block_signature WHERE block_signature.fk_block_id = block.id AND flag = 'commit') AS last_commit_signed
+  , (SELECT COUNT(*) FROM block_signature WHERE block_signature.fk_block_id = block.id AND flag != 'commit') AS last_commit_missed
+FROM block
+LEFT JOIN chain ON block.fk_chain_id = chain.id
+LEFT JOIN test_case ON chain.fk_test_id = test_case.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_block_header view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_block_events`)
+	if err != nil {
+		return fmt.Errorf("drop old v_block_events view: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE VIEW v_block_events AS
+SELECT
+  test_case.id AS test_case_id
+  , test_case.name AS test_case_name
+  , chain.id AS chain_kid
+  , chain.chain_id AS chain_id
+  , block.id AS block_id
+  , block.height AS block_height
+  , block_event.id AS event_id
+  , block_event.type AS event_type
+  , block_event_attr.key AS event_key
+  , block_event_attr.value AS event_value
+FROM block_event_attr
+LEFT JOIN block_event ON block_event_attr.fk_event_id = block_event.id
+LEFT JOIN block ON block_event.fk_block_id = block.id
+LEFT JOIN chain ON block.fk_chain_id = chain.id
+LEFT JOIN test_case ON chain.fk_test_id = test_case.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_block_events view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_ibc_packets`)
+	if err != nil {
+		return fmt.Errorf("drop old v_ibc_packets view: %w", err)
+	}
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_ibc_packet_events`)
+	if err != nil {
+		return fmt.Errorf("drop old v_ibc_packet_events view: %w", err)
+	}
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_tx_events`)
+	if err != nil {
+		return fmt.Errorf("drop old v_tx_events view: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE VIEW v_tx_events AS
+SELECT
+  test_case.id AS test_case_id
+  , test_case.name AS test_case_name
+  , chain.id AS chain_kid
+  , chain.chain_id AS chain_id
+  , block.id AS block_id
+  , block.height AS block_height
+  , tx.id AS tx_id
+  , tendermint_event.id AS event_id
+  , tendermint_event.type AS event_type
+  , tendermint_event_attr.key AS event_key
+  , tendermint_event_attr.value AS event_value
+FROM tendermint_event_attr
+LEFT JOIN tendermint_event ON tendermint_event_attr.fk_event_id = tendermint_event.id
+LEFT JOIN tx ON tendermint_event.fk_tx_id = tx.id
+LEFT JOIN block ON tx.fk_block_id = block.id
+LEFT JOIN chain ON block.fk_chain_id = chain.id
+LEFT JOIN test_case ON chain.fk_test_id = test_case.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_tx_events view: %w", err)
+	}
+
+	// One row per IBC packet event, with the attributes identifying the packet as columns.
+	_, err = tx.Exec(`CREATE VIEW v_ibc_packet_events AS
+SELECT
+  v_tx_events.test_case_id
+  , v_tx_events.chain_kid
+  , v_tx_events.chain_id
+  , v_tx_events.block_height
+  , block.block_time
+  , v_tx_events.tx_id
+  , v_tx_events.event_id
+  , v_tx_events.event_type
+  , MAX(CASE WHEN event_key = 'packet_sequence' THEN event_value END) AS packet_sequence
+  , MAX(CASE WHEN event_key = 'packet_src_port' THEN event_value END) AS src_port
+  , MAX(CASE WHEN event_key = 'packet_src_channel' THEN event_value END) AS src_channel
+  , MAX(CASE WHEN event_key = 'packet_dst_port' THEN event_value END) AS dst_port
+  , MAX(CASE WHEN event_key = 'packet_dst_channel' THEN event_value END) AS dst_channel
+FROM v_tx_events
+LEFT JOIN block ON v_tx_events.block_id = block.id
+WHERE event_type IN ('send_packet', 'recv_packet', 'write_acknowledgement', 'acknowledge_packet', 'timeout_packet')
+GROUP BY v_tx_events.test_case_id, v_tx_events.chain_kid, v_tx_events.chain_id, v_tx_events.block_height, block.block_time,
+  v_tx_events.tx_id, v_tx_events.event_id, v_tx_events.event_type
+`)
+	if err != nil {
+		return fmt.Errorf("create v_ibc_packet_events view: %w", err)
+	}
+
+	// One row per sent packet, joined with the events of its lifecycle.
+	// Packets are identified by their source port, channel and sequence,
+	// which are unique per chain, so the receiving chain is any other chain with the same packet.
+	// The src_last_block_time is the latest block time of the sending chain, for detecting stuck packets.
+	_, err = tx.Exec(`CREATE VIEW v_ibc_packets AS
+SELECT
+  snd.test_case_id
+  , CAST(snd.packet_sequence AS BIGINT) AS sequence
+  , snd.src_port
+  , snd.src_channel
+  , snd.dst_port
+  , snd.dst_channel
+  , snd.chain_kid AS src_chain_kid
+  , snd.chain_id AS src_chain_id
+  , snd.block_height AS send_height
+  , snd.block_time AS send_time
+  , rcv.chain_kid AS dst_chain_kid
+  , rcv.chain_id AS dst_chain_id
+  , rcv.block_height AS recv_height
+  , rcv.block_time AS recv_time
+  , ack.block_height AS ack_height
+  , ack.block_time AS ack_time
+  , tmo.block_height AS timeout_height
+  , tmo.block_time AS timeout_time
+  , (SELECT MAX(block.block_time) FROM block WHERE block.fk_chain_id = snd.chain_kid) AS src_last_block_time
+FROM v_ibc_packet_events snd
+LEFT JOIN v_ibc_packet_events rcv ON rcv.event_type = 'recv_packet'
+  AND rcv.test_case_id = snd.test_case_id AND rcv.chain_kid != snd.chain_kid
+  AND rcv.packet_sequence = snd.packet_sequence AND rcv.src_port = snd.src_port AND rcv.src_channel = snd.src_channel
+  AND rcv.dst_port = snd.dst_port AND rcv.dst_channel = snd.dst_channel
+LEFT JOIN v_ibc_packet_events ack ON ack.event_type = 'acknowledge_packet'
+  AND ack.chain_kid = snd.chain_kid
+  AND ack.packet_sequence = snd.packet_sequence AND ack.src_port = snd.src_port AND ack.src_channel = snd.src_channel
+LEFT JOIN v_ibc_packet_events tmo ON tmo.event_type = 'timeout_packet'
+  AND tmo.chain_kid = snd.chain_kid
+  AND tmo.packet_sequence = snd.packet_sequence AND tmo.src_port = snd.src_port AND tmo.src_channel = snd.src_channel
+WHERE snd.event_type = 'send_packet'
+`)
+	if err != nil {
+		return fmt.Errorf("create v_ibc_packets view: %w", err)
+	}
+
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_timeline`)
+	if err != nil {
+		return fmt.Errorf("drop old v_timeline view: %w", err)
+	}
+
+	// Blocks without a header, saved with SaveBlock, have no block time and are omitted from the timeline.
+	_, err = tx.Exec(`CREATE VIEW v_timeline AS
+SELECT
+  chain.fk_test_id AS test_case_id
+  , block.block_time AS at
+  , 'block' AS kind
+  , chain.chain_id AS source
+  , block.height AS block_height
+  , 'height ' || block.height AS summary
+  , (SELECT COUNT(*) FROM tx WHERE tx.fk_block_id = block.id) || ' txs' AS detail
+FROM block
+INNER JOIN chain ON block.fk_chain_id = chain.id
+WHERE block.block_time IS NOT NULL
+UNION ALL
+SELECT
+  fk_test_id
+  , started_at
+  , kind
+  , COALESCE(container_name, '')
+  , NULL
+  , command
+  , 'exit code ' || exit_code || COALESCE(': ' || error, '')
+FROM exec
+UNION ALL
+SELECT
+  fk_test_id
+  , created_at
+  , 'test'
+  , name
+  , NULL
+  , type
+  , message
+FROM test_event
+`)
+	if err != nil {
+		return fmt.Errorf("create v_timeline view: %w", err)
+	}
+
+	// Views decoding the transactions of chains that are not cosmos based.
+	// The v_chain_messages view depends on the others, so it is dropped first and created last.
+	for _, v := range []struct{ name, sqlite, postgres string }{
+		{"v_chain_messages", "", ""},
+		{"v_substrate_extrinsics", substrateExtrinsicsView, postgresSubstrateExtrinsicsView},
+		{"v_evm_transactions", evmTransactionsView, postgresEVMTransactionsView},
+		{"v_evm_logs", evmLogsView, postgresEVMLogsView},
+		{"v_utxo_inputs", utxoInputsView, postgresUTXOInputsView},
+		{"v_utxo_outputs", utxoOutputsView, postgresUTXOOutputsView},
+		{"v_chain_messages", chainMessagesView, chainMessagesView},
+	} {
+		_, err = tx.Exec(`DROP VIEW IF EXISTS ` + v.name)
+		if err != nil {
+			return fmt.Errorf("drop old %s view: %w", v.name, err)
+		}
+		create := v.sqlite
+		if tx.d == dialectPostgres {
+			create = v.postgres
+		}
+		if create == "" {
+			continue
+		}
+		_, err = tx.Exec(create)
+		if err != nil {
+			return fmt.Errorf("create %s view: %w", v.name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -280,3 +666,317 @@ func errIgnoreDuplicateColumn(err error, col string) error {
 	}
 	return err
 }
+
+// postgresCosmosMessagesView is v_cosmos_messages using the PostgreSQL JSON functions.
+const postgresCosmosMessagesView = `CREATE VIEW v_cosmos_messages AS
+SELECT
+  test_case_id
+  , test_case_name
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , msg.n - 1 as msg_n -- message position within the tx
+  , msg.value->>'@type' as type
+  , msg.value#>>'{client_state,chain_id}' as client_chain_id
+  , msg.value->>'client_id' as client_id
+  , msg.value#>>'{counterparty,client_id}' as counterparty_client_id
+  , msg.value->>'connection_id' as conn_id
+  , COALESCE(
+      msg.value->>'counterparty_connection_id', -- ConnectionOpenAck
+      msg.value#>>'{counterparty,connection_id}' -- ConnectionOpenTry
+    ) as counterparty_conn_id
+  , COALESCE(
+      msg.value->>'port_id',                -- ChannelOpen*
+      msg.value->>'source_port',            -- MsgTransfer
+      msg.value#>>'{packet,source_port}'    -- MsgRecvPacket and MsgAcknowledgement (might be backwards)
+    ) as port_id
+  , COALESCE(
+      msg.value#>>'{channel,counterparty,port_id}', -- ChannelOpenTry
+      msg.value#>>'{packet,destination_port}'       -- MsgRecvPacket and MsgAcknowledgement (might be backwards)
+    ) as counterparty_port_id
+  , COALESCE(
+      msg.value->>'channel_id',             -- ChannelOpen*
+      msg.value->>'source_channel',         -- MsgTransfer
+      msg.value#>>'{packet,source_channel}' -- MsgRecvPacket and MsgAcknowledgement (might be backwards)
+    ) as channel_id
+  , COALESCE(
+      msg.value->>'counterparty_channel_id',           -- ChannelOpenAck
+      msg.value#>>'{channel,counterparty,channel_id}', -- ChannelOpenTry
+      msg.value#>>'{packet,destination_channel}'       -- MsgRecvPacket and MsgAcknowledgement (might be backwards)
+    ) as counterparty_channel_id
+  , msg.value::text as raw
+FROM v_tx_flattened
+CROSS JOIN LATERAL jsonb_array_elements(v_tx_flattened.tx::jsonb #> '{body,messages}') WITH ORDINALITY AS msg(value, n)
+`
+
+// The chain message views only decode transactions of their chain type,
+// in the formats defined in chain_messages.go.
+
+const substrateExtrinsicsView = `CREATE VIEW v_substrate_extrinsics AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , json_extract(tx, '$.index') AS extrinsic_index
+  , json_extract(tx, '$.section') AS section
+  , json_extract(tx, '$.method') AS method
+  , json_extract(tx, '$.signer') AS signer
+  , json_extract(tx, '$.args') AS args
+FROM v_tx_flattened
+WHERE chain_type = 'polkadot'
+`
+
+const postgresSubstrateExtrinsicsView = `CREATE VIEW v_substrate_extrinsics AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , (tx::jsonb->>'index')::bigint AS extrinsic_index
+  , tx::jsonb->>'section' AS section
+  , tx::jsonb->>'method' AS method
+  , tx::jsonb->>'signer' AS signer
+  , tx::jsonb->>'args' AS args
+FROM v_tx_flattened
+WHERE chain_type = 'polkadot'
+`
+
+const evmTransactionsView = `CREATE VIEW v_evm_transactions AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , json_extract(tx, '$.hash') AS tx_hash
+  , json_extract(tx, '$.from') AS from_address
+  , json_extract(tx, '$.to') AS to_address
+  , json_extract(tx, '$.value') AS value
+  , json_extract(tx, '$.status') AS status
+  , json_extract(tx, '$.gas_used') AS gas_used
+  , json_extract(tx, '$.input') AS input
+  , json_array_length(tx, '$.logs') AS log_count
+FROM v_tx_flattened
+WHERE chain_type = 'ethereum'
+`
+
+const postgresEVMTransactionsView = `CREATE VIEW v_evm_transactions AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , tx::jsonb->>'hash' AS tx_hash
+  , tx::jsonb->>'from' AS from_address
+  , tx::jsonb->>'to' AS to_address
+  , tx::jsonb->>'value' AS value
+  , (tx::jsonb->>'status')::bigint AS status
+  , (tx::jsonb->>'gas_used')::bigint AS gas_used
+  , tx::jsonb->>'input' AS input
+  , jsonb_array_length(tx::jsonb->'logs') AS log_count
+FROM v_tx_flattened
+WHERE chain_type = 'ethereum'
+`
+
+const evmLogsView = `CREATE VIEW v_evm_logs AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , json_extract(tx, '$.hash') AS tx_hash
+  , key AS log_n -- log position within the tx
+  , json_extract(value, '$.index') AS log_index -- log position within the block
+  , json_extract(value, '$.address') AS address
+  , json_extract(value, '$.topics[0]') AS topic0 -- event signature hash
+  , json_extract(value, '$.topics') AS topics
+  , json_extract(value, '$.data') AS data
+FROM v_tx_flattened, json_each(v_tx_flattened.tx, '$.logs')
+WHERE chain_type = 'ethereum'
+`
+
+const postgresEVMLogsView = `CREATE VIEW v_evm_logs AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , tx::jsonb->>'hash' AS tx_hash
+  , log.n - 1 AS log_n -- log position within the tx
+  , (log.value->>'index')::bigint AS log_index -- log position within the block
+  , log.value->>'address' AS address
+  , log.value#>>'{topics,0}' AS topic0 -- event signature hash
+  , (log.value->'topics')::text AS topics
+  , log.value->>'data' AS data
+FROM v_tx_flattened
+CROSS JOIN LATERAL jsonb_array_elements(v_tx_flattened.tx::jsonb->'logs') WITH ORDINALITY AS log(value, n)
+WHERE chain_type = 'ethereum'
+`
+
+const utxoInputsView = `CREATE VIEW v_utxo_inputs AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , json_extract(tx, '$.txid') AS tx_hash
+  , key AS input_n
+  , json_extract(value, '$.txid') AS prev_tx_hash
+  , json_extract(value, '$.vout') AS prev_vout
+  , json_extract(value, '$.coinbase') AS coinbase
+FROM v_tx_flattened, json_each(v_tx_flattened.tx, '$.inputs')
+WHERE chain_type = 'utxo'
+`
+
+const postgresUTXOInputsView = `CREATE VIEW v_utxo_inputs AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , tx::jsonb->>'txid' AS tx_hash
+  , input.n - 1 AS input_n
+  , input.value->>'txid' AS prev_tx_hash
+  , (input.value->>'vout')::bigint AS prev_vout
+  , input.value->>'coinbase' AS coinbase
+FROM v_tx_flattened
+CROSS JOIN LATERAL jsonb_array_elements(v_tx_flattened.tx::jsonb->'inputs') WITH ORDINALITY AS input(value, n)
+WHERE chain_type = 'utxo'
+`
+
+const utxoOutputsView = `CREATE VIEW v_utxo_outputs AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , json_extract(tx, '$.txid') AS tx_hash
+  , json_extract(value, '$.n') AS output_n
+  , json_extract(value, '$.address') AS address
+  , json_extract(value, '$.value') AS value
+  , json_extract(value, '$.type') AS script_type
+FROM v_tx_flattened, json_each(v_tx_flattened.tx, '$.outputs')
+WHERE chain_type = 'utxo'
+`
+
+const postgresUTXOOutputsView = `CREATE VIEW v_utxo_outputs AS
+SELECT
+  test_case_id
+  , chain_kid
+  , chain_id
+  , block_id
+  , block_height
+  , tx_id
+  , tx::jsonb->>'txid' AS tx_hash
+  , (output.value->>'n')::bigint AS output_n
+  , output.value->>'address' AS address
+  , output.value->>'value' AS value
+  , output.value->>'type' AS script_type
+FROM v_tx_flattened
+CROSS JOIN LATERAL jsonb_array_elements(v_tx_flattened.tx::jsonb->'outputs') AS output(value)
+WHERE chain_type = 'utxo'
+`
+
+// chainMessagesView summarizes the chain message views with common columns.
+// The msg_group orders EVM transactions before their logs, and UTXO inputs before outputs.
+const chainMessagesView = `CREATE VIEW v_chain_messages AS
+SELECT
+  chain_kid
+  , block_height
+  , tx_id
+  , NULL AS tx_hash
+  , 0 AS msg_group
+  , extrinsic_index AS msg_n
+  , section || '.' || method AS type
+  , signer AS sender
+  , NULL AS recipient
+  , NULL AS amount
+  , args AS detail
+FROM v_substrate_extrinsics
+UNION ALL
+SELECT
+  chain_kid
+  , block_height
+  , tx_id
+  , tx_hash
+  , 0
+  , 0
+  , CASE WHEN to_address IS NULL THEN 'create' WHEN input = '0x' THEN 'transfer' ELSE 'call' END
+  , from_address
+  , to_address
+  , value
+  , 'status ' || status || ', ' || log_count || ' logs'
+FROM v_evm_transactions
+UNION ALL
+SELECT chain_kid, block_height, tx_id, tx_hash, 1, log_n, 'log', address, NULL, NULL, topic0
+FROM v_evm_logs
+UNION ALL
+SELECT chain_kid, block_height, tx_id, tx_hash, 0, input_n, 'input', COALESCE(prev_tx_hash || ':' || prev_vout, 'coinbase'), NULL, NULL, NULL
+FROM v_utxo_inputs
+UNION ALL
+SELECT chain_kid, block_height, tx_id, tx_hash, 1, output_n, 'output', NULL, address, value, script_type
+FROM v_utxo_outputs
+`
+
+// ErrSchemaMismatch is returned by CheckSchema when a database was not migrated by this version of blockdb.
+var ErrSchemaMismatch = errors.New("block database schema does not match this version")
+
+// schemaRelations are the tables and views created by Migrate.
+var schemaRelations = []string{
+	"schema_version", "test_case", "chain", "block", "tx",
+	"tendermint_event", "tendermint_event_attr", "block_event", "block_event_attr", "block_signature",
+	"exec", "test_event",
+	"v_tx_flattened", "v_cosmos_messages", "v_tx_agg", "v_block_header", "v_block_events", "v_tx_events",
+	"v_ibc_packet_events", "v_ibc_packets", "v_timeline",
+	"v_substrate_extrinsics", "v_evm_transactions", "v_evm_logs", "v_utxo_inputs", "v_utxo_outputs",
+	"v_chain_messages",
+}
+
+// CheckSchema returns an error wrapping ErrSchemaMismatch unless every table and view created by Migrate
+// exists in db. Unlike Migrate, it does not modify db, so it suits read-only consumers of a shared database.
+func CheckSchema(ctx context.Context, db *sql.DB) error {
+	var missing []string
+	for _, rel := range schemaRelations {
+		rows, err := db.QueryContext(ctx, `SELECT * FROM `+rel+` LIMIT 0`)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			missing = append(missing, rel)
+			continue
+		}
+		_ = rows.Close()
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	gitSha := "unknown"
+	if v, err := NewQuery(db).CurrentSchemaVersion(ctx); err == nil {
+		gitSha = v.GitSha
+	}
+	return fmt.Errorf(
+		"%w: missing %s (schema from git sha %s); migrate it by running a test or the debug subcommand with this version",
+		ErrSchemaMismatch, strings.Join(missing, ", "), gitSha,
+	)
+}
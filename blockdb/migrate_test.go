@@ -1,6 +1,7 @@
 package blockdb
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -43,3 +44,55 @@ func TestMigrate(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "new-sha", gotSha)
 }
+
+func TestCheckSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("migrated", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		require.NoError(t, CheckSchema(ctx, db))
+
+		// Every relation created by Migrate is checked.
+		rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%'`)
+		require.NoError(t, err)
+		defer rows.Close()
+		var names []string
+		for rows.Next() {
+			var name string
+			require.NoError(t, rows.Scan(&name))
+			names = append(names, name)
+		}
+		require.NoError(t, rows.Err())
+		require.ElementsMatch(t, schemaRelations, names)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		db := emptyDB()
+		defer db.Close()
+
+		err := CheckSchema(ctx, db)
+		require.ErrorIs(t, err, ErrSchemaMismatch)
+		require.Contains(t, err.Error(), "schema from git sha unknown")
+
+		// Nothing is created.
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM sqlite_master`).Scan(&count))
+		require.Zero(t, count)
+	})
+
+	t.Run("older version", func(t *testing.T) {
+		db := migratedDB()
+		defer db.Close()
+
+		_, err := db.Exec(`DROP VIEW v_chain_messages`)
+		require.NoError(t, err)
+
+		err = CheckSchema(ctx, db)
+		require.ErrorIs(t, err, ErrSchemaMismatch)
+		require.Contains(t, err.Error(), "missing v_chain_messages (schema from git sha test)")
+	})
+}
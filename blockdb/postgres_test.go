@@ -0,0 +1,163 @@
+package blockdb_test
+
+// This test is in the blockdb_test package,
+// so it can import interchaintest without creating an import cycle.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+)
+
+// postgresDB starts a PostgreSQL container for the duration of the test and connects to it.
+func postgresDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	c := dockerutil.NewContainerLifecycle(zaptest.NewLogger(t), dockerutil.RuntimeFor(client), fmt.Sprintf("postgres-%s", dockerutil.RandLowerCaseLetterString(5)))
+	image := ibc.DockerImage{Repository: "postgres", Version: "16-alpine"}
+	require.NoError(t, c.CreateContainer(
+		ctx, t.Name(), network, image,
+		nat.PortMap{"5432/tcp": {}}, nil, nil, "postgres", nil,
+		[]string{"POSTGRES_PASSWORD=interchaintest", "POSTGRES_DB=blockdb"}, nil,
+	))
+	require.NoError(t, c.StartContainer(ctx))
+	t.Cleanup(func() { _ = c.RemoveContainer(context.Background()) })
+
+	ports, err := c.GetHostPorts(ctx, "5432/tcp")
+	require.NoError(t, err)
+	dsn := fmt.Sprintf("postgres://postgres:interchaintest@%s/blockdb?sslmode=disable", ports[0])
+
+	// The server accepts connections shortly after the container starts.
+	var db *sql.DB
+	require.Eventually(t, func() bool {
+		db, err = blockdb.ConnectDB(ctx, dsn)
+		return err == nil
+	}, time.Minute, time.Second, "failed to connect to postgres: %v", err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestPostgres(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+	db := postgresDB(t)
+
+	// Shards sharing a database migrate concurrently.
+	var eg errgroup.Group
+	for i := 0; i < 4; i++ {
+		eg.Go(func() error { return blockdb.Migrate(db, "abc123") })
+	}
+	require.NoError(t, eg.Wait())
+	require.NoError(t, blockdb.Migrate(db, "def456"))
+
+	q := blockdb.NewQuery(db)
+	schema, err := q.CurrentSchemaVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "def456", schema.GitSha)
+
+	tc, err := blockdb.CreateTestCase(ctx, db, "TestPostgres", "def456")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-1", "cosmos")
+	require.NoError(t, err)
+
+	transfer := `{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend"},{"@type":"/ibc.applications.transfer.v1.MsgTransfer","source_port":"transfer","source_channel":"channel-0"}]}}`
+	block := blockdb.Block{
+		Height: 2,
+		Header: blockdb.BlockHeader{Hash: "ABC", Time: time.Now(), GasUsed: 10, GasWanted: 20},
+		Txs: []blockdb.Tx{{
+			Data: []byte(transfer),
+			Events: []blockdb.Event{{Type: "send_packet", Attributes: []blockdb.EventAttribute{
+				{Key: "packet_sequence", Value: "1"},
+				{Key: "packet_src_port", Value: "transfer"},
+				{Key: "packet_src_channel", Value: "channel-0"},
+			}}},
+		}},
+		Events:     []blockdb.Event{{Type: "rewards", Attributes: []blockdb.EventAttribute{{Key: "amount", Value: "1stake"}}}},
+		Signatures: []blockdb.CommitSig{{ValidatorAddress: "VAL", Flag: blockdb.CommitFlagCommit, Timestamp: time.Now()}},
+	}
+	require.NoError(t, chain.SaveBlock(ctx, 1, nil))
+	require.NoError(t, chain.SaveFullBlock(ctx, block))
+	// Saving a block again replaces it.
+	block.Header.Hash = "DEF"
+	require.NoError(t, chain.SaveFullBlock(ctx, block))
+
+	require.NoError(t, tc.SaveExec(ctx, blockdb.Exec{
+		Kind: blockdb.ExecKindContainer, ContainerName: "rly-1", Command: []string{"rly", "start"},
+		StartedAt: time.Now(), FinishedAt: time.Now(),
+	}))
+	require.NoError(t, tc.SaveExec(ctx, blockdb.Exec{
+		Kind: blockdb.ExecKindRelayer, ContainerName: "rly-1", Command: []string{"rly", "start"},
+		StartedAt: time.Now(), FinishedAt: time.Now(),
+	}))
+	require.NoError(t, tc.SaveTestEvent(ctx, blockdb.TestEvent{Type: "BeginTest", Name: "TestPostgres", When: time.Now()}))
+
+	cases, err := q.RecentTestCases(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, cases, 1)
+	require.EqualValues(t, 2, cases[0].ChainHeight.Int64)
+	require.EqualValues(t, 1, cases[0].TxTotal.Int64)
+
+	txs, err := q.Transactions(ctx, cases[0].ChainPKey)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.JSONEq(t, transfer, string(txs[0].Tx))
+
+	msgs, err := q.CosmosMessages(ctx, cases[0].ChainPKey)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	require.Equal(t, 1, msgs[1].Index)
+	require.Equal(t, "/ibc.applications.transfer.v1.MsgTransfer", msgs[1].Type)
+	require.Equal(t, "channel-0", msgs[1].ChannelID.String)
+
+	var hash string
+	var signed int
+	require.NoError(t, db.QueryRow(`SELECT block_hash, last_commit_signed FROM v_block_header WHERE block_height = 2`).Scan(&hash, &signed))
+	require.Equal(t, "DEF", hash)
+	require.Equal(t, 1, signed)
+
+	timeline, err := q.Timeline(ctx, cases[0].ID)
+	require.NoError(t, err)
+	require.Len(t, timeline, 3)
+	var kinds []string
+	for _, e := range timeline {
+		kinds = append(kinds, e.Kind)
+	}
+	require.ElementsMatch(t, []string{"block", blockdb.ExecKindRelayer, "test"}, kinds)
+
+	blocks, err := q.Blocks(ctx, cases[0].ChainPKey)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.True(t, blocks[1].Time.Valid)
+
+	results, err := q.Search(ctx, cases[0].ID, "packet_sequence=1")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	results, err = q.Search(ctx, cases[0].ID, "msgtransfer")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	packets, err := q.IBCPackets(ctx, cases[0].ID)
+	require.NoError(t, err)
+	require.Len(t, packets, 1)
+	require.Equal(t, blockdb.PacketStateSent, packets[0].State())
+	require.EqualValues(t, 1, packets[0].Sequence)
+}
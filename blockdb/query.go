@@ -4,16 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 )
 
 // Query is a service that queries the database.
 type Query struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect dialect
 }
 
 func NewQuery(db *sql.DB) *Query {
-	return &Query{db: db}
+	return &Query{db: db, dialect: dialectOf(db)}
 }
 
 func timeToLocal(timeStr string) (time.Time, error) {
@@ -30,9 +32,9 @@ type SchemaVersionResult struct {
 	CreatedAt time.Time
 }
 
-// CurrentSchemaVersion returns the latest git sha and time that produced the sqlite schema.
+// CurrentSchemaVersion returns the latest git sha and time that produced the database schema.
 func (q *Query) CurrentSchemaVersion(ctx context.Context) (SchemaVersionResult, error) {
-	row := q.db.QueryRowContext(ctx, `SELECT git_sha, created_at FROM schema_version ORDER BY id DESC limit 1`)
+	row := q.db.QueryRowContext(ctx, q.dialect.rebind(`SELECT git_sha, created_at FROM schema_version ORDER BY id DESC limit 1`))
 	var (
 		res      SchemaVersionResult
 		createAt string
@@ -63,14 +65,32 @@ type TestCaseResult struct {
 
 // RecentTestCases returns aggregated data for each test case and chain combination.
 func (q *Query) RecentTestCases(ctx context.Context, limit int) ([]TestCaseResult, error) {
-	rows, err := q.db.QueryContext(ctx, `SELECT 
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT 
         test_case_id, test_case_created_at, test_case_name, test_case_git_sha, chain_kid, chain_id, chain_type, chain_height, tx_total
     FROM v_tx_agg 
     WHERE chain_kid IS NOT NULL
-    ORDER BY test_case_id DESC, chain_id ASC LIMIT ?`, limit)
+    ORDER BY test_case_id DESC, chain_id ASC LIMIT ?`), limit)
 	if err != nil {
 		return nil, err
 	}
+	return scanTestCases(rows)
+}
+
+// TestCase returns aggregated data for each chain of the test case.
+// The results are empty if the test case does not exist or has no chains.
+func (q *Query) TestCase(ctx context.Context, testCaseID int64) ([]TestCaseResult, error) {
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT
+        test_case_id, test_case_created_at, test_case_name, test_case_git_sha, chain_kid, chain_id, chain_type, chain_height, tx_total
+    FROM v_tx_agg
+    WHERE test_case_id = ? AND chain_kid IS NOT NULL
+    ORDER BY chain_id ASC`), testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTestCases(rows)
+}
+
+func scanTestCases(rows *sql.Rows) ([]TestCaseResult, error) {
 	defer rows.Close()
 	var results []TestCaseResult
 	for rows.Next() {
@@ -78,7 +98,7 @@ func (q *Query) RecentTestCases(ctx context.Context, limit int) ([]TestCaseResul
 			res       TestCaseResult
 			createdAt string
 		)
-		if err = rows.Scan(
+		if err := rows.Scan(
 			&res.ID,
 			&createdAt,
 			&res.Name,
@@ -125,7 +145,7 @@ type CosmosMessageResult struct {
 // associated messages.
 // chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
 func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMessageResult, error) {
-	rows, err := q.db.QueryContext(ctx, `SELECT 
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT 
         block_height
         , msg_n -- message index or position within the tx
         , type
@@ -140,7 +160,7 @@ func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMe
         , counterparty_channel_id
     FROM v_cosmos_messages
     WHERE chain_kid = ?
-    ORDER BY block_height ASC , msg_n ASC`, chainPkey)
+    ORDER BY block_height ASC , msg_n ASC`), chainPkey)
 	if err != nil {
 		return nil, err
 	}
@@ -169,7 +189,56 @@ func (q *Query) CosmosMessages(ctx context.Context, chainPkey int64) ([]CosmosMe
 	return results, nil
 }
 
+// ChainMessageResult is a message decoded from a transaction of a chain that is not cosmos based.
+// Depending on the chain type, a message is a substrate extrinsic, an EVM transaction or log,
+// or a UTXO input or output.
+type ChainMessageResult struct {
+	Height int64
+	TxHash sql.NullString
+	Index  int    // Position within the tx, or the block for substrate extrinsics.
+	Type   string // E.g. Balances.transfer_keep_alive, call, log, input, output
+
+	Sender    sql.NullString
+	Recipient sql.NullString
+	Amount    sql.NullString
+	Detail    sql.NullString
+}
+
+// ChainMessages returns a summary of the messages for the chain, for chain types where HasChainMessages is true.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) ChainMessages(ctx context.Context, chainPkey int64) ([]ChainMessageResult, error) {
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT
+        block_height, tx_hash, msg_n, type, sender, recipient, amount, detail
+    FROM v_chain_messages
+    WHERE chain_kid = ?
+    ORDER BY block_height ASC, tx_id ASC, msg_group ASC, msg_n ASC`), chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []ChainMessageResult
+	for rows.Next() {
+		var res ChainMessageResult
+		if err := rows.Scan(
+			&res.Height,
+			&res.TxHash,
+			&res.Index,
+			&res.Type,
+			&res.Sender,
+			&res.Recipient,
+			&res.Amount,
+			&res.Detail,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
 type TxResult struct {
+	ID     int64 // tx primary key
 	Height int64
 	Tx     []byte
 }
@@ -177,11 +246,11 @@ type TxResult struct {
 // Transactions returns TxResults only for blocks with transactions present.
 // chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
 func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult, error) {
-	rows, err := q.db.QueryContext(ctx, `SELECT block.height, tx.data FROM tx 
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT tx.id, block.height, tx.data FROM tx 
     INNER JOIN block on tx.fk_block_id = block.id
     INNER JOIN chain on block.fk_chain_id = chain.id
     WHERE chain.id = ?
-    ORDER BY block.height ASC, tx.id ASC`, chainPkey)
+    ORDER BY block.height ASC, tx.id ASC`), chainPkey)
 	if err != nil {
 		return nil, err
 	}
@@ -190,11 +259,407 @@ func (q *Query) Transactions(ctx context.Context, chainPkey int64) ([]TxResult,
 	var results []TxResult
 	for rows.Next() {
 		var res TxResult
-		if err := rows.Scan(&res.Height, &res.Tx); err != nil {
+		if err := rows.Scan(&res.ID, &res.Height, &res.Tx); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+type BlockResult struct {
+	Height int64
+
+	// The header fields are only valid for blocks saved with their header.
+	Hash            sql.NullString
+	Time            sql.NullTime // Always set to user's local time zone.
+	ProposerAddress sql.NullString
+	GasUsed         int64
+	GasWanted       int64
+
+	TxTotal int64
+}
+
+// Blocks returns the blocks of the chain in ascending order, including blocks without transactions.
+// chainPkey is the chain primary key "chain.id", not to be confused with the column "chain_id".
+func (q *Query) Blocks(ctx context.Context, chainPkey int64) ([]BlockResult, error) {
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT
+        block_height, block_hash, block_time, proposer_address, gas_used, gas_wanted, tx_total
+    FROM v_block_header
+    WHERE chain_kid = ?
+    ORDER BY block_height ASC`), chainPkey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []BlockResult
+	for rows.Next() {
+		var (
+			res       BlockResult
+			blockTime sql.NullString
+		)
+		if err := rows.Scan(&res.Height, &res.Hash, &blockTime, &res.ProposerAddress, &res.GasUsed, &res.GasWanted, &res.TxTotal); err != nil {
+			return nil, err
+		}
+		if res.Time, err = parseNullTime(blockTime); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+type EventAttributeResult struct {
+	ChainPKey int64
+	ChainID   string
+	Height    int64
+	TxID      sql.NullInt64 // Null for block events, such as those from begin and end block.
+
+	EventID   int64
+	EventType string
+	Key       string
+	Value     string
+}
+
+// EventAttributes returns the tx and block event attributes matching key and value, across all chains of the test case.
+// An empty key or value matches any key or value.
+// Within a block, block events are ordered after tx events.
+func (q *Query) EventAttributes(ctx context.Context, testCaseID int64, key, value string) ([]EventAttributeResult, error) {
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT
+        chain_kid, chain_id, block_height, tx_id, event_id, event_type, event_key, event_value
+    FROM v_tx_events
+    WHERE test_case_id = ? AND (? = '' OR event_key = ?) AND (? = '' OR event_value = ?)
+    UNION ALL
+    SELECT
+        chain_kid, chain_id, block_height, NULL, event_id, event_type, event_key, event_value
+    FROM v_block_events
+    WHERE test_case_id = ? AND (? = '' OR event_key = ?) AND (? = '' OR event_value = ?)
+    ORDER BY chain_id ASC, block_height ASC, tx_id ASC NULLS LAST, event_id ASC`),
+		testCaseID, key, key, value, value,
+		testCaseID, key, key, value, value,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []EventAttributeResult
+	for rows.Next() {
+		var res EventAttributeResult
+		if err := rows.Scan(&res.ChainPKey, &res.ChainID, &res.Height, &res.TxID, &res.EventID, &res.EventType, &res.Key, &res.Value); err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// States of an IBCPacketResult.
+const (
+	PacketStateSent         = "sent"
+	PacketStateReceived     = "received"
+	PacketStateAcknowledged = "acknowledged"
+	PacketStateTimedOut     = "timed out"
+)
+
+// DefaultStuckPacketTimeout is a reasonable duration for IBCPacketResult.Stuck,
+// well beyond the time a relayer takes to relay a packet in a test.
+const DefaultStuckPacketTimeout = time.Minute
+
+// IBCPacketResult is the lifecycle of an IBC packet across the sending and receiving chains.
+// Times are only valid for blocks saved with their header, and are always set to user's local time zone.
+type IBCPacketResult struct {
+	Sequence   int64
+	SrcChainID string
+	SrcPort    string
+	SrcChannel string
+	DstChainID sql.NullString // Null until the packet is received.
+	DstPort    string
+	DstChannel string
+
+	SendHeight    int64
+	SendTime      sql.NullTime
+	RecvHeight    sql.NullInt64
+	RecvTime      sql.NullTime
+	AckHeight     sql.NullInt64
+	AckTime       sql.NullTime
+	TimeoutHeight sql.NullInt64
+	TimeoutTime   sql.NullTime
+
+	// SrcLastBlockTime is the time of the latest block of the sending chain.
+	SrcLastBlockTime sql.NullTime
+}
+
+// State returns the latest step of the packet's lifecycle, e.g. PacketStateReceived.
+func (p IBCPacketResult) State() string {
+	switch {
+	case p.AckHeight.Valid:
+		return PacketStateAcknowledged
+	case p.TimeoutHeight.Valid:
+		return PacketStateTimedOut
+	case p.RecvHeight.Valid:
+		return PacketStateReceived
+	default:
+		return PacketStateSent
+	}
+}
+
+// Complete returns true if the packet was acknowledged or timed out, so the sending chain needs nothing further.
+func (p IBCPacketResult) Complete() bool {
+	return p.AckHeight.Valid || p.TimeoutHeight.Valid
+}
+
+// RecvLatency returns the time from sending to receiving the packet, if known.
+func (p IBCPacketResult) RecvLatency() (time.Duration, bool) {
+	return latency(p.SendTime, p.RecvTime)
+}
+
+// AckLatency returns the time from receiving to acknowledging the packet, if known.
+func (p IBCPacketResult) AckLatency() (time.Duration, bool) {
+	return latency(p.RecvTime, p.AckTime)
+}
+
+// TotalLatency returns the time from sending the packet to its completion, if known.
+func (p IBCPacketResult) TotalLatency() (time.Duration, bool) {
+	if p.TimeoutTime.Valid {
+		return latency(p.SendTime, p.TimeoutTime)
+	}
+	return latency(p.SendTime, p.AckTime)
+}
+
+// Stuck returns true if the packet is not complete,
+// although the sending chain produced blocks for longer than timeout after the packet was sent.
+// Using block time, rather than the current time, allows finding stuck packets long after a test finished.
+func (p IBCPacketResult) Stuck(timeout time.Duration) bool {
+	if p.Complete() {
+		return false
+	}
+	d, ok := latency(p.SendTime, p.SrcLastBlockTime)
+	return ok && d > timeout
+}
+
+func latency(from, to sql.NullTime) (time.Duration, bool) {
+	if !from.Valid || !to.Valid {
+		return 0, false
+	}
+	return to.Time.Sub(from.Time), true
+}
+
+// IBCPackets returns the lifecycle of every packet sent by the chains of the test case,
+// ordered by sending chain and then by packet.
+func (q *Query) IBCPackets(ctx context.Context, testCaseID int64) ([]IBCPacketResult, error) {
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT
+        sequence, src_chain_id, src_port, src_channel, dst_chain_id, COALESCE(dst_port, ''), COALESCE(dst_channel, ''),
+        send_height, send_time, recv_height, recv_time, ack_height, ack_time, timeout_height, timeout_time,
+        src_last_block_time
+    FROM v_ibc_packets
+    WHERE test_case_id = ? AND sequence IS NOT NULL AND src_port IS NOT NULL AND src_channel IS NOT NULL
+    ORDER BY src_chain_id ASC, src_port ASC, src_channel ASC, sequence ASC`), testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []IBCPacketResult
+	for rows.Next() {
+		var (
+			res                                                 IBCPacketResult
+			sendTime, recvTime, ackTime, timeoutTime, lastBlock sql.NullString
+		)
+		if err := rows.Scan(
+			&res.Sequence, &res.SrcChainID, &res.SrcPort, &res.SrcChannel, &res.DstChainID, &res.DstPort, &res.DstChannel,
+			&res.SendHeight, &sendTime, &res.RecvHeight, &recvTime, &res.AckHeight, &ackTime, &res.TimeoutHeight, &timeoutTime,
+			&lastBlock,
+		); err != nil {
 			return nil, err
 		}
+		for _, t := range []struct {
+			src sql.NullString
+			dst *sql.NullTime
+		}{
+			{sendTime, &res.SendTime},
+			{recvTime, &res.RecvTime},
+			{ackTime, &res.AckTime},
+			{timeoutTime, &res.TimeoutTime},
+			{lastBlock, &res.SrcLastBlockTime},
+		} {
+			if *t.dst, err = parseNullTime(t.src); err != nil {
+				return nil, err
+			}
+		}
 		results = append(results, res)
 	}
+	return results, nil
+}
+
+// parseNullTime parses a nullable RFC3339 time column into the user's local time zone.
+func parseNullTime(s sql.NullString) (sql.NullTime, error) {
+	if !s.Valid {
+		return sql.NullTime{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s.String)
+	if err != nil {
+		return sql.NullTime{}, fmt.Errorf("parse time: %w", err)
+	}
+	return sql.NullTime{Time: t.In(time.Local), Valid: true}, nil
+}
+
+// Kinds of SearchResult.
+const (
+	SearchKindTx      = "tx"
+	SearchKindMessage = "message"
+	SearchKindEvent   = "event"
+)
+
+type SearchResult struct {
+	Kind      string // One of SearchKindTx, SearchKindMessage, or SearchKindEvent.
+	ChainPKey int64
+	ChainID   string
+	Height    int64
+	TxID      sql.NullInt64 // Null for block events.
 
+	// Summary describes the match, e.g. the message type, the event attribute, or an excerpt of the tx.
+	Summary string
+}
+
+// Search finds the transactions, messages and event attributes matching term, across all chains of the test case.
+//
+// A term of the form key=value matches event attributes exactly, e.g. packet_sequence=5 or sender=cosmos1...
+// An empty value matches any value of the key.
+// Any other term matches message types and transaction data containing the term, ignoring case.
+func (q *Query) Search(ctx context.Context, testCaseID int64, term string) ([]SearchResult, error) {
+	if key, value, ok := strings.Cut(term, "="); ok {
+		return q.searchEventAttributes(ctx, testCaseID, strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, nil
+	}
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(term)) + "%"
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT
+        'message' AS kind, chain_kid, chain_id, block_height, tx_id, type AS summary, NULL AS data
+    FROM v_cosmos_messages
+    WHERE test_case_id = ? AND chain_kid IN (SELECT id FROM chain WHERE chain_type = 'cosmos') AND LOWER(type) LIKE ? ESCAPE '\'
+    UNION ALL
+    SELECT
+        'message', chain.id, chain.chain_id, block_height, tx_id, type, NULL
+    FROM v_chain_messages
+    INNER JOIN chain ON v_chain_messages.chain_kid = chain.id
+    WHERE chain.fk_test_id = ? AND LOWER(type) LIKE ? ESCAPE '\'
+    UNION ALL
+    SELECT
+        'tx', chain_kid, chain_id, block_height, tx_id, '', tx
+    FROM v_tx_flattened
+    WHERE test_case_id = ? AND LOWER(tx) LIKE ? ESCAPE '\'
+    ORDER BY chain_id ASC, block_height ASC, tx_id ASC, kind DESC`),
+		testCaseID, pattern,
+		testCaseID, pattern,
+		testCaseID, pattern,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []SearchResult
+	for rows.Next() {
+		var (
+			res  SearchResult
+			data sql.NullString
+		)
+		if err := rows.Scan(&res.Kind, &res.ChainPKey, &res.ChainID, &res.Height, &res.TxID, &res.Summary, &data); err != nil {
+			return nil, err
+		}
+		if data.Valid {
+			res.Summary = excerpt(data.String, term)
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func (q *Query) searchEventAttributes(ctx context.Context, testCaseID int64, key, value string) ([]SearchResult, error) {
+	if key == "" {
+		return nil, nil
+	}
+	attrs, err := q.EventAttributes(ctx, testCaseID, key, value)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]SearchResult, len(attrs))
+	for i, attr := range attrs {
+		results[i] = SearchResult{
+			Kind:      SearchKindEvent,
+			ChainPKey: attr.ChainPKey,
+			ChainID:   attr.ChainID,
+			Height:    attr.Height,
+			TxID:      attr.TxID,
+			Summary:   fmt.Sprintf("%s %s=%s", attr.EventType, attr.Key, attr.Value),
+		}
+	}
+	return results, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// excerpt returns the text surrounding the first case-insensitive match of term in s.
+func excerpt(s, term string) string {
+	const margin = 30
+	i := strings.Index(strings.ToLower(s), strings.ToLower(term))
+	if i < 0 {
+		return ""
+	}
+	start, end := max(0, i-margin), min(len(s), i+len(term)+margin)
+	out := s[start:end]
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(s) {
+		out += "…"
+	}
+	return out
+}
+
+type TimelineResult struct {
+	At     time.Time
+	Kind   string // E.g. block, relayer, container, test
+	Source string // E.g. chain ID, container name, or test name
+
+	BlockHeight sql.NullInt64
+
+	Summary, Detail string
+}
+
+// Timeline returns the blocks of every chain, relayer and container executions, and test events
+// for the test case in chronological order.
+func (q *Query) Timeline(ctx context.Context, testCaseID int64) ([]TimelineResult, error) {
+	rows, err := q.db.QueryContext(ctx, q.dialect.rebind(`SELECT at, kind, source, block_height, summary, detail
+    FROM v_timeline
+    WHERE test_case_id = ?
+    ORDER BY at ASC`), testCaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []TimelineResult
+	for rows.Next() {
+		var (
+			res TimelineResult
+			at  string
+		)
+		if err := rows.Scan(&at, &res.Kind, &res.Source, &res.BlockHeight, &res.Summary, &res.Detail); err != nil {
+			return nil, err
+		}
+		t, err := time.Parse(time.RFC3339Nano, at)
+		if err != nil {
+			return nil, fmt.Errorf("parse at: %w", err)
+		}
+		res.At = t.In(time.Local)
+		results = append(results, res)
+	}
 	return results, nil
 }
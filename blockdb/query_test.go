@@ -2,8 +2,10 @@ package blockdb
 
 import (
 	"context"
+	"database/sql"
 	_ "embed"
 	"encoding/json"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -169,6 +171,115 @@ func TestQuery_CosmosMessages(t *testing.T) {
 	}
 }
 
+func TestQuery_ChainMessages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "sha")
+	require.NoError(t, err)
+
+	saveTx := func(chain *Chain, height int64, v any) {
+		bz, err := json.Marshal(v)
+		require.NoError(t, err)
+		require.NoError(t, chain.SaveBlock(ctx, height, []Tx{{Data: bz}}))
+	}
+
+	// Transactions of other chain types are not decoded, even if they are not JSON.
+	cosmos, err := tc.AddChain(ctx, "cosmos-1", "cosmos")
+	require.NoError(t, err)
+	require.NoError(t, cosmos.SaveBlock(ctx, 1, []Tx{{Data: []byte("not json")}}))
+
+	t.Run("substrate", func(t *testing.T) {
+		chain, err := tc.AddChain(ctx, "polkadot-1", "polkadot")
+		require.NoError(t, err)
+		saveTx(chain, 3, SubstrateExtrinsic{Index: 1, Section: "Balances", Method: "transfer_keep_alive", Signer: "5Grw", Args: "0x00"})
+
+		results, err := NewQuery(db).ChainMessages(ctx, chain.id)
+		require.NoError(t, err)
+		require.Equal(t, []ChainMessageResult{{
+			Height: 3,
+			Index:  1,
+			Type:   "Balances.transfer_keep_alive",
+			Sender: sql.NullString{String: "5Grw", Valid: true},
+			Detail: sql.NullString{String: "0x00", Valid: true},
+		}}, results)
+	})
+
+	t.Run("evm", func(t *testing.T) {
+		chain, err := tc.AddChain(ctx, "ethereum-1", "ethereum")
+		require.NoError(t, err)
+		saveTx(chain, 5, EVMTx{
+			Hash: "0xabc", From: "0x01", To: "0x02", Value: "1000", Input: "0xa9059cbb", Status: 1,
+			Logs: []EVMLog{{Index: 7, Address: "0x02", Topics: []string{"0xddf2", "0x01"}, Data: "0x"}},
+		})
+		saveTx(chain, 6, EVMTx{Hash: "0xdef", From: "0x01", Value: "0", Input: "0x6080", Logs: []EVMLog{}})
+
+		results, err := NewQuery(db).ChainMessages(ctx, chain.id)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		call := results[0]
+		require.EqualValues(t, 5, call.Height)
+		require.Equal(t, "0xabc", call.TxHash.String)
+		require.Equal(t, "call", call.Type)
+		require.Equal(t, "0x01", call.Sender.String)
+		require.Equal(t, "0x02", call.Recipient.String)
+		require.Equal(t, "1000", call.Amount.String)
+		require.Equal(t, "status 1, 1 logs", call.Detail.String)
+
+		log := results[1]
+		require.Equal(t, "log", log.Type)
+		require.Equal(t, 0, log.Index)
+		require.Equal(t, "0x02", log.Sender.String)
+		require.Equal(t, "0xddf2", log.Detail.String)
+
+		create := results[2]
+		require.Equal(t, "create", create.Type)
+		require.False(t, create.Recipient.Valid)
+		require.Equal(t, "status 0, 0 logs", create.Detail.String)
+	})
+
+	t.Run("utxo", func(t *testing.T) {
+		chain, err := tc.AddChain(ctx, "bitcoin-1", "utxo")
+		require.NoError(t, err)
+		saveTx(chain, 2, UTXOTx{
+			TxID:    "aa",
+			Inputs:  []UTXOInput{{Coinbase: "03"}},
+			Outputs: []UTXOOutput{{N: 0, Value: "50.00000000", Address: "bcrt1q", Type: "witness_v0_keyhash"}},
+		})
+		saveTx(chain, 3, UTXOTx{
+			TxID:   "bb",
+			Inputs: []UTXOInput{{TxID: "aa", Vout: 0}},
+			Outputs: []UTXOOutput{
+				{N: 0, Value: "1.5", Address: "bcrt1r", Type: "witness_v0_keyhash"},
+				{N: 1, Value: "0", Type: "nulldata"},
+			},
+		})
+
+		results, err := NewQuery(db).ChainMessages(ctx, chain.id)
+		require.NoError(t, err)
+
+		var got []string
+		for _, res := range results {
+			got = append(got, strings.Join([]string{
+				strconv.FormatInt(res.Height, 10), res.TxHash.String, res.Type, strconv.Itoa(res.Index),
+				res.Sender.String, res.Recipient.String, res.Amount.String, res.Detail.String,
+			}, " "))
+		}
+		require.Equal(t, []string{
+			"2 aa input 0 coinbase   ",
+			"2 aa output 0  bcrt1q 50.00000000 witness_v0_keyhash",
+			"3 bb input 0 aa:0   ",
+			"3 bb output 0  bcrt1r 1.5 witness_v0_keyhash",
+			"3 bb output 1   0 nulldata",
+		}, got)
+	})
+}
+
 func TestQuery_Transactions(t *testing.T) {
 	t.Parallel()
 
@@ -191,6 +302,8 @@ func TestQuery_Transactions(t *testing.T) {
 
 		require.Len(t, results, 3)
 
+		require.NotZero(t, results[0].ID)
+		require.Less(t, results[0].ID, results[1].ID)
 		require.EqualValues(t, 12, results[0].Height)
 		require.Equal(t, "1", string(results[0].Tx))
 
@@ -216,3 +329,384 @@ func TestQuery_Transactions(t *testing.T) {
 		require.Len(t, results, 0)
 	})
 }
+
+func TestQuery_Timeline(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test1", "sha1")
+	require.NoError(t, err)
+	c, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, tc.SaveTestEvent(ctx, TestEvent{Type: "BeginTest", Name: "test1", When: start}))
+	require.NoError(t, c.SaveFullBlock(ctx, Block{
+		Height: 3,
+		Header: BlockHeader{Time: start.Add(2 * time.Second)},
+		Txs:    []Tx{{Data: []byte("tx1")}},
+	}))
+	// Blocks without a header are omitted.
+	require.NoError(t, c.SaveBlock(ctx, 4, nil))
+
+	exec := Exec{
+		Kind:          ExecKindContainer,
+		ContainerName: "rly-abc",
+		Command:       []string{"rly", "tx", "flush"},
+		ExitCode:      1,
+		Error:         "boom",
+		StartedAt:     start.Add(time.Second + time.Millisecond),
+		FinishedAt:    start.Add(3 * time.Second),
+	}
+	require.NoError(t, tc.SaveExec(ctx, exec))
+	// The same command reported by the relayer is deduplicated.
+	exec.Kind = ExecKindRelayer
+	require.NoError(t, tc.SaveExec(ctx, exec))
+	exec.Kind = ExecKindContainer
+	require.NoError(t, tc.SaveExec(ctx, exec))
+
+	require.NoError(t, tc.SaveTestEvent(ctx, TestEvent{Type: "FinishTest", Name: "test1", Message: "passed", When: start.Add(time.Second)}))
+
+	other, err := CreateTestCase(ctx, db, "test2", "sha1")
+	require.NoError(t, err)
+	require.NoError(t, other.SaveTestEvent(ctx, TestEvent{Type: "BeginTest", Name: "test2", When: start}))
+
+	results, err := NewQuery(db).Timeline(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	require.Equal(t, "test", results[0].Kind)
+	require.Equal(t, "test1", results[0].Source)
+	require.Equal(t, "BeginTest", results[0].Summary)
+	require.True(t, start.Equal(results[0].At))
+
+	require.Equal(t, "FinishTest", results[1].Summary)
+	require.Equal(t, "passed", results[1].Detail)
+
+	require.Equal(t, "relayer", results[2].Kind)
+	require.Equal(t, "rly-abc", results[2].Source)
+	require.Equal(t, "rly tx flush", results[2].Summary)
+	require.Equal(t, "exit code 1: boom", results[2].Detail)
+	require.False(t, results[2].BlockHeight.Valid)
+
+	require.Equal(t, "block", results[3].Kind)
+	require.Equal(t, "chain-a", results[3].Source)
+	require.EqualValues(t, 3, results[3].BlockHeight.Int64)
+	require.Equal(t, "height 3", results[3].Summary)
+	require.Equal(t, "1 txs", results[3].Detail)
+}
+
+func TestQuery_TestCase(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test1", "sha1")
+	require.NoError(t, err)
+	_, err = tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+	_, err = tc.AddChain(ctx, "chain-a", "polkadot")
+	require.NoError(t, err)
+
+	other, err := CreateTestCase(ctx, db, "test2", "sha1")
+	require.NoError(t, err)
+	_, err = other.AddChain(ctx, "chain-c", "cosmos")
+	require.NoError(t, err)
+
+	results, err := NewQuery(db).TestCase(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.Equal(t, "test1", results[0].Name)
+	require.Equal(t, "chain-a", results[0].ChainID)
+	require.Equal(t, "polkadot", results[0].ChainType)
+	require.Equal(t, "chain-b", results[1].ChainID)
+
+	results, err = NewQuery(db).TestCase(ctx, 100)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestQuery_Blocks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	blockTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, chain.SaveFullBlock(ctx, Block{
+		Height: 2,
+		Header: BlockHeader{Hash: "ABC", Time: blockTime, ProposerAddress: "VAL1", GasUsed: 10, GasWanted: 20},
+		Txs:    []Tx{{Data: []byte(`1`)}, {Data: []byte(`2`)}},
+	}))
+	require.NoError(t, chain.SaveBlock(ctx, 1, nil))
+
+	results, err := NewQuery(db).Blocks(ctx, chain.id)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.EqualValues(t, 1, results[0].Height)
+	require.False(t, results[0].Hash.Valid)
+	require.False(t, results[0].Time.Valid)
+	require.Zero(t, results[0].TxTotal)
+
+	require.EqualValues(t, 2, results[1].Height)
+	require.Equal(t, "ABC", results[1].Hash.String)
+	require.True(t, blockTime.Equal(results[1].Time.Time))
+	require.Equal(t, "VAL1", results[1].ProposerAddress.String)
+	require.EqualValues(t, 10, results[1].GasUsed)
+	require.EqualValues(t, 20, results[1].GasWanted)
+	require.EqualValues(t, 2, results[1].TxTotal)
+}
+
+func TestQuery_EventAttributes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chain, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+
+	require.NoError(t, chain.SaveFullBlock(ctx, Block{
+		Height: 5,
+		Txs: []Tx{{
+			Data: []byte(`{}`),
+			Events: []Event{
+				{Type: "transfer", Attributes: []EventAttribute{{"sender", "cosmos1abc"}, {"amount", "10uatom"}}},
+				{Type: "message", Attributes: []EventAttribute{{"sender", "cosmos1def"}}},
+			},
+		}},
+		Events: []Event{
+			{Type: "coin_spent", Attributes: []EventAttribute{{"spender", "cosmos1abc"}}},
+		},
+	}))
+
+	q := NewQuery(db)
+
+	results, err := q.EventAttributes(ctx, tc.id, "sender", "cosmos1abc")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, chain.id, results[0].ChainPKey)
+	require.Equal(t, "chain-a", results[0].ChainID)
+	require.EqualValues(t, 5, results[0].Height)
+	require.True(t, results[0].TxID.Valid)
+	require.Equal(t, "transfer", results[0].EventType)
+	require.Equal(t, "sender", results[0].Key)
+	require.Equal(t, "cosmos1abc", results[0].Value)
+
+	results, err = q.EventAttributes(ctx, tc.id, "sender", "")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	results, err = q.EventAttributes(ctx, tc.id, "", "cosmos1abc")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "spender", results[1].Key)
+	require.False(t, results[1].TxID.Valid)
+
+	results, err = q.EventAttributes(ctx, tc.id+1, "sender", "")
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestQuery_Search(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	cosmos, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	dot, err := tc.AddChain(ctx, "chain-b", "polkadot")
+	require.NoError(t, err)
+
+	require.NoError(t, cosmos.SaveFullBlock(ctx, Block{
+		Height: 3,
+		Txs: []Tx{{
+			Data: []byte(`{"body":{"messages":[{"@type":"/ibc.applications.transfer.v1.MsgTransfer","sender":"cosmos1abc"}]}}`),
+			Events: []Event{
+				{Type: "send_packet", Attributes: []EventAttribute{{"packet_sequence", "5"}}},
+			},
+		}},
+		Events: []Event{
+			{Type: "send_packet", Attributes: []EventAttribute{{"packet_sequence", "6"}}},
+		},
+	}))
+	extrinsic, err := json.Marshal(SubstrateExtrinsic{Section: "Balances", Method: "transfer_keep_alive", Signer: "5Grw"})
+	require.NoError(t, err)
+	require.NoError(t, dot.SaveBlock(ctx, 4, []Tx{{Data: extrinsic}}))
+
+	q := NewQuery(db)
+
+	t.Run("event attribute", func(t *testing.T) {
+		results, err := q.Search(ctx, tc.id, "packet_sequence=5")
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, SearchKindEvent, results[0].Kind)
+		require.Equal(t, cosmos.id, results[0].ChainPKey)
+		require.EqualValues(t, 3, results[0].Height)
+		require.True(t, results[0].TxID.Valid)
+		require.Equal(t, "send_packet packet_sequence=5", results[0].Summary)
+
+		results, err = q.Search(ctx, tc.id, " packet_sequence = ")
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.False(t, results[1].TxID.Valid)
+	})
+
+	t.Run("message type and tx data", func(t *testing.T) {
+		results, err := q.Search(ctx, tc.id, "msgtransfer")
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		require.Equal(t, SearchKindTx, results[0].Kind)
+		require.Contains(t, results[0].Summary, "MsgTransfer")
+		require.Equal(t, SearchKindMessage, results[1].Kind)
+		require.Equal(t, "/ibc.applications.transfer.v1.MsgTransfer", results[1].Summary)
+		require.Equal(t, results[0].TxID, results[1].TxID)
+
+		// The decoded type is not in the tx data, which has separate section and method fields.
+		results, err = q.Search(ctx, tc.id, "Balances.transfer")
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, SearchKindMessage, results[0].Kind)
+		require.Equal(t, "chain-b", results[0].ChainID)
+		require.Equal(t, "Balances.transfer_keep_alive", results[0].Summary)
+	})
+
+	t.Run("like wildcards are literal", func(t *testing.T) {
+		results, err := q.Search(ctx, tc.id, "%")
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("empty term", func(t *testing.T) {
+		for _, term := range []string{"", " ", "=5"} {
+			results, err := q.Search(ctx, tc.id, term)
+			require.NoError(t, err)
+			require.Empty(t, results, term)
+		}
+	})
+}
+
+func TestExcerpt(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "short FOO text", excerpt("short FOO text", "foo"))
+	require.Equal(t, "…"+strings.Repeat("a", 30)+"foo"+strings.Repeat("b", 30)+"…", excerpt(strings.Repeat("a", 40)+"foo"+strings.Repeat("b", 40), "FOO"))
+	require.Empty(t, excerpt("nothing", "foo"))
+}
+
+func TestQuery_IBCPackets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := migratedDB()
+	defer db.Close()
+
+	tc, err := CreateTestCase(ctx, db, "test", "abc123")
+	require.NoError(t, err)
+	chainA, err := tc.AddChain(ctx, "chain-a", "cosmos")
+	require.NoError(t, err)
+	chainB, err := tc.AddChain(ctx, "chain-b", "cosmos")
+	require.NoError(t, err)
+
+	// Both chains use channel-0, so packets in each direction have the same port, channel and sequence.
+	packetEvent := func(typ string, seq int) Event {
+		return Event{Type: typ, Attributes: []EventAttribute{
+			{"packet_sequence", strconv.Itoa(seq)},
+			{"packet_src_port", "transfer"},
+			{"packet_src_channel", "channel-0"},
+			{"packet_dst_port", "transfer"},
+			{"packet_dst_channel", "channel-0"},
+		}}
+	}
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	saveBlock := func(chain *Chain, height int64, at time.Duration, events ...Event) {
+		require.NoError(t, chain.SaveFullBlock(ctx, Block{
+			Height: height,
+			Header: BlockHeader{Time: start.Add(at)},
+			Txs:    []Tx{{Data: []byte(`{}`), Events: events}},
+		}))
+	}
+
+	saveBlock(chainA, 3, 0, packetEvent("send_packet", 1))
+	saveBlock(chainB, 3, time.Second, packetEvent("send_packet", 1))
+	saveBlock(chainB, 4, 2*time.Second, packetEvent("recv_packet", 1), packetEvent("write_acknowledgement", 1))
+	saveBlock(chainA, 6, 5*time.Second, packetEvent("acknowledge_packet", 1), packetEvent("recv_packet", 1))
+	saveBlock(chainA, 7, 6*time.Second, packetEvent("send_packet", 2), packetEvent("send_packet", 3))
+	saveBlock(chainA, 9, 10*time.Second, packetEvent("timeout_packet", 3))
+	saveBlock(chainA, 20, 2*time.Minute)
+
+	results, err := NewQuery(db).IBCPackets(ctx, tc.id)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	acked := results[0]
+	require.Equal(t, "chain-a", acked.SrcChainID)
+	require.EqualValues(t, 1, acked.Sequence)
+	require.Equal(t, "transfer", acked.SrcPort)
+	require.Equal(t, "channel-0", acked.SrcChannel)
+	require.Equal(t, "chain-b", acked.DstChainID.String)
+	require.EqualValues(t, 3, acked.SendHeight)
+	require.EqualValues(t, 4, acked.RecvHeight.Int64)
+	require.EqualValues(t, 6, acked.AckHeight.Int64)
+	require.False(t, acked.TimeoutHeight.Valid)
+	require.Equal(t, PacketStateAcknowledged, acked.State())
+	require.True(t, acked.Complete())
+	require.False(t, acked.Stuck(DefaultStuckPacketTimeout))
+	d, ok := acked.RecvLatency()
+	require.True(t, ok)
+	require.Equal(t, 2*time.Second, d)
+	d, ok = acked.AckLatency()
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, d)
+	d, ok = acked.TotalLatency()
+	require.True(t, ok)
+	require.Equal(t, 5*time.Second, d)
+
+	stuck := results[1]
+	require.EqualValues(t, 2, stuck.Sequence)
+	require.False(t, stuck.DstChainID.Valid)
+	require.Equal(t, PacketStateSent, stuck.State())
+	require.True(t, stuck.Stuck(DefaultStuckPacketTimeout))
+	require.False(t, stuck.Stuck(time.Hour))
+	_, ok = stuck.RecvLatency()
+	require.False(t, ok)
+
+	timedOut := results[2]
+	require.EqualValues(t, 3, timedOut.Sequence)
+	require.Equal(t, PacketStateTimedOut, timedOut.State())
+	require.False(t, timedOut.Stuck(DefaultStuckPacketTimeout))
+	d, ok = timedOut.TotalLatency()
+	require.True(t, ok)
+	require.Equal(t, 4*time.Second, d)
+
+	// Received, but not acknowledged, by the other direction.
+	received := results[3]
+	require.Equal(t, "chain-b", received.SrcChainID)
+	require.EqualValues(t, 1, received.Sequence)
+	require.Equal(t, "chain-a", received.DstChainID.String)
+	require.EqualValues(t, 6, received.RecvHeight.Int64)
+	require.Equal(t, PacketStateReceived, received.State())
+	// Chain B stopped producing blocks shortly after sending.
+	require.False(t, received.Stuck(DefaultStuckPacketTimeout))
+}
@@ -0,0 +1,69 @@
+package blockdb
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Exec is a command executed in a container or by a relayer during a test.
+type Exec struct {
+	// One of ExecKindContainer or ExecKindRelayer.
+	Kind string
+
+	// Empty if the command did not run in a container, e.g. an in-process relayer.
+	ContainerName string
+
+	Command []string
+
+	Stdout, Stderr string
+	ExitCode       int
+
+	// Error is set if the command failed to execute.
+	Error string
+
+	StartedAt, FinishedAt time.Time
+}
+
+// Values of Exec.Kind.
+const (
+	ExecKindContainer = "container"
+	ExecKindRelayer   = "relayer"
+)
+
+// SaveExec tracks a command executed during the test case.
+//
+// Relayers typically run their commands in containers, so the same command may be saved
+// as both a container and a relayer exec. Execs are deduplicated by container name,
+// with the relayer kind taking precedence.
+func (tc *TestCase) SaveExec(ctx context.Context, exec Exec) error {
+	_, err := tc.db.ExecContext(ctx, tc.dialect.rebind(`INSERT INTO exec(
+    kind, container_name, command, stdout, stderr, exit_code, error, started_at, finished_at, fk_test_id
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(container_name) DO UPDATE SET kind = CASE WHEN excluded.kind = 'relayer' THEN 'relayer' ELSE exec.kind END`),
+		exec.Kind, nullString(exec.ContainerName), strings.Join(exec.Command, " "),
+		exec.Stdout, exec.Stderr, exec.ExitCode, nullString(exec.Error),
+		formatTime(exec.StartedAt), formatTime(exec.FinishedAt), tc.id,
+	)
+	return err
+}
+
+// TestEvent is a notable event in the lifecycle of a test, such as a test starting, failing, or finishing.
+type TestEvent struct {
+	// E.g. BeginTest, FinishTest, TestError.
+	Type string
+
+	// Name of the test or subtest.
+	Name string
+
+	Message string
+	When    time.Time
+}
+
+// SaveTestEvent tracks an event for the test case or one of its subtests.
+func (tc *TestCase) SaveTestEvent(ctx context.Context, event TestEvent) error {
+	_, err := tc.db.ExecContext(ctx, tc.dialect.rebind(`INSERT INTO test_event(type, name, message, created_at, fk_test_id) VALUES (?, ?, ?, ?, ?)`),
+		event.Type, event.Name, event.Message, formatTime(event.When), tc.id,
+	)
+	return err
+}
@@ -40,8 +40,15 @@ var (
 	}
 
 	keyMap = map[mainContent][]keyBinding{
-		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "cosmos messages"}, {"enter", "view txs"}}, tableNavKeys),
+		testCasesMain:      bindingsWithBase([]keyBinding{{"m", "messages"}, {"t", "timeline"}, {"p", "ibc packets"}, {"/", "search"}, {"enter", "view txs"}}, tableNavKeys),
 		cosmosMessagesMain: bindingsWithBase(tableNavKeys),
+		timelineMain:       bindingsWithBase(tableNavKeys),
+		chainMessagesMain:  bindingsWithBase(tableNavKeys),
+		packetsMain:        bindingsWithBase(tableNavKeys),
+		searchMain: bindingsWithBase([]keyBinding{
+			{"enter", "search or go to tx"},
+			{"/", "edit search"},
+		}, tableNavKeys),
 		txDetailMain: bindingsWithBase([]keyBinding{
 			{"[", "previous tx"},
 			{"]", "next tx"},
@@ -19,6 +19,10 @@ const (
 	cosmosMessagesMain
 	txDetailMain
 	errorModalMain
+	timelineMain
+	chainMessagesMain
+	searchMain
+	packetsMain
 )
 
 type mainStack []mainContent
@@ -30,7 +34,11 @@ func (stack mainStack) Pop() []mainContent               { return stack[:len(sta
 // QueryService fetches data from a database.
 type QueryService interface {
 	CosmosMessages(ctx context.Context, chainPkey int64) ([]blockdb.CosmosMessageResult, error)
+	ChainMessages(ctx context.Context, chainPkey int64) ([]blockdb.ChainMessageResult, error)
 	Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error)
+	Timeline(ctx context.Context, testCaseID int64) ([]blockdb.TimelineResult, error)
+	Search(ctx context.Context, testCaseID int64, term string) ([]blockdb.SearchResult, error)
+	IBCPackets(ctx context.Context, testCaseID int64) ([]blockdb.IBCPacketResult, error)
 }
 
 // Model encapsulates state that updates a view.
@@ -60,7 +68,7 @@ func NewModel(
 ) *Model {
 	m := &Model{
 		querySvc:      querySvc,
-		databasePath:  databasePath,
+		databasePath:  blockdb.RedactDSN(databasePath),
 		schemaVersion: schemaVersion,
 		schemaDate:    schemaDate,
 		testCases:     testCases,
@@ -0,0 +1,35 @@
+package presenter
+
+import (
+	"strconv"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+)
+
+// ChainMessage presents a blockdb.ChainMessageResult.
+type ChainMessage struct {
+	Result blockdb.ChainMessageResult
+}
+
+func (msg ChainMessage) Height() string { return strconv.FormatInt(msg.Result.Height, 10) }
+
+// Tx is the tx hash, shortened to fit a table column.
+func (msg ChainMessage) Tx() string {
+	const maxLen = 16
+	hash := msg.Result.TxHash.String
+	if len(hash) <= maxLen {
+		return hash
+	}
+	return hash[:maxLen/2] + "…" + hash[len(hash)-maxLen/2:]
+}
+
+// Index is the message's ordered position within the tx, or the block for substrate extrinsics.
+func (msg ChainMessage) Index() string { return strconv.Itoa(msg.Result.Index) }
+
+// Type is e.g. Balances.transfer_keep_alive, call, log, input, or output.
+func (msg ChainMessage) Type() string { return msg.Result.Type }
+
+func (msg ChainMessage) Sender() string    { return msg.Result.Sender.String }
+func (msg ChainMessage) Recipient() string { return msg.Result.Recipient.String }
+func (msg ChainMessage) Amount() string    { return msg.Result.Amount.String }
+func (msg ChainMessage) Detail() string    { return msg.Result.Detail.String }
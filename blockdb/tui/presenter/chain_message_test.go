@@ -0,0 +1,50 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		res := blockdb.ChainMessageResult{
+			Height:    12,
+			TxHash:    sql.NullString{String: "ABC", Valid: true},
+			Index:     2,
+			Type:      "output",
+			Recipient: sql.NullString{String: "bcrt1qaddr", Valid: true},
+			Amount:    sql.NullString{String: "0.5", Valid: true},
+			Detail:    sql.NullString{String: "witness_v0_keyhash", Valid: true},
+		}
+		pres := ChainMessage{res}
+
+		require.Equal(t, "12", pres.Height())
+		require.Equal(t, "ABC", pres.Tx())
+		require.Equal(t, "2", pres.Index())
+		require.Equal(t, "output", pres.Type())
+		require.Empty(t, pres.Sender())
+		require.Equal(t, "bcrt1qaddr", pres.Recipient())
+		require.Equal(t, "0.5", pres.Amount())
+		require.Equal(t, "witness_v0_keyhash", pres.Detail())
+	})
+
+	t.Run("long tx hash", func(t *testing.T) {
+		res := blockdb.ChainMessageResult{
+			TxHash: sql.NullString{String: "0x8b4e5c0bd1f0f6b1f3c9c63bd5b7e0a4f4a1ec5e6df06e2b1e8f5a7a2d3c4b5a", Valid: true},
+		}
+		pres := ChainMessage{res}
+
+		require.Equal(t, "0x8b4e5c…2d3c4b5a", pres.Tx())
+	})
+
+	t.Run("no tx hash", func(t *testing.T) {
+		pres := ChainMessage{blockdb.ChainMessageResult{Type: "Timestamp.set"}}
+
+		require.Empty(t, pres.Tx())
+	})
+}
@@ -0,0 +1,69 @@
+package presenter
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+)
+
+// IBCPacket presents a blockdb.IBCPacketResult.
+type IBCPacket struct {
+	Result blockdb.IBCPacketResult
+}
+
+func (p IBCPacket) Sequence() string { return strconv.FormatInt(p.Result.Sequence, 10) }
+
+// Source is the sending chain, port and channel, e.g. gaia-1 transfer/channel-0
+func (p IBCPacket) Source() string {
+	return strings.TrimSpace(p.Result.SrcChainID + " " + p.Result.SrcPort + "/" + p.Result.SrcChannel)
+}
+
+// Destination is the receiving chain, port and channel. The chain is unknown until the packet is received.
+func (p IBCPacket) Destination() string {
+	return strings.TrimSpace(p.Result.DstChainID.String + " " + p.Result.DstPort + "/" + p.Result.DstChannel)
+}
+
+// State is the latest step of the packet's lifecycle, flagging packets stuck for longer than blockdb.DefaultStuckPacketTimeout.
+func (p IBCPacket) State() string {
+	if p.Result.Stuck(blockdb.DefaultStuckPacketTimeout) {
+		return p.Result.State() + " (stuck)"
+	}
+	return p.Result.State()
+}
+
+func (p IBCPacket) Sent() string { return strconv.FormatInt(p.Result.SendHeight, 10) }
+
+// Received is the height receiving the packet, with the latency since it was sent, e.g. 12 (+2.5s)
+func (p IBCPacket) Received() string {
+	d, ok := p.Result.RecvLatency()
+	return heightWithLatency(p.Result.RecvHeight, d, ok)
+}
+
+// Acknowledged is the height acknowledging the packet, with the latency since it was received.
+func (p IBCPacket) Acknowledged() string {
+	d, ok := p.Result.AckLatency()
+	return heightWithLatency(p.Result.AckHeight, d, ok)
+}
+
+// TimedOut is the height timing out the packet, with the latency since it was sent.
+func (p IBCPacket) TimedOut() string {
+	if !p.Result.TimeoutHeight.Valid {
+		return ""
+	}
+	d, ok := p.Result.TotalLatency()
+	return heightWithLatency(p.Result.TimeoutHeight, d, ok)
+}
+
+func heightWithLatency(height sql.NullInt64, d time.Duration, ok bool) string {
+	if !height.Valid {
+		return ""
+	}
+	s := strconv.FormatInt(height.Int64, 10)
+	if ok {
+		s += " (+" + d.Round(time.Millisecond).String() + ")"
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIBCPacket(t *testing.T) {
+	t.Parallel()
+
+	sent := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	nullTime := func(d time.Duration) sql.NullTime { return sql.NullTime{Time: sent.Add(d), Valid: true} }
+
+	t.Run("acknowledged", func(t *testing.T) {
+		pres := IBCPacket{blockdb.IBCPacketResult{
+			Sequence:   7,
+			SrcChainID: "chain-a",
+			SrcPort:    "transfer",
+			SrcChannel: "channel-0",
+			DstChainID: sql.NullString{String: "chain-b", Valid: true},
+			DstPort:    "transfer",
+			DstChannel: "channel-1",
+			SendHeight: 10,
+			SendTime:   nullTime(0),
+			RecvHeight: sql.NullInt64{Int64: 12, Valid: true},
+			RecvTime:   nullTime(2500 * time.Millisecond),
+			AckHeight:  sql.NullInt64{Int64: 14, Valid: true},
+			AckTime:    nullTime(4 * time.Second),
+		}}
+
+		require.Equal(t, "7", pres.Sequence())
+		require.Equal(t, "chain-a transfer/channel-0", pres.Source())
+		require.Equal(t, "chain-b transfer/channel-1", pres.Destination())
+		require.Equal(t, "acknowledged", pres.State())
+		require.Equal(t, "10", pres.Sent())
+		require.Equal(t, "12 (+2.5s)", pres.Received())
+		require.Equal(t, "14 (+1.5s)", pres.Acknowledged())
+		require.Empty(t, pres.TimedOut())
+	})
+
+	t.Run("stuck", func(t *testing.T) {
+		pres := IBCPacket{blockdb.IBCPacketResult{
+			SrcChainID:       "chain-a",
+			SrcPort:          "transfer",
+			SrcChannel:       "channel-0",
+			DstPort:          "transfer",
+			DstChannel:       "channel-1",
+			SendHeight:       10,
+			SendTime:         nullTime(0),
+			SrcLastBlockTime: nullTime(time.Hour),
+		}}
+
+		require.Equal(t, "transfer/channel-1", pres.Destination())
+		require.Equal(t, "sent (stuck)", pres.State())
+		require.Empty(t, pres.Received())
+		require.Empty(t, pres.Acknowledged())
+	})
+
+	t.Run("timed out without block times", func(t *testing.T) {
+		pres := IBCPacket{blockdb.IBCPacketResult{
+			SendHeight:    10,
+			TimeoutHeight: sql.NullInt64{Int64: 30, Valid: true},
+		}}
+
+		require.Equal(t, "timed out", pres.State())
+		require.Equal(t, "30", pres.TimedOut())
+	})
+}
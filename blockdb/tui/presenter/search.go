@@ -0,0 +1,25 @@
+package presenter
+
+import (
+	"strconv"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+)
+
+// SearchResult presents a blockdb.SearchResult.
+type SearchResult struct {
+	Result blockdb.SearchResult
+}
+
+func (p SearchResult) ChainID() string { return p.Result.ChainID }
+func (p SearchResult) Height() string  { return strconv.FormatInt(p.Result.Height, 10) }
+func (p SearchResult) Kind() string    { return p.Result.Kind }
+func (p SearchResult) Summary() string { return p.Result.Summary }
+
+// Tx is the tx primary key, or "block" for block events which do not belong to a tx.
+func (p SearchResult) Tx() string {
+	if !p.Result.TxID.Valid {
+		return "block"
+	}
+	return strconv.FormatInt(p.Result.TxID.Int64, 10)
+}
@@ -0,0 +1,31 @@
+package presenter
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchResult(t *testing.T) {
+	t.Parallel()
+
+	res := blockdb.SearchResult{
+		Kind:    blockdb.SearchKindEvent,
+		ChainID: "chain-a",
+		Height:  12,
+		TxID:    sql.NullInt64{Int64: 34, Valid: true},
+		Summary: "send_packet packet_sequence=5",
+	}
+	pres := SearchResult{res}
+
+	require.Equal(t, "chain-a", pres.ChainID())
+	require.Equal(t, "12", pres.Height())
+	require.Equal(t, "34", pres.Tx())
+	require.Equal(t, "event", pres.Kind())
+	require.Equal(t, "send_packet packet_sequence=5", pres.Summary())
+
+	res.TxID = sql.NullInt64{}
+	require.Equal(t, "block", SearchResult{res}.Tx())
+}
@@ -0,0 +1,17 @@
+package presenter
+
+import (
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+)
+
+// Timeline presents a blockdb.TimelineResult.
+type Timeline struct {
+	Result blockdb.TimelineResult
+}
+
+// Time is precise to the millisecond, as many events may occur within the same second.
+func (p Timeline) Time() string    { return p.Result.At.Format("15:04:05.000") }
+func (p Timeline) Kind() string    { return p.Result.Kind }
+func (p Timeline) Source() string  { return p.Result.Source }
+func (p Timeline) Summary() string { return p.Result.Summary }
+func (p Timeline) Detail() string  { return p.Result.Detail }
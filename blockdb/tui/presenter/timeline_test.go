@@ -0,0 +1,28 @@
+package presenter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline(t *testing.T) {
+	t.Parallel()
+
+	result := blockdb.TimelineResult{
+		At:      time.Date(2024, 1, 2, 15, 4, 5, int(123*time.Millisecond), time.Local),
+		Kind:    "relayer",
+		Source:  "rly-abc",
+		Summary: "rly tx flush",
+		Detail:  "exit code 0",
+	}
+
+	pres := Timeline{result}
+	require.Equal(t, "15:04:05.123", pres.Time())
+	require.Equal(t, "relayer", pres.Kind())
+	require.Equal(t, "rly-abc", pres.Source())
+	require.Equal(t, "rly tx flush", pres.Summary())
+	require.Equal(t, "exit code 0", pres.Detail())
+}
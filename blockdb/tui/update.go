@@ -7,6 +7,7 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v8/blockdb/tui/presenter"
 )
 
@@ -39,8 +40,17 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 			return nil
 
 		case event.Rune() == 'm' && m.stack.Current() == testCasesMain:
-			// Show cosmos messages.
+			// Show messages, decoded according to the chain type.
 			tc := m.testCases[m.selectedRow()]
+			if blockdb.HasChainMessages(tc.ChainType) {
+				results, err := m.querySvc.ChainMessages(ctx, tc.ChainPKey)
+				if err != nil {
+					m.pushErrorModal(fmt.Errorf("query chain messages: %w", err))
+					return nil
+				}
+				m.pushMainView(chainMessagesMain, chainMessagesView(tc, results))
+				return nil
+			}
 			results, err := m.querySvc.CosmosMessages(ctx, tc.ChainPKey)
 			if err != nil {
 				m.pushErrorModal(fmt.Errorf("query cosmos messages: %w", err))
@@ -49,6 +59,69 @@ func (m *Model) Update(ctx context.Context) func(event *tcell.EventKey) *tcell.E
 			m.pushMainView(cosmosMessagesMain, cosmosMessagesView(tc, results))
 			return nil
 
+		case event.Rune() == 't' && m.stack.Current() == testCasesMain:
+			// Show timeline of blocks, executions, and test events across all chains in the test case.
+			tc := m.testCases[m.selectedRow()]
+			results, err := m.querySvc.Timeline(ctx, tc.ID)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query timeline: %w", err))
+				return nil
+			}
+			m.pushMainView(timelineMain, timelineView(tc, results))
+			return nil
+
+		case event.Rune() == 'p' && m.stack.Current() == testCasesMain:
+			// Show the lifecycle of IBC packets across all chains in the test case.
+			tc := m.testCases[m.selectedRow()]
+			results, err := m.querySvc.IBCPackets(ctx, tc.ID)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query ibc packets: %w", err))
+				return nil
+			}
+			m.pushMainView(packetsMain, packetsView(tc, results))
+			return nil
+
+		case event.Rune() == '/' && m.stack.Current() == testCasesMain:
+			// Search across all chains of the test case.
+			tc := m.testCases[m.selectedRow()]
+			m.pushMainView(searchMain, newSearchView(tc))
+			return nil
+
+		case event.Rune() == '/' && m.stack.Current() == searchMain && !m.searchView().Input.HasFocus():
+			m.searchView().ActivateInput()
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == searchMain && m.searchView().Input.HasFocus():
+			view := m.searchView()
+			results, err := m.querySvc.Search(ctx, view.TestCase.ID, view.Input.GetText())
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("search: %w", err))
+				return nil
+			}
+			view.SetResults(results)
+			return nil
+
+		case event.Key() == tcell.KeyEnter && m.stack.Current() == searchMain:
+			// Jump to the tx of the selected search result.
+			res, ok := m.searchView().Selected()
+			if !ok {
+				return nil
+			}
+			txs, err := m.querySvc.Transactions(ctx, res.ChainPKey)
+			if err != nil {
+				m.pushErrorModal(fmt.Errorf("query transactions: %w", err))
+				return nil
+			}
+			idx := txIndex(txs, res)
+			if idx < 0 {
+				m.pushErrorModal(fmt.Errorf("no transactions at or after height %d", res.Height))
+				return nil
+			}
+			detail := newTxDetailView(res.ChainID, txs)
+			detail.Pages.SwitchToPage(strconv.Itoa(idx))
+			m.pushMainView(txDetailMain, detail)
+			return nil
+
 		case event.Rune() == '[' && m.stack.Current() == txDetailMain:
 			goToPrevPage(m.txDetailView().Pages)
 			return nil
@@ -111,6 +184,26 @@ func (m *Model) txDetailView() *txDetailView {
 	return primitive.(*txDetailView)
 }
 
+func (m *Model) searchView() *searchView {
+	_, primitive := m.mainContentView().GetFrontPage()
+	return primitive.(*searchView)
+}
+
+// txIndex returns the index of the search result's tx within txs.
+// Block events do not belong to a tx, so the result is the first tx at or after the block's height.
+// Returns -1 if there is no such tx.
+func txIndex(txs []blockdb.TxResult, res blockdb.SearchResult) int {
+	for i, tx := range txs {
+		if res.TxID.Valid && tx.ID == res.TxID.Int64 {
+			return i
+		}
+		if !res.TxID.Valid && tx.Height >= res.Height {
+			return i
+		}
+	}
+	return -1
+}
+
 // gotToNextPage assumes a convention where the page name is equal to its index. e.g. "0", "1", "2", etc.
 func gotToNextPage(pages *tview.Pages) {
 	idxStr, _ := pages.GetFrontPage()
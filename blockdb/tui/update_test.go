@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -29,10 +30,16 @@ func draw(view tview.Primitive) {
 }
 
 type mockQueryService struct {
-	GotChainPkey int64
-	Messages     []blockdb.CosmosMessageResult
-	Txs          []blockdb.TxResult
-	Err          error
+	GotChainPkey  int64
+	GotTestCaseID int64
+	Messages      []blockdb.CosmosMessageResult
+	ChainMsgs     []blockdb.ChainMessageResult
+	Txs           []blockdb.TxResult
+	Events        []blockdb.TimelineResult
+	GotTerm       string
+	Packets       []blockdb.IBCPacketResult
+	SearchResults []blockdb.SearchResult
+	Err           error
 }
 
 func (m *mockQueryService) Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error) {
@@ -51,6 +58,39 @@ func (m *mockQueryService) CosmosMessages(ctx context.Context, chainPkey int64)
 	return m.Messages, m.Err
 }
 
+func (m *mockQueryService) ChainMessages(ctx context.Context, chainPkey int64) ([]blockdb.ChainMessageResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotChainPkey = chainPkey
+	return m.ChainMsgs, m.Err
+}
+
+func (m *mockQueryService) Timeline(ctx context.Context, testCaseID int64) ([]blockdb.TimelineResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCaseID = testCaseID
+	return m.Events, m.Err
+}
+
+func (m *mockQueryService) Search(ctx context.Context, testCaseID int64, term string) ([]blockdb.SearchResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCaseID = testCaseID
+	m.GotTerm = term
+	return m.SearchResults, m.Err
+}
+
+func (m *mockQueryService) IBCPackets(ctx context.Context, testCaseID int64) ([]blockdb.IBCPacketResult, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotTestCaseID = testCaseID
+	return m.Packets, m.Err
+}
+
 func TestModel_Update(t *testing.T) {
 	ctx := context.Background()
 
@@ -95,6 +135,158 @@ func TestModel_Update(t *testing.T) {
 		require.Contains(t, table.(*tview.Table).GetTitle(), "my-chain1")
 	})
 
+	t.Run("chain messages view", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Messages: []blockdb.CosmosMessageResult{{Height: 1}},
+			ChainMsgs: []blockdb.ChainMessageResult{
+				{Height: 10, Type: "call"},
+				{Height: 10, Type: "log"},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ChainPKey: 7, ChainID: "my-evm", ChainType: "ethereum"},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('m'))
+
+		require.EqualValues(t, 7, querySvc.GotChainPkey)
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		_, table := model.mainContentView().GetFrontPage()
+
+		// 3 rows: 1 header + 2 blockdb.ChainMessageResult
+		require.Equal(t, 3, table.(*tview.Table).GetRowCount())
+		require.Contains(t, table.(*tview.Table).GetTitle(), "my-evm")
+	})
+
+	t.Run("ibc packets view", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Packets: []blockdb.IBCPacketResult{
+				{Sequence: 1, SrcChainID: "chain-a"},
+				{Sequence: 2, SrcChainID: "chain-a"},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, Name: "TestFoo", ChainPKey: 5},
+			{ID: 4, ChainPKey: 6},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('p'))
+
+		require.EqualValues(t, 3, querySvc.GotTestCaseID)
+		require.Equal(t, packetsMain, model.stack.Current())
+		_, table := model.mainContentView().GetFrontPage()
+
+		// 3 rows: 1 header + 2 blockdb.IBCPacketResult
+		require.Equal(t, 3, table.(*tview.Table).GetRowCount())
+		require.Contains(t, table.(*tview.Table).GetTitle(), "TestFoo")
+	})
+
+	t.Run("search", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			SearchResults: []blockdb.SearchResult{
+				{Kind: blockdb.SearchKindEvent, ChainPKey: 8, ChainID: "chain-b", Height: 3, TxID: sql.NullInt64{Int64: 22, Valid: true}},
+				{Kind: blockdb.SearchKindEvent, ChainPKey: 8, ChainID: "chain-b", Height: 4},
+			},
+			Txs: []blockdb.TxResult{
+				{ID: 21, Height: 3},
+				{ID: 22, Height: 3},
+				{ID: 23, Height: 5},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, Name: "TestFoo", ChainPKey: 5},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('/'))
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		view := model.searchView()
+		require.True(t, view.Input.HasFocus())
+
+		view.Input.SetText("packet_sequence=5")
+		update(enterKey)
+
+		require.EqualValues(t, 3, querySvc.GotTestCaseID)
+		require.Equal(t, "packet_sequence=5", querySvc.GotTerm)
+		require.False(t, view.Input.HasFocus())
+		// 3 rows: 1 header + 2 blockdb.SearchResult
+		require.Equal(t, 3, view.Results.GetRowCount())
+
+		// Jump to the tx of the first result.
+		draw(model.RootView())
+		update(enterKey)
+
+		require.EqualValues(t, 8, querySvc.GotChainPkey)
+		require.Equal(t, 3, model.mainContentView().GetPageCount())
+		idx, _ := model.txDetailView().Pages.GetFrontPage()
+		require.Equal(t, "1", idx)
+
+		// Block events jump to the first tx at or after the block's height.
+		update(escKey)
+		view.Results.Select(2, 0)
+		update(enterKey)
+		idx, _ = model.txDetailView().Pages.GetFrontPage()
+		require.Equal(t, "2", idx)
+
+		// Edit the search again.
+		update(escKey)
+		update(runeKey('/'))
+		require.True(t, view.Input.HasFocus())
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		querySvc := &mockQueryService{Err: errors.New("boom")}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{{ID: 3}})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('/'))
+		update(enterKey)
+
+		require.Equal(t, 3, model.mainContentView().GetPageCount())
+		require.Equal(t, errorModalMain, model.stack.Current())
+	})
+
+	t.Run("timeline view", func(t *testing.T) {
+		querySvc := &mockQueryService{
+			Events: []blockdb.TimelineResult{
+				{Kind: "test", Summary: "BeginTest"},
+				{Kind: "block", Summary: "height 1"},
+			},
+		}
+		model := NewModel(querySvc, "", "", time.Now(), []blockdb.TestCaseResult{
+			{ID: 3, Name: "TestFoo", ChainPKey: 5},
+			{ID: 4, ChainPKey: 6},
+		})
+
+		draw(model.RootView())
+
+		update := model.Update(ctx)
+		update(runeKey('t'))
+
+		// By default, first row is selected in a rendered table.
+		require.EqualValues(t, 3, querySvc.GotTestCaseID)
+
+		require.Equal(t, 2, model.mainContentView().GetPageCount())
+		_, table := model.mainContentView().GetFrontPage()
+
+		// 3 rows: 1 header + 2 blockdb.TimelineResult
+		require.Equal(t, 3, table.(*tview.Table).GetRowCount())
+		require.Contains(t, table.(*tview.Table).GetTitle(), "TestFoo")
+	})
+
 	t.Run("tx detail", func(t *testing.T) {
 		querySvc := &mockQueryService{
 			Txs: []blockdb.TxResult{
@@ -150,6 +150,183 @@ func cosmosMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.CosmosMessageR
 	return detailTableView(title, headers, rows)
 }
 
+func chainMessagesView(tc blockdb.TestCaseResult, msgs []blockdb.ChainMessageResult) *tview.Table {
+	headers := []string{
+		"Height",
+		"Tx",
+		"Index",
+		"Type",
+		"Sender",
+		"Recipient",
+		"Amount",
+		"Detail",
+	}
+
+	rows := make([][]string, len(msgs))
+	for i, msg := range msgs {
+		pres := presenter.ChainMessage{Result: msg}
+		rows[i] = []string{
+			pres.Height(),
+			pres.Tx(),
+			pres.Index(),
+			pres.Type(),
+			pres.Sender(),
+			pres.Recipient(),
+			pres.Amount(),
+			pres.Detail(),
+		}
+	}
+
+	title := fmt.Sprintf("%s [%s]", tc.ChainID, presenter.FormatTime(tc.CreatedAt))
+	return detailTableView(title, headers, rows)
+}
+
+func packetsView(tc blockdb.TestCaseResult, packets []blockdb.IBCPacketResult) *tview.Table {
+	headers := []string{
+		"Source",
+		"Sequence",
+		"Destination",
+		"State",
+		"Sent",
+		"Received",
+		"Acknowledged",
+		"Timed Out",
+	}
+
+	rows := make([][]string, len(packets))
+	for i, packet := range packets {
+		pres := presenter.IBCPacket{Result: packet}
+		rows[i] = []string{
+			pres.Source(),
+			pres.Sequence(),
+			pres.Destination(),
+			pres.State(),
+			pres.Sent(),
+			pres.Received(),
+			pres.Acknowledged(),
+			pres.TimedOut(),
+		}
+	}
+
+	title := fmt.Sprintf("%s IBC Packets [%s]", tc.Name, presenter.FormatTime(tc.CreatedAt))
+	return detailTableView(title, headers, rows)
+}
+
+func timelineView(tc blockdb.TestCaseResult, events []blockdb.TimelineResult) *tview.Table {
+	headers := []string{
+		"Time",
+		"Kind",
+		"Source",
+		"Summary",
+		"Detail",
+	}
+
+	rows := make([][]string, len(events))
+	for i, event := range events {
+		pres := presenter.Timeline{Result: event}
+		rows[i] = []string{
+			pres.Time(),
+			pres.Kind(),
+			pres.Source(),
+			pres.Summary(),
+			pres.Detail(),
+		}
+	}
+
+	title := fmt.Sprintf("%s Timeline [%s]", tc.Name, presenter.FormatTime(tc.CreatedAt))
+	return detailTableView(title, headers, rows)
+}
+
+// searchView searches the transactions, messages and event attributes of all chains in a test case.
+type searchView struct {
+	*tview.Flex
+
+	TestCase blockdb.TestCaseResult
+	Input    *tview.InputField
+	Results  *tview.Table
+
+	results []blockdb.SearchResult
+}
+
+func newSearchView(tc blockdb.TestCaseResult) *searchView {
+	view := &searchView{TestCase: tc}
+
+	view.Input = tview.NewInputField().
+		SetFieldTextColor(searchActiveColor).
+		SetFieldBackgroundColor(backgroundColor).
+		SetPlaceholder("e.g. packet_sequence=5, sender=cosmos1..., or MsgTransfer").
+		SetPlaceholderTextColor(searchInactiveColor)
+	view.Input.SetTitle(fmt.Sprintf("Search %s", tc.Name)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorder(true).
+		SetBorderAttributes(tcell.AttrDim)
+
+	view.Results = view.resultsTable()
+
+	view.Flex = tview.NewFlex().SetDirection(tview.FlexRow)
+	view.Flex.SetBorder(false)
+	view.Flex.AddItem(view.Input, 3, 1, true)
+	view.Flex.AddItem(view.Results, 0, 9, false)
+
+	view.ActivateInput()
+	return view
+}
+
+func (view *searchView) resultsTable() *tview.Table {
+	headers := []string{
+		"Chain",
+		"Height",
+		"Tx",
+		"Kind",
+		"Summary",
+	}
+
+	rows := make([][]string, len(view.results))
+	for i, res := range view.results {
+		pres := presenter.SearchResult{Result: res}
+		rows[i] = []string{
+			pres.ChainID(),
+			pres.Height(),
+			pres.Tx(),
+			pres.Kind(),
+			pres.Summary(),
+		}
+	}
+
+	title := fmt.Sprintf("%d Results [%s]", len(view.results), presenter.FormatTime(view.TestCase.CreatedAt))
+	return detailTableView(title, headers, rows)
+}
+
+// SetResults replaces the results table and moves focus to it, so that results can be selected.
+func (view *searchView) SetResults(results []blockdb.SearchResult) {
+	view.results = results
+	view.Flex.RemoveItem(view.Results)
+	view.Results = view.resultsTable()
+	view.Flex.AddItem(view.Results, 0, 9, false)
+
+	view.Input.SetFieldTextColor(searchInactiveColor)
+	view.Input.Blur()
+	view.Results.Focus(nil)
+}
+
+// ActivateInput moves focus to the search input.
+func (view *searchView) ActivateInput() {
+	view.Input.SetFieldTextColor(searchActiveColor)
+	view.Results.Blur()
+	view.Input.Focus(nil)
+}
+
+// Selected returns the selected search result, if any.
+func (view *searchView) Selected() (blockdb.SearchResult, bool) {
+	row, _ := view.Results.GetSelection()
+	// Offset by 1 to account for header row.
+	i := row - 1
+	if i < 0 || i >= len(view.results) {
+		return blockdb.SearchResult{}, false
+	}
+	return view.results[i], true
+}
+
 func errorModalView(err error) *tview.Flex {
 	modal := tview.NewModal().
 		SetText(fmt.Sprintf("Error: %v", err)).
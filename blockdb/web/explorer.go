@@ -0,0 +1,429 @@
+// Package web serves a read-only block explorer over a blockdb database.
+package web
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb/tui/presenter"
+)
+
+// QueryService fetches data from a database.
+type QueryService interface {
+	RecentTestCases(ctx context.Context, limit int) ([]blockdb.TestCaseResult, error)
+	TestCase(ctx context.Context, testCaseID int64) ([]blockdb.TestCaseResult, error)
+	Blocks(ctx context.Context, chainPkey int64) ([]blockdb.BlockResult, error)
+	Transactions(ctx context.Context, chainPkey int64) ([]blockdb.TxResult, error)
+	CosmosMessages(ctx context.Context, chainPkey int64) ([]blockdb.CosmosMessageResult, error)
+	ChainMessages(ctx context.Context, chainPkey int64) ([]blockdb.ChainMessageResult, error)
+	EventAttributes(ctx context.Context, testCaseID int64, key, value string) ([]blockdb.EventAttributeResult, error)
+	IBCPackets(ctx context.Context, testCaseID int64) ([]blockdb.IBCPacketResult, error)
+}
+
+// recentTestCasesLimit matches the number of test cases shown by the terminal UI.
+const recentTestCasesLimit = 100
+
+//go:embed explorer.html
+var templateFS embed.FS
+
+var pageTemplate = template.Must(template.ParseFS(templateFS, "explorer.html"))
+
+// Explorer is an http.Handler serving HTML pages over a blockdb database.
+// Every page is also available as JSON, by adding the query parameter format=json.
+type Explorer struct {
+	querySvc     QueryService
+	databasePath string
+	mux          *http.ServeMux
+}
+
+// NewExplorer returns a valid *Explorer.
+// The databasePath is only displayed in page headers, with any password redacted.
+func NewExplorer(querySvc QueryService, databasePath string) *Explorer {
+	e := &Explorer{
+		querySvc:     querySvc,
+		databasePath: blockdb.RedactDSN(databasePath),
+		mux:          http.NewServeMux(),
+	}
+
+	e.handle("GET /{$}", e.testCases)
+	e.handle("GET /test-cases/{id}", e.testCase)
+	e.handle("GET /test-cases/{id}/events", e.events)
+	e.handle("GET /test-cases/{id}/packets", e.packets)
+	e.handle("GET /test-cases/{id}/chains/{chain}/blocks", e.blocks)
+	e.handle("GET /test-cases/{id}/chains/{chain}/txs", e.txs)
+	e.handle("GET /test-cases/{id}/chains/{chain}/messages", e.messages)
+
+	return e
+}
+
+// ServeHTTP implements http.Handler.
+func (e *Explorer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mux.ServeHTTP(w, r)
+}
+
+var errNotFound = errors.New("not found")
+
+func (e *Explorer) handle(pattern string, fn func(r *http.Request) (*page, error)) {
+	e.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		p, err := fn(r)
+		switch {
+		case errors.Is(err, errNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if r.URL.Query().Get("format") == "json" {
+			e.writeJSON(w, p)
+			return
+		}
+
+		p.DatabasePath = e.databasePath
+		p.JSONURL = jsonURL(r)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := pageTemplate.Execute(w, p); err != nil {
+			// Too late to change the status code; the page is already partially written.
+			fmt.Fprintf(w, "render page: %v", err)
+		}
+	})
+}
+
+func (e *Explorer) writeJSON(w http.ResponseWriter, p *page) {
+	bz := p.json
+	if bz == nil {
+		objs := make([]map[string]string, len(p.Rows))
+		for i, row := range p.Rows {
+			obj := make(map[string]string, len(row))
+			for col, c := range row {
+				obj[jsonKey(p.Headers[col])] = c.Text
+			}
+			objs[i] = obj
+		}
+		var err error
+		if bz, err = json.Marshal(objs); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", p.filename+".json"))
+	_, _ = w.Write(bz)
+}
+
+// jsonKey converts a table header to a JSON object key, e.g. "Tx Total" to "tx_total".
+func jsonKey(header string) string {
+	return strings.ReplaceAll(strings.ToLower(header), " ", "_")
+}
+
+func jsonURL(r *http.Request) string {
+	q := r.URL.Query()
+	q.Set("format", "json")
+	return r.URL.Path + "?" + q.Encode()
+}
+
+// page is the data rendered by the template, a table with optional navigation and search.
+type page struct {
+	Title        string
+	DatabasePath string
+	JSONURL      string
+
+	Links  []link
+	Search *searchForm
+
+	Headers []string
+	Rows    [][]cell
+
+	// filename is the name of the JSON download, without the extension.
+	filename string
+	// json replaces the table rows as the JSON representation of the page, if set.
+	json []byte
+}
+
+type link struct {
+	Text, Href string
+}
+
+type cell struct {
+	Text string
+	Href string // Optional.
+	Pre  bool   // Render preformatted, e.g. for indented JSON.
+}
+
+type searchForm struct {
+	Action     string
+	Key, Value string
+}
+
+func textCells(texts ...string) []cell {
+	cells := make([]cell, len(texts))
+	for i, text := range texts {
+		cells[i] = cell{Text: text}
+	}
+	return cells
+}
+
+func (e *Explorer) testCases(r *http.Request) (*page, error) {
+	results, err := e.querySvc.RecentTestCases(r.Context(), recentTestCasesLimit)
+	if err != nil {
+		return nil, fmt.Errorf("query recent test cases: %w", err)
+	}
+
+	p := &page{
+		Title:    "Test Cases",
+		Headers:  []string{"ID", "Date", "Name", "Git Sha", "Chain", "Type", "Height", "Tx Total"},
+		filename: "test-cases",
+	}
+	for _, tc := range results {
+		pres := presenter.TestCase{Result: tc}
+		row := textCells(pres.ID(), pres.Date(), pres.Name(), pres.GitSha(), pres.ChainID(), tc.ChainType, pres.Height(), pres.TxTotal())
+		row[0].Href = testCaseURL(tc.ID)
+		row[4].Href = chainURL(tc, "blocks")
+		p.Rows = append(p.Rows, row)
+	}
+	return p, nil
+}
+
+func (e *Explorer) testCase(r *http.Request) (*page, error) {
+	chains, err := e.testCaseChains(r)
+	if err != nil {
+		return nil, err
+	}
+	tc := chains[0]
+
+	p := &page{
+		Title:    fmt.Sprintf("%s [%s]", tc.Name, presenter.FormatTime(tc.CreatedAt)),
+		Links:    testCaseLinks(tc),
+		Search:   &searchForm{Action: testCaseURL(tc.ID) + "/events"},
+		Headers:  []string{"Chain", "Type", "Height", "Tx Total", "Blocks", "Txs", "Messages"},
+		filename: fmt.Sprintf("test-case-%d", tc.ID),
+	}
+	for _, c := range chains {
+		pres := presenter.TestCase{Result: c}
+		p.Rows = append(p.Rows, []cell{
+			{Text: c.ChainID},
+			{Text: c.ChainType},
+			{Text: pres.Height()},
+			{Text: pres.TxTotal()},
+			{Text: "blocks", Href: chainURL(c, "blocks")},
+			{Text: "txs", Href: chainURL(c, "txs")},
+			{Text: "messages", Href: chainURL(c, "messages")},
+		})
+	}
+	return p, nil
+}
+
+func (e *Explorer) blocks(r *http.Request) (*page, error) {
+	chain, err := e.chain(r)
+	if err != nil {
+		return nil, err
+	}
+	results, err := e.querySvc.Blocks(r.Context(), chain.ChainPKey)
+	if err != nil {
+		return nil, fmt.Errorf("query blocks: %w", err)
+	}
+
+	p := chainPage(chain, "Blocks", "Height", "Time", "Hash", "Proposer", "Gas Used", "Gas Wanted", "Tx Total")
+	for _, b := range results {
+		var blockTime string
+		if b.Time.Valid {
+			blockTime = presenter.FormatTime(b.Time.Time)
+		}
+		p.Rows = append(p.Rows, textCells(
+			strconv.FormatInt(b.Height, 10),
+			blockTime,
+			b.Hash.String,
+			b.ProposerAddress.String,
+			strconv.FormatInt(b.GasUsed, 10),
+			strconv.FormatInt(b.GasWanted, 10),
+			strconv.FormatInt(b.TxTotal, 10),
+		))
+	}
+	return p, nil
+}
+
+func (e *Explorer) txs(r *http.Request) (*page, error) {
+	chain, err := e.chain(r)
+	if err != nil {
+		return nil, err
+	}
+	results, err := e.querySvc.Transactions(r.Context(), chain.ChainPKey)
+	if err != nil {
+		return nil, fmt.Errorf("query transactions: %w", err)
+	}
+
+	p := chainPage(chain, "Transactions", "Height", "Tx")
+	for _, tx := range results {
+		pres := presenter.Tx{Result: tx}
+		p.Rows = append(p.Rows, []cell{{Text: pres.Height()}, {Text: pres.Data(), Pre: true}})
+	}
+	p.json = presenter.Txs(results).ToJSON()
+	return p, nil
+}
+
+func (e *Explorer) messages(r *http.Request) (*page, error) {
+	chain, err := e.chain(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if blockdb.HasChainMessages(chain.ChainType) {
+		results, err := e.querySvc.ChainMessages(r.Context(), chain.ChainPKey)
+		if err != nil {
+			return nil, fmt.Errorf("query chain messages: %w", err)
+		}
+		p := chainPage(chain, "Messages", "Height", "Tx", "Index", "Type", "Sender", "Recipient", "Amount", "Detail")
+		for _, msg := range results {
+			pres := presenter.ChainMessage{Result: msg}
+			row := textCells(pres.Height(), pres.Tx(), pres.Index(), pres.Type(), pres.Sender(), pres.Recipient(), pres.Amount(), pres.Detail())
+			// Show the full tx hash, since the page is not limited to the width of a terminal.
+			row[1].Text = msg.TxHash.String
+			p.Rows = append(p.Rows, row)
+		}
+		return p, nil
+	}
+
+	results, err := e.querySvc.CosmosMessages(r.Context(), chain.ChainPKey)
+	if err != nil {
+		return nil, fmt.Errorf("query cosmos messages: %w", err)
+	}
+	p := chainPage(chain, "Messages", "Height", "Index", "Type", "Client Chain", "Client", "Connection", "Channel:Port")
+	for _, msg := range results {
+		pres := presenter.CosmosMessage{Result: msg}
+		p.Rows = append(p.Rows, textCells(pres.Height(), pres.Index(), pres.Type(), pres.ClientChain(), pres.Clients(), pres.Connections(), pres.Channels()))
+	}
+	return p, nil
+}
+
+func (e *Explorer) events(r *http.Request) (*page, error) {
+	chains, err := e.testCaseChains(r)
+	if err != nil {
+		return nil, err
+	}
+	tc := chains[0]
+
+	key, value := r.URL.Query().Get("key"), r.URL.Query().Get("value")
+	p := &page{
+		Title:    fmt.Sprintf("%s Events", tc.Name),
+		Links:    testCaseLinks(tc),
+		Search:   &searchForm{Action: testCaseURL(tc.ID) + "/events", Key: key, Value: value},
+		Headers:  []string{"Chain", "Height", "Tx", "Event", "Key", "Value"},
+		filename: fmt.Sprintf("test-case-%d-events", tc.ID),
+	}
+	if key == "" && value == "" {
+		// Listing every attribute of every event is rarely useful and potentially very large.
+		return p, nil
+	}
+
+	results, err := e.querySvc.EventAttributes(r.Context(), tc.ID, key, value)
+	if err != nil {
+		return nil, fmt.Errorf("query event attributes: %w", err)
+	}
+	for _, attr := range results {
+		var txID string
+		if attr.TxID.Valid {
+			txID = strconv.FormatInt(attr.TxID.Int64, 10)
+		}
+		p.Rows = append(p.Rows, textCells(attr.ChainID, strconv.FormatInt(attr.Height, 10), txID, attr.EventType, attr.Key, attr.Value))
+	}
+	return p, nil
+}
+
+func (e *Explorer) packets(r *http.Request) (*page, error) {
+	chains, err := e.testCaseChains(r)
+	if err != nil {
+		return nil, err
+	}
+	tc := chains[0]
+
+	results, err := e.querySvc.IBCPackets(r.Context(), tc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("query ibc packets: %w", err)
+	}
+
+	p := &page{
+		Title:    fmt.Sprintf("%s IBC Packets", tc.Name),
+		Links:    testCaseLinks(tc),
+		Headers:  []string{"Source", "Sequence", "Destination", "State", "Sent", "Received", "Acknowledged", "Timed Out"},
+		filename: fmt.Sprintf("test-case-%d-packets", tc.ID),
+	}
+	for _, packet := range results {
+		pres := presenter.IBCPacket{Result: packet}
+		p.Rows = append(p.Rows, textCells(
+			pres.Source(),
+			pres.Sequence(),
+			pres.Destination(),
+			pres.State(),
+			pres.Sent(),
+			pres.Received(),
+			pres.Acknowledged(),
+			pres.TimedOut(),
+		))
+	}
+	return p, nil
+}
+
+// testCaseChains returns the chains of the test case in the request path.
+// The result is never empty, because a test case without chains is not found.
+func (e *Explorer) testCaseChains(r *http.Request) ([]blockdb.TestCaseResult, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("test case %q: %w", r.PathValue("id"), errNotFound)
+	}
+	chains, err := e.querySvc.TestCase(r.Context(), id)
+	if err != nil {
+		return nil, fmt.Errorf("query test case: %w", err)
+	}
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("test case %d: %w", id, errNotFound)
+	}
+	return chains, nil
+}
+
+// chain returns the chain in the request path, which must belong to the test case in the request path.
+func (e *Explorer) chain(r *http.Request) (blockdb.TestCaseResult, error) {
+	chains, err := e.testCaseChains(r)
+	if err != nil {
+		return blockdb.TestCaseResult{}, err
+	}
+	for _, c := range chains {
+		if strconv.FormatInt(c.ChainPKey, 10) == r.PathValue("chain") {
+			return c, nil
+		}
+	}
+	return blockdb.TestCaseResult{}, fmt.Errorf("chain %q: %w", r.PathValue("chain"), errNotFound)
+}
+
+func chainPage(chain blockdb.TestCaseResult, title string, headers ...string) *page {
+	return &page{
+		Title:    fmt.Sprintf("%s %s [%s]", chain.ChainID, title, chain.Name),
+		Links:    append(testCaseLinks(chain), link{"blocks", chainURL(chain, "blocks")}, link{"txs", chainURL(chain, "txs")}, link{"messages", chainURL(chain, "messages")}),
+		Headers:  headers,
+		filename: fmt.Sprintf("test-case-%d-%s-%s", chain.ID, chain.ChainID, strings.ToLower(title)),
+	}
+}
+
+func testCaseLinks(tc blockdb.TestCaseResult) []link {
+	return []link{
+		{"test cases", "/"},
+		{tc.Name, testCaseURL(tc.ID)},
+		{"packets", testCaseURL(tc.ID) + "/packets"},
+	}
+}
+
+func testCaseURL(id int64) string {
+	return fmt.Sprintf("/test-cases/%d", id)
+}
+
+func chainURL(chain blockdb.TestCaseResult, view string) string {
+	return fmt.Sprintf("/test-cases/%d/chains/%d/%s", chain.ID, chain.ChainPKey, view)
+}
@@ -17,6 +17,7 @@ import (
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
 )
 
 type ClientContextOpt func(clientContext client.Context) client.Context
@@ -106,7 +107,7 @@ func (b *Broadcaster) GetClientContext(ctx context.Context, user User) (client.C
 	if !ok {
 		localDir := b.t.TempDir()
 		containerKeyringDir := path.Join(cn.HomeDir(), "keyring-test")
-		kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, cn.DockerClient, localDir, containerKeyringDir, cn.containerLifecycle.ContainerID())
+		kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, cn.Runtime, localDir, containerKeyringDir, cn.containerLifecycle.ContainerID())
 		if err != nil {
 			return client.Context{}, err
 		}
@@ -193,7 +194,13 @@ func (b *Broadcaster) defaultTxFactory(clientCtx client.Context, account client.
 
 // BroadcastTx uses the provided Broadcaster to broadcast all the provided messages which will be signed
 // by the User provided. The sdk.TxResponse and an error are returned.
-func BroadcastTx(ctx context.Context, broadcaster *Broadcaster, broadcastingUser User, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+func BroadcastTx(ctx context.Context, broadcaster *Broadcaster, broadcastingUser User, msgs ...sdk.Msg) (resp sdk.TxResponse, err error) {
+	ctx, span := tracing.Start(ctx, "cosmos.tx.broadcast", tracing.AttrChainID.String(broadcaster.chain.Config().ChainID))
+	defer func() {
+		span.SetAttributes(tracing.AttrTxHash.String(resp.TxHash))
+		tracing.End(span, err)
+	}()
+
 	f, err := broadcaster.GetFactory(ctx, broadcastingUser)
 	if err != nil {
 		return sdk.TxResponse{}, err
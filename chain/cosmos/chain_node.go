@@ -18,12 +18,14 @@ import (
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
 	tmjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/p2p"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	comettypes "github.com/cometbft/cometbft/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -32,7 +34,6 @@ import (
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
-	volumetypes "github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"go.uber.org/zap"
@@ -47,6 +48,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
 )
 
 // ChainNode represents a node in the test network that is being created
@@ -57,6 +59,7 @@ type ChainNode struct {
 	Validator    bool
 	NetworkID    string
 	DockerClient *dockerclient.Client
+	Runtime      dockerutil.ContainerRuntime
 	Client       rpcclient.Client
 	GrpcConn     *grpc.ClientConn
 	TestName     string
@@ -90,13 +93,14 @@ func NewChainNode(log *zap.Logger, validator bool, chain *CosmosChain, dockerCli
 
 		Chain:        chain,
 		DockerClient: dockerClient,
+		Runtime:      dockerutil.RuntimeFor(dockerClient),
 		NetworkID:    networkID,
 		TestName:     testName,
 		Image:        image,
 		Index:        index,
 	}
 
-	tn.containerLifecycle = dockerutil.NewContainerLifecycle(log, dockerClient, tn.Name())
+	tn.containerLifecycle = dockerutil.NewContainerLifecycle(log, tn.Runtime, tn.Name())
 
 	return tn
 }
@@ -172,23 +176,21 @@ func (tn *ChainNode) NewSidecarProcess(
 ) error {
 	s := NewSidecar(tn.log, true, preStart, tn.Chain, cli, networkID, processName, tn.TestName, image, homeDir, tn.Index, ports, startCmd, env)
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel:   tn.TestName,
-			dockerutil.NodeOwnerLabel: s.Name(),
-		},
+	volumeName, err := s.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel:   tn.TestName,
+		dockerutil.NodeOwnerLabel: s.Name(),
 	})
 	if err != nil {
 		return fmt.Errorf("creating volume for sidecar process: %w", err)
 	}
-	s.VolumeName = v.Name
+	s.VolumeName = volumeName
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: tn.log,
 
-		Client: cli,
+		Runtime: s.Runtime,
 
-		VolumeName: v.Name,
+		VolumeName: volumeName,
 		ImageRef:   image.Ref(),
 		TestName:   tn.TestName,
 		UidGid:     image.UidGid,
@@ -234,6 +236,11 @@ func (tn *ChainNode) ContainerID() string {
 	return tn.containerLifecycle.ContainerID()
 }
 
+// Stats returns a single sample of the node container's resource usage.
+func (tn *ChainNode) Stats(ctx context.Context) (dockerutil.ContainerStats, error) {
+	return tn.containerLifecycle.Stats(ctx)
+}
+
 // hostname of the test node container
 func (tn *ChainNode) HostName() string {
 	return dockerutil.CondenseHostName(tn.Name())
@@ -272,7 +279,7 @@ func (tn *ChainNode) PrivValFileContent(ctx context.Context) ([]byte, error) {
 }
 
 func (tn *ChainNode) OverwritePrivValFile(ctx context.Context, content []byte) error {
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.Runtime, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, "config/priv_validator_key.json", content); err != nil {
 		return fmt.Errorf("overwriting priv_validator_key.json: %w", err)
 	}
@@ -359,7 +366,7 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 	if err := testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
-		tn.DockerClient,
+		tn.Runtime,
 		tn.TestName,
 		tn.VolumeName,
 		"config/config.toml",
@@ -390,7 +397,7 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 	return testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
-		tn.DockerClient,
+		tn.Runtime,
 		tn.TestName,
 		tn.VolumeName,
 		"config/app.toml",
@@ -410,7 +417,7 @@ func (tn *ChainNode) SetPeers(ctx context.Context, peers string) error {
 	return testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
-		tn.DockerClient,
+		tn.Runtime,
 		tn.TestName,
 		tn.VolumeName,
 		"config/config.toml",
@@ -427,8 +434,18 @@ func (tn *ChainNode) Height(ctx context.Context) (int64, error) {
 	return height, nil
 }
 
-// FindTxs implements blockdb.BlockSaver.
+// FindTxs implements blockdb.TxFinder.
 func (tn *ChainNode) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, error) {
+	block, err := tn.FindBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return block.Txs, nil
+}
+
+// FindBlock implements blockdb.BlockFinder.
+// For compatibility with FindTxs, the block-level events are also included in an artificial finalize_block transaction.
+func (tn *ChainNode) FindBlock(ctx context.Context, height int64) (blockdb.Block, error) {
 	h := int64(height)
 	var eg errgroup.Group
 	var blockRes *coretypes.ResultBlockResults
@@ -442,7 +459,7 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, e
 		return err
 	})
 	if err := eg.Wait(); err != nil {
-		return nil, err
+		return blockdb.Block{}, err
 	}
 	interfaceRegistry := tn.Chain.Config().EncodingConfig.InterfaceRegistry
 	txs := make([]blockdb.Tx, 0, len(block.Block.Txs)+2)
@@ -484,23 +501,69 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, e
 		finalizeBlockTx := blockdb.Tx{
 			Data: []byte(`{"data":"finalize_block","note":"this is a transaction artificially created for debugging purposes"}`),
 		}
-		finalizeBlockTx.Events = make([]blockdb.Event, len(blockRes.FinalizeBlockEvents))
-		for i, e := range blockRes.FinalizeBlockEvents {
-			attrs := make([]blockdb.EventAttribute, len(e.Attributes))
-			for j, attr := range e.Attributes {
-				attrs[j] = blockdb.EventAttribute{
-					Key:   string(attr.Key),
-					Value: string(attr.Value),
-				}
+		finalizeBlockTx.Events = finalizeBlockEvents(blockRes.FinalizeBlockEvents)
+		txs = append(txs, finalizeBlockTx)
+	}
+
+	header := blockdb.BlockHeader{
+		Hash:            block.BlockID.Hash.String(),
+		Time:            block.Block.Time,
+		ProposerAddress: block.Block.ProposerAddress.String(),
+		AppHash:         block.Block.AppHash.String(),
+	}
+	for _, rTx := range blockRes.TxsResults {
+		header.GasUsed += rTx.GasUsed
+		header.GasWanted += rTx.GasWanted
+	}
+
+	var sigs []blockdb.CommitSig
+	if lastCommit := block.Block.LastCommit; lastCommit != nil {
+		sigs = make([]blockdb.CommitSig, len(lastCommit.Signatures))
+		for i, sig := range lastCommit.Signatures {
+			sigs[i] = blockdb.CommitSig{
+				ValidatorAddress: sig.ValidatorAddress.String(),
+				Flag:             commitFlag(sig.BlockIDFlag),
+				Timestamp:        sig.Timestamp,
 			}
-			finalizeBlockTx.Events[i] = blockdb.Event{
-				Type:       e.Type,
-				Attributes: attrs,
+		}
+	}
+
+	return blockdb.Block{
+		Height:     height,
+		Header:     header,
+		Txs:        txs,
+		Events:     finalizeBlockEvents(blockRes.FinalizeBlockEvents),
+		Signatures: sigs,
+	}, nil
+}
+
+func finalizeBlockEvents(events []abcitypes.Event) []blockdb.Event {
+	out := make([]blockdb.Event, len(events))
+	for i, e := range events {
+		attrs := make([]blockdb.EventAttribute, len(e.Attributes))
+		for j, attr := range e.Attributes {
+			attrs[j] = blockdb.EventAttribute{
+				Key:   attr.Key,
+				Value: attr.Value,
 			}
 		}
-		txs = append(txs, finalizeBlockTx)
+		out[i] = blockdb.Event{
+			Type:       e.Type,
+			Attributes: attrs,
+		}
+	}
+	return out
+}
+
+func commitFlag(flag comettypes.BlockIDFlag) string {
+	switch flag {
+	case comettypes.BlockIDFlagCommit:
+		return blockdb.CommitFlagCommit
+	case comettypes.BlockIDFlagNil:
+		return blockdb.CommitFlagNil
+	default:
+		return blockdb.CommitFlagAbsent
 	}
-	return txs, nil
 }
 
 // TxCommand is a helper to retrieve a full command for broadcasting a tx
@@ -541,7 +604,16 @@ func (tn *ChainNode) TxCommand(keyName string, command ...string) []string {
 }
 
 // ExecTx executes a transaction, waits for 2 blocks if successful, then returns the tx hash.
-func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...string) (string, error) {
+func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...string) (txHash string, err error) {
+	ctx, span := tracing.Start(ctx, "cosmos.tx.exec",
+		tracing.AttrChainID.String(tn.Chain.Config().ChainID),
+		tracing.AttrCommand.StringSlice(command),
+	)
+	defer func() {
+		span.SetAttributes(tracing.AttrTxHash.String(txHash))
+		tracing.End(span, err)
+	}()
+
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
@@ -689,7 +761,7 @@ func (tn *ChainNode) InitHomeFolder(ctx context.Context) error {
 // the docker filesystem. relPath describes the location of the file in the
 // docker volume relative to the home directory
 func (tn *ChainNode) WriteFile(ctx context.Context, content []byte, relPath string) error {
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.Runtime, tn.TestName)
 	return fw.WriteFile(ctx, tn.VolumeName, relPath, content)
 }
 
@@ -707,7 +779,7 @@ func (tn *ChainNode) CopyFile(ctx context.Context, srcPath, dstPath string) erro
 // ReadFile reads the contents of a single file at the specified path in the docker filesystem.
 // relPath describes the location of the file in the docker volume relative to the home directory.
 func (tn *ChainNode) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.Runtime, tn.TestName)
 	gen, err := fr.SingleFileContent(ctx, tn.VolumeName, relPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file at %s: %w", relPath, err)
@@ -715,6 +787,30 @@ func (tn *ChainNode) ReadFile(ctx context.Context, relPath string) ([]byte, erro
 	return gen, nil
 }
 
+// CopyDirToVolume copies the files of the host directory srcDir selected by filter to the docker filesystem,
+// such as to seed a keyring or snapshot data. relDir describes the location of the directory
+// in the docker volume relative to the home directory.
+func (tn *ChainNode) CopyDirToVolume(ctx context.Context, srcDir, relDir string, filter dockerutil.DirFilter) error {
+	return dockerutil.CopyDirToVolume(ctx, tn.volumeDirOptions(filter), srcDir, relDir)
+}
+
+// CopyDirFromVolume copies the files of the directory at relDir in the docker filesystem selected by filter
+// to the host directory dstDir. relDir is relative to the home directory.
+func (tn *ChainNode) CopyDirFromVolume(ctx context.Context, relDir, dstDir string, filter dockerutil.DirFilter) error {
+	return dockerutil.CopyDirFromVolume(ctx, tn.volumeDirOptions(filter), relDir, dstDir)
+}
+
+func (tn *ChainNode) volumeDirOptions(filter dockerutil.DirFilter) dockerutil.VolumeDirOptions {
+	return dockerutil.VolumeDirOptions{
+		Log:        tn.logger(),
+		Runtime:    tn.Runtime,
+		VolumeName: tn.VolumeName,
+		TestName:   tn.TestName,
+		UidGid:     tn.Image.UidGid,
+		Filter:     filter,
+	}
+}
+
 // CreateKey creates a key in the keyring backend test for the given node
 func (tn *ChainNode) CreateKey(ctx context.Context, name string) error {
 	tn.lock.Lock()
@@ -824,7 +920,10 @@ func (tn *ChainNode) Gentx(ctx context.Context, name string, genesisSelfDelegati
 }
 
 // CollectGentxs runs collect gentxs on the node's home folders
-func (tn *ChainNode) CollectGentxs(ctx context.Context) error {
+func (tn *ChainNode) CollectGentxs(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "cosmos.gentx.collect", tracing.AttrChainID.String(tn.Chain.Config().ChainID))
+	defer func() { tracing.End(span, err) }()
+
 	command := []string{tn.Chain.Config().Bin}
 	if tn.IsAboveSDK47(ctx) {
 		command = append(command, "genesis")
@@ -835,7 +934,7 @@ func (tn *ChainNode) CollectGentxs(ctx context.Context) error {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
-	_, _, err := tn.Exec(ctx, command, tn.Chain.Config().Env)
+	_, _, err = tn.Exec(ctx, command, tn.Chain.Config().Env)
 	return err
 }
 
@@ -890,7 +989,7 @@ func (tn *ChainNode) ConsumerAdditionProposal(ctx context.Context, keyName strin
 
 	fileName := "proposal_" + dockerutil.RandLowerCaseLetterString(4) + ".json"
 
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.Runtime, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, fileName, propBz); err != nil {
 		return "", fmt.Errorf("failure writing proposal json: %w", err)
 	}
@@ -1146,12 +1245,13 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 			TestName:           tn.TestName,
 			VolumeName:         tn.VolumeName,
 			DockerClient:       tn.DockerClient,
+			Runtime:            tn.Runtime,
 			NetworkID:          tn.NetworkID,
 			Index:              tn.Index,
 			homeDir:            tn.HomeDir(),
 			log:                tn.log,
 			env:                chainCfg.Env,
-			containerLifecycle: dockerutil.NewContainerLifecycle(tn.log, tn.DockerClient, containerName),
+			containerLifecycle: dockerutil.NewContainerLifecycle(tn.log, tn.Runtime, containerName),
 		})
 	}
 
@@ -1163,20 +1263,47 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 		usingPorts[nat.Port(port)] = []nat.PortBinding{}
 	}
 
-	// to prevent port binding conflicts, host port overrides are only exposed on the first validator node.
-	if tn.Validator && tn.Index == 0 && chainCfg.HostPortOverride != nil {
-		for intP, extP := range chainCfg.HostPortOverride {
-			usingPorts[nat.Port(fmt.Sprintf("%d/tcp", intP))] = []nat.PortBinding{
-				{
-					HostPort: fmt.Sprintf("%d", extP),
-				},
-			}
+	// To prevent port binding conflicts, host port overrides are only exposed on the first validator node,
+	// unless a stride shifts them for every node.
+	position := tn.Index
+	if !tn.Validator {
+		position += tn.Chain.(*CosmosChain).NumValidators
+	}
+	if overrides := dockerutil.HostPortOverrideBindings(chainCfg, position); len(overrides) > 0 {
+		for p, b := range overrides {
+			usingPorts[p] = b
 		}
 
 		fmt.Printf("Port Overrides: %v. Using: %v\n", chainCfg.HostPortOverride, usingPorts)
 	}
 
-	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, usingPorts, tn.Bind(), nil, tn.HostName(), cmd, chainCfg.Env, []string{})
+	tn.containerLifecycle.SetResources(chainCfg.Resources)
+	tn.containerLifecycle.SetLabels(map[string]string{
+		dockerutil.NodeOwnerLabel: tn.Name(),
+		dockerutil.ChainIDLabel:   chainCfg.ChainID,
+		dockerutil.NodeTypeLabel:  tn.NodeType(),
+		dockerutil.NodeIndexLabel: strconv.Itoa(tn.Index),
+	})
+	env := chainCfg.Env
+	if tn.Runtime.RunsOnHost() {
+		env = append(append([]string(nil), env...), tn.hostProcessEnv()...)
+	}
+	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, usingPorts, tn.Bind(), nil, tn.HostName(), cmd, env, []string{})
+}
+
+// hostProcessEnv returns the environment overriding the listen addresses of the node's config,
+// so that a node running as a host process listens on the host ports that the process runtime rewrites them to.
+// The cosmos-sdk reads config values from environment variables prefixed with the name of the binary.
+func (tn *ChainNode) hostProcessEnv() []string {
+	prefix := strings.ToUpper(strings.ReplaceAll(path.Base(tn.Chain.Config().Bin), "-", "_")) + "_"
+	return []string{
+		prefix + "RPC_LADDR=tcp://0.0.0.0:26657",
+		prefix + "P2P_LADDR=tcp://0.0.0.0:26656",
+		prefix + "GRPC_ADDRESS=0.0.0.0:9090",
+		prefix + "API_ADDRESS=tcp://0.0.0.0:1317",
+		// The gRPC-web port of older SDKs is not published, and would be shared by every node.
+		prefix + "GRPC_WEB_ENABLE=false",
+	}
 }
 
 func (tn *ChainNode) StartContainer(ctx context.Context) error {
@@ -1220,6 +1347,22 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 		return err
 	}
 
+	return tn.connect(ctx, rpcOverrideAddr)
+}
+
+// Attach makes tn manage the existing node container id, created by an earlier process,
+// starting it if needed and rediscovering its host ports.
+// CometMock is not supported.
+func (tn *ChainNode) Attach(ctx context.Context, id string) error {
+	if err := tn.containerLifecycle.Attach(ctx, id); err != nil {
+		return err
+	}
+	return tn.connect(ctx, "")
+}
+
+// connect sets the host ports of the started node container and waits for its RPC client to be ready.
+// If rpcOverrideAddr is set, it is used as the RPC address instead.
+func (tn *ChainNode) connect(ctx context.Context, rpcOverrideAddr string) error {
 	// Set the host ports once since they will not change after the container has started.
 	hostPorts, err := tn.containerLifecycle.GetHostPorts(ctx, rpcPort, grpcPort, apiPort, p2pPort)
 	if err != nil {
@@ -1294,7 +1437,10 @@ func (tn *ChainNode) InitValidatorGenTx(
 	chainType *ibc.ChainConfig,
 	genesisAmounts []sdk.Coin,
 	genesisSelfDelegation sdk.Coin,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "cosmos.gentx", tracing.AttrChainID.String(chainType.ChainID))
+	defer func() { tracing.End(span, err) }()
+
 	if err := tn.CreateKey(ctx, valKey); err != nil {
 		return err
 	}
@@ -1402,7 +1548,7 @@ func (nodes ChainNodes) logger() *zap.Logger {
 }
 
 func (tn *ChainNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
+	job := dockerutil.NewImage(tn.logger(), tn.Runtime, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
 		Binds: tn.Bind(),
@@ -6,9 +6,9 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"io"
 	"math"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,8 +27,6 @@ import (
 	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types" // nolint:staticcheck
 	chanTypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
 	ccvclient "github.com/cosmos/interchain-security/v5/x/ccv/provider/client"
-	dockertypes "github.com/docker/docker/api/types"
-	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
 	wasmtypes "github.com/strangelove-ventures/interchaintest/v8/chain/cosmos/08-wasm-types"
@@ -36,6 +34,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -177,7 +176,7 @@ func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[
 				if err := testutil.ModifyTomlConfigFile(
 					ctx,
 					fn.logger(),
-					fn.DockerClient,
+					fn.Runtime,
 					fn.TestName,
 					fn.VolumeName,
 					configFile,
@@ -618,20 +617,12 @@ func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) {
 		if image.Version == "local" {
 			continue
 		}
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			dockertypes.ImagePullOptions{},
-		)
-		if err != nil {
+		if err := dockerutil.PullImage(ctx, cli, image.Repository+":"+image.Version); err != nil {
 			c.log.Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
 			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
 		}
 	}
 }
@@ -650,24 +641,22 @@ func (c *CosmosChain) NewChainNode(
 	// The ChainNode's VolumeName cannot be set until after we create the volume.
 	tn := NewChainNode(c.log, validator, c, cli, networkID, testName, image, index)
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel: testName,
+	volumeName, err := tn.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel: testName,
 
-			dockerutil.NodeOwnerLabel: tn.Name(),
-		},
+		dockerutil.NodeOwnerLabel: tn.Name(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume for chain node: %w", err)
 	}
-	tn.VolumeName = v.Name
+	tn.VolumeName = volumeName
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: c.log,
 
-		Client: cli,
+		Runtime: tn.Runtime,
 
-		VolumeName: v.Name,
+		VolumeName: volumeName,
 		ImageRef:   image.Ref(),
 		TestName:   testName,
 		UidGid:     image.UidGid,
@@ -708,23 +697,21 @@ func (c *CosmosChain) NewSidecarProcess(
 	// The SidecarProcess's VolumeName cannot be set until after we create the volume.
 	s := NewSidecar(c.log, false, preStart, c, cli, networkID, processName, testName, image, homeDir, index, ports, startCmd, env)
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel:   testName,
-			dockerutil.NodeOwnerLabel: s.Name(),
-		},
+	volumeName, err := s.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel:   testName,
+		dockerutil.NodeOwnerLabel: s.Name(),
 	})
 	if err != nil {
 		return fmt.Errorf("creating volume for sidecar process: %w", err)
 	}
-	s.VolumeName = v.Name
+	s.VolumeName = volumeName
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: c.log,
 
-		Client: cli,
+		Runtime: s.Runtime,
 
-		VolumeName: v.Name,
+		VolumeName: volumeName,
 		ImageRef:   image.Ref(),
 		TestName:   testName,
 		UidGid:     image.UidGid,
@@ -817,6 +804,82 @@ func (c *CosmosChain) initializeSidecars(
 	return nil
 }
 
+// Attach rediscovers the nodes of the chain started by an earlier process in the persistent network of testName,
+// in place of Initialize and Start.
+// Nodes are found by the labels of their containers, and stopped nodes are restarted.
+// Chains with sidecars or CometMock cannot be attached to.
+func (c *CosmosChain) Attach(ctx context.Context, testName string, cli *client.Client, networkID string) error {
+	chainCfg := c.Config()
+	if len(chainCfg.SidecarConfigs) > 0 || chainCfg.UsesCometMock() {
+		return fmt.Errorf("attaching to chain %s: chains with sidecars or CometMock are not supported", chainCfg.ChainID)
+	}
+
+	rt := dockerutil.RuntimeFor(cli)
+	cs, err := dockerutil.LabelledContainers(ctx, rt, testName, map[string]string{
+		dockerutil.ChainIDLabel: chainCfg.ChainID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var vals, fullNodes ChainNodes
+	for _, ctr := range cs {
+		index, err := strconv.Atoi(ctr.Labels[dockerutil.NodeIndexLabel])
+		if err != nil {
+			return fmt.Errorf("container %s has an invalid node index: %w", ctr.ID, err)
+		}
+		validator := ctr.Labels[dockerutil.NodeTypeLabel] == "val"
+
+		tn := NewChainNode(c.log, validator, c, cli, networkID, testName, chainCfg.Images[0], index)
+		volumes, err := dockerutil.OwnedVolumes(ctx, rt, testName, tn.Name())
+		if err != nil {
+			return err
+		}
+		if len(volumes) == 0 {
+			return fmt.Errorf("no volume found for node %s", tn.Name())
+		}
+		tn.VolumeName = volumes[0]
+
+		if validator {
+			vals = append(vals, tn)
+		} else {
+			fullNodes = append(fullNodes, tn)
+		}
+	}
+	if len(vals) == 0 {
+		return fmt.Errorf("no validators of chain %s found in %s", chainCfg.ChainID, testName)
+	}
+	for _, nodes := range []ChainNodes{vals, fullNodes} {
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Index < nodes[j].Index })
+		for i, n := range nodes {
+			if n.Index != i {
+				return fmt.Errorf("chain %s is missing node %d", chainCfg.ChainID, i)
+			}
+		}
+	}
+
+	byName := make(map[string]string, len(cs))
+	for _, ctr := range cs {
+		byName[ctr.Labels[dockerutil.NodeOwnerLabel]] = ctr.ID
+	}
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range append(append(ChainNodes{}, vals...), fullNodes...) {
+		n := n
+		eg.Go(func() error {
+			return n.Attach(egCtx, byName[n.Name()])
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return fmt.Errorf("attaching to nodes of chain %s: %w", chainCfg.ChainID, err)
+	}
+
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+	c.Validators, c.FullNodes = vals, fullNodes
+	c.NumValidators, c.numFullNodes = len(vals), len(fullNodes)
+	return nil
+}
+
 type GenesisValidatorPubKey struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`
@@ -880,7 +943,7 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 				if err := testutil.ModifyTomlConfigFile(
 					ctx,
 					v.logger(),
-					v.DockerClient,
+					v.Runtime,
 					v.TestName,
 					v.VolumeName,
 					configFile,
@@ -912,7 +975,7 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 				if err := testutil.ModifyTomlConfigFile(
 					ctx,
 					n.logger(),
-					n.DockerClient,
+					n.Runtime,
 					n.TestName,
 					n.VolumeName,
 					configFile,
@@ -941,6 +1004,76 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		}
 	}
 
+	if err := c.assembleGenesis(ctx, genesisAmounts, additionalGenesisWallets); err != nil {
+		return err
+	}
+
+	chainNodes := c.Nodes()
+
+	// Start any sidecar processes that should be running before the chain starts
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, s := range c.Sidecars {
+		s := s
+
+		err := s.containerLifecycle.Running(ctx)
+		if s.preStart && err != nil {
+			eg.Go(func() error {
+				if err := s.CreateContainer(egCtx); err != nil {
+					return err
+				}
+
+				if err := s.StartContainer(egCtx); err != nil {
+					return err
+				}
+
+				return nil
+			})
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		n := n
+		eg.Go(func() error {
+			return n.CreateNodeContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	peers := chainNodes.PeerString(ctx)
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		n := n
+		c.log.Info("Starting container", zap.String("container", n.Name()))
+		eg.Go(func() error {
+			if err := n.SetPeers(egCtx, peers); err != nil {
+				return err
+			}
+			return n.StartContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// Wait for blocks before considering the chains "started"
+	return testutil.WaitForBlocks(ctx, 2, c.getFullNode())
+}
+
+// assembleGenesis collects the accounts and gentxs of all validators into the first validator's genesis file,
+// applies any genesis modifications, and distributes the result to every node.
+func (c *CosmosChain) assembleGenesis(ctx context.Context, genesisAmounts [][]types.Coin, additionalGenesisWallets []ibc.WalletAmount) (err error) {
+	ctx, span := tracing.Start(ctx, "cosmos.genesis", tracing.AttrChainID.String(c.cfg.ChainID))
+	defer func() { tracing.End(span, err) }()
+
+	chainCfg := c.Config()
+
 	// for the validators we need to collect the gentxs and the accounts
 	// to the first node's genesis file
 	validator0 := c.Validators[0]
@@ -1002,7 +1135,6 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 	}
 
 	chainNodes := c.Nodes()
-
 	for _, cn := range chainNodes {
 		if err := cn.OverwriteGenesisFile(ctx, genbz); err != nil {
 			return err
@@ -1013,60 +1145,7 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
-	// Start any sidecar processes that should be running before the chain starts
-	eg, egCtx := errgroup.WithContext(ctx)
-	for _, s := range c.Sidecars {
-		s := s
-
-		err = s.containerLifecycle.Running(ctx)
-		if s.preStart && err != nil {
-			eg.Go(func() error {
-				if err := s.CreateContainer(egCtx); err != nil {
-					return err
-				}
-
-				if err := s.StartContainer(egCtx); err != nil {
-					return err
-				}
-
-				return nil
-			})
-		}
-	}
-	if err := eg.Wait(); err != nil {
-		return err
-	}
-
-	eg, egCtx = errgroup.WithContext(ctx)
-	for _, n := range chainNodes {
-		n := n
-		eg.Go(func() error {
-			return n.CreateNodeContainer(egCtx)
-		})
-	}
-	if err := eg.Wait(); err != nil {
-		return err
-	}
-
-	peers := chainNodes.PeerString(ctx)
-
-	eg, egCtx = errgroup.WithContext(ctx)
-	for _, n := range chainNodes {
-		n := n
-		c.log.Info("Starting container", zap.String("container", n.Name()))
-		eg.Go(func() error {
-			if err := n.SetPeers(egCtx, peers); err != nil {
-				return err
-			}
-			return n.StartContainer(egCtx)
-		})
-	}
-	if err := eg.Wait(); err != nil {
-		return err
-	}
-
-	// Wait for blocks before considering the chains "started"
-	return testutil.WaitForBlocks(ctx, 2, c.getFullNode())
+	return nil
 }
 
 // Height implements ibc.Chain
@@ -1147,6 +1226,45 @@ func (c *CosmosChain) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx,
 	return fn.FindTxs(ctx, height)
 }
 
+// FindBlock implements blockdb.BlockFinder.
+func (c *CosmosChain) FindBlock(ctx context.Context, height int64) (blockdb.Block, error) {
+	fn := c.getFullNode()
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+	return fn.FindBlock(ctx, height)
+}
+
+// Stats samples the resource usage of every node and sidecar container of the chain.
+func (c *CosmosChain) Stats(ctx context.Context) ([]dockerutil.ContainerStats, error) {
+	var sidecars []*SidecarProcess
+	sidecars = append(sidecars, c.Sidecars...)
+	for _, n := range c.Nodes() {
+		sidecars = append(sidecars, n.Sidecars...)
+	}
+
+	nodes := c.Nodes()
+	stats := make([]dockerutil.ContainerStats, len(nodes)+len(sidecars))
+	var eg errgroup.Group
+	for i, n := range nodes {
+		i, n := i, n
+		eg.Go(func() (err error) {
+			stats[i], err = n.Stats(ctx)
+			return err
+		})
+	}
+	for i, s := range sidecars {
+		i, s := len(nodes)+i, s
+		eg.Go(func() (err error) {
+			stats[i], err = s.Stats(ctx)
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 // StopAllNodes stops and removes all long running containers (validators and full nodes)
 func (c *CosmosChain) StopAllNodes(ctx context.Context) error {
 	var eg errgroup.Group
@@ -0,0 +1,46 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeesPaid returns the total transaction fees in denom paid by address in the blocks
+// after fromHeight up to and including toHeight,
+// found by searching the node's tx index for transactions with address as the fee payer.
+func (c *CosmosChain) FeesPaid(ctx context.Context, address, denom string, fromHeight, toHeight int64) (sdkmath.Int, error) {
+	client := c.getFullNode().Client
+	query := fmt.Sprintf("tx.fee_payer='%s' AND tx.height>%d AND tx.height<=%d", address, fromHeight, toHeight)
+
+	total := sdkmath.ZeroInt()
+	perPage := 100
+	for page := 1; ; page++ {
+		res, err := client.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return sdkmath.Int{}, fmt.Errorf("search txs paid by %s: %w", address, err)
+		}
+		for _, tx := range res.Txs {
+			for _, e := range tx.TxResult.Events {
+				if e.Type != "tx" {
+					continue
+				}
+				for _, attr := range e.Attributes {
+					if attr.Key != "fee" || attr.Value == "" {
+						continue
+					}
+					fee, err := sdk.ParseCoinsNormalized(attr.Value)
+					if err != nil {
+						return sdkmath.Int{}, fmt.Errorf("parse fee of tx %X: %w", tx.Hash, err)
+					}
+					total = total.Add(fee.AmountOf(denom))
+				}
+			}
+		}
+		if page*perPage >= res.TotalCount {
+			return total, nil
+		}
+	}
+}
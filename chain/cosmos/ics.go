@@ -227,7 +227,7 @@ func (c *CosmosChain) StartConsumer(testName string, ctx context.Context, additi
 				if err := testutil.ModifyTomlConfigFile(
 					ctx,
 					v.logger(),
-					v.DockerClient,
+					v.Runtime,
 					v.TestName,
 					v.VolumeName,
 					configFile,
@@ -447,7 +447,7 @@ func (c *CosmosChain) transformCCVState(ctx context.Context, ccvState []byte, co
 	if err != nil {
 		return nil, fmt.Errorf("failed to write ccv state to file: %w", err)
 	}
-	job := dockerutil.NewImage(c.log, c.GetNode().DockerClient, c.GetNode().NetworkID,
+	job := dockerutil.NewImage(c.log, c.GetNode().Runtime, c.GetNode().NetworkID,
 		c.GetNode().TestName, "ghcr.io/strangelove-ventures/heighliner/ics", imageVersion,
 	)
 	cmd := []string{"interchain-security-cd", "genesis", "transform"}
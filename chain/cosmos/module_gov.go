@@ -34,7 +34,7 @@ func (tn *ChainNode) SubmitProposal(ctx context.Context, keyName string, prop Tx
 		return "", err
 	}
 
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.Runtime, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, file, propJson); err != nil {
 		return "", fmt.Errorf("writing contract file to docker volume: %w", err)
 	}
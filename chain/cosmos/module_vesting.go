@@ -49,7 +49,7 @@ func (tn *ChainNode) VestingCreatePeriodicAccount(ctx context.Context, keyName s
 		return err
 	}
 
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.Runtime, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, file, periodsJSON); err != nil {
 		return fmt.Errorf("writing periods JSON file to docker volume: %w", err)
 	}
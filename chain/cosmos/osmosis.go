@@ -28,7 +28,7 @@ func OsmosisCreatePool(c *CosmosChain, ctx context.Context, keyName string, para
 
 	poolFile := "pool.json"
 
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.Runtime, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, poolFile, poolbz); err != nil {
 		return "", fmt.Errorf("failed to write pool file: %w", err)
 	}
@@ -0,0 +1,189 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	chanTypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"golang.org/x/sync/errgroup"
+)
+
+// RelayedPacket is a single packet message (receive, acknowledgement, or timeout)
+// submitted to the chain by a relayer.
+type RelayedPacket struct {
+	// Height of the block including the transaction.
+	Height int64
+	// Hex encoded hash of the transaction carrying the message.
+	TxHash string
+	// Bech32 address of the relayer wallet that signed the message.
+	Signer string
+	// Type URL of the message, e.g. /ibc.core.channel.v1.MsgRecvPacket.
+	MsgType string
+
+	Packet ibc.Packet
+
+	// Result code, codespace and log of the transaction.
+	// A zero Code means the transaction succeeded.
+	Code      uint32
+	Codespace string
+	Log       string
+
+	// Result of the message, taken from its response in the transaction result data.
+	// It is NOOP when another relayer had already delivered the packet,
+	// and UNSPECIFIED when the transaction failed or the response could not be decoded.
+	Result chanTypes.ResponseResultType
+}
+
+// Delivered reports whether the message was executed by the chain,
+// rather than rejected or ignored because another relayer delivered the packet first.
+func (p RelayedPacket) Delivered() bool {
+	return p.Code == 0 && p.Result != chanTypes.NOOP
+}
+
+// Redundant reports whether the message was included in a block but ignored
+// because another relayer had already delivered the packet.
+// Redundant transactions are accepted with code 0: ibc-go only rejects them in CheckTx.
+func (p RelayedPacket) Redundant() bool {
+	return p.Code == 0 && p.Result == chanTypes.NOOP
+}
+
+// RelayedPackets returns every packet message included in blocks from startHeight to endHeight inclusive,
+// along with the signer of each message and the result of its transaction.
+// Failed transactions are included, so redundant relays that made it into a block are reported.
+func (c *CosmosChain) RelayedPackets(ctx context.Context, startHeight, endHeight int64) ([]RelayedPacket, error) {
+	var out []RelayedPacket
+	for h := startHeight; h <= endHeight; h++ {
+		packets, err := c.relayedPacketsAt(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("find relayed packets at height %d: %w", h, err)
+		}
+		out = append(out, packets...)
+	}
+	return out, nil
+}
+
+func (c *CosmosChain) relayedPacketsAt(ctx context.Context, height int64) ([]RelayedPacket, error) {
+	client := c.getFullNode().Client
+
+	var eg errgroup.Group
+	var blockRes *coretypes.ResultBlockResults
+	var block *coretypes.ResultBlock
+	eg.Go(func() (err error) {
+		blockRes, err = client.BlockResults(ctx, &height)
+		return err
+	})
+	eg.Go(func() (err error) {
+		block, err = client.Block(ctx, &height)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	var out []RelayedPacket
+	for i, txbz := range block.Block.Txs {
+		tx, err := decodeTX(c.cfg.EncodingConfig.InterfaceRegistry, txbz)
+		if err != nil {
+			return nil, fmt.Errorf("decode tendermint tx: %w", err)
+		}
+		res := blockRes.TxsResults[i]
+		var results []chanTypes.ResponseResultType
+		if res.Code == 0 {
+			if results, err = packetMsgResults(res.Data); err != nil {
+				return nil, fmt.Errorf("decode result of tx %X: %w", txbz.Hash(), err)
+			}
+		}
+		for j, msg := range tx.GetMsgs() {
+			signer, packet, ok := packetMsgDetails(msg)
+			if !ok {
+				continue
+			}
+			var result chanTypes.ResponseResultType
+			if j < len(results) {
+				result = results[j]
+			}
+			out = append(out, RelayedPacket{
+				Height:    height,
+				TxHash:    fmt.Sprintf("%X", txbz.Hash()),
+				Signer:    signer,
+				MsgType:   sdk.MsgTypeURL(msg),
+				Packet:    toIBCPacket(packet),
+				Code:      res.Code,
+				Codespace: res.Codespace,
+				Log:       res.Log,
+				Result:    result,
+			})
+		}
+	}
+	return out, nil
+}
+
+// packetMsgDetails extracts the signer and packet from msg
+// if msg is one of the packet messages submitted by relayers.
+func packetMsgDetails(msg sdk.Msg) (string, chanTypes.Packet, bool) {
+	switch m := msg.(type) {
+	case *chanTypes.MsgRecvPacket:
+		return m.Signer, m.Packet, true
+	case *chanTypes.MsgAcknowledgement:
+		return m.Signer, m.Packet, true
+	case *chanTypes.MsgTimeout:
+		return m.Signer, m.Packet, true
+	case *chanTypes.MsgTimeoutOnClose:
+		return m.Signer, m.Packet, true
+	default:
+		return "", chanTypes.Packet{}, false
+	}
+}
+
+// packetMsgResults decodes the result of each message of a successful transaction from its result data,
+// leaving UNSPECIFIED the results of messages other than packet messages.
+func packetMsgResults(data []byte) ([]chanTypes.ResponseResultType, error) {
+	var msgData sdk.TxMsgData
+	if err := proto.Unmarshal(data, &msgData); err != nil {
+		return nil, err
+	}
+
+	results := make([]chanTypes.ResponseResultType, len(msgData.MsgResponses))
+	for i, res := range msgData.MsgResponses {
+		var err error
+		switch res.TypeUrl {
+		case "/ibc.core.channel.v1.MsgRecvPacketResponse":
+			var resp chanTypes.MsgRecvPacketResponse
+			err = proto.Unmarshal(res.Value, &resp)
+			results[i] = resp.Result
+		case "/ibc.core.channel.v1.MsgAcknowledgementResponse":
+			var resp chanTypes.MsgAcknowledgementResponse
+			err = proto.Unmarshal(res.Value, &resp)
+			results[i] = resp.Result
+		case "/ibc.core.channel.v1.MsgTimeoutResponse":
+			var resp chanTypes.MsgTimeoutResponse
+			err = proto.Unmarshal(res.Value, &resp)
+			results[i] = resp.Result
+		case "/ibc.core.channel.v1.MsgTimeoutOnCloseResponse":
+			var resp chanTypes.MsgTimeoutOnCloseResponse
+			err = proto.Unmarshal(res.Value, &resp)
+			results[i] = resp.Result
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", res.TypeUrl, err)
+		}
+	}
+	return results, nil
+}
+
+func toIBCPacket(p chanTypes.Packet) ibc.Packet {
+	return ibc.Packet{
+		Sequence:         p.Sequence,
+		SourcePort:       p.SourcePort,
+		SourceChannel:    p.SourceChannel,
+		DestPort:         p.DestinationPort,
+		DestChannel:      p.DestinationChannel,
+		Data:             p.Data,
+		TimeoutHeight:    p.TimeoutHeight.String(),
+		TimeoutTimestamp: ibc.Nanoseconds(p.TimeoutTimestamp),
+	}
+}
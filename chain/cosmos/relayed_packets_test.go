@@ -0,0 +1,53 @@
+package cosmos
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/proto"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	chanTypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketMsgResults(t *testing.T) {
+	var responses []*codectypes.Any
+	for _, resp := range []proto.Message{
+		&clienttypes.MsgUpdateClientResponse{},
+		&chanTypes.MsgRecvPacketResponse{Result: chanTypes.NOOP},
+		&chanTypes.MsgAcknowledgementResponse{Result: chanTypes.SUCCESS},
+		&chanTypes.MsgTimeoutResponse{Result: chanTypes.NOOP},
+	} {
+		a, err := codectypes.NewAnyWithValue(resp)
+		require.NoError(t, err)
+		responses = append(responses, a)
+	}
+	data, err := proto.Marshal(&sdk.TxMsgData{MsgResponses: responses})
+	require.NoError(t, err)
+
+	results, err := packetMsgResults(data)
+	require.NoError(t, err)
+	require.Equal(t, []chanTypes.ResponseResultType{
+		chanTypes.UNSPECIFIED, chanTypes.NOOP, chanTypes.SUCCESS, chanTypes.NOOP,
+	}, results)
+
+	_, err = packetMsgResults([]byte("not a proto"))
+	require.Error(t, err)
+}
+
+func TestRelayedPacketClassification(t *testing.T) {
+	for _, tc := range []struct {
+		name                 string
+		p                    RelayedPacket
+		delivered, redundant bool
+	}{
+		{"success", RelayedPacket{Result: chanTypes.SUCCESS}, true, false},
+		{"noop relay included in a block", RelayedPacket{Result: chanTypes.NOOP}, false, true},
+		{"undecoded response", RelayedPacket{}, true, false},
+		{"failed tx", RelayedPacket{Code: 5, Codespace: "sdk"}, false, false},
+	} {
+		require.Equal(t, tc.delivered, tc.p.Delivered(), tc.name)
+		require.Equal(t, tc.redundant, tc.p.Redundant(), tc.name)
+	}
+}
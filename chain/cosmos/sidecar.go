@@ -33,6 +33,7 @@ type SidecarProcess struct {
 
 	VolumeName   string
 	DockerClient *dockerclient.Client
+	Runtime      dockerutil.ContainerRuntime
 	NetworkID    string
 	Image        ibc.DockerImage
 	ports        nat.PortMap
@@ -77,6 +78,7 @@ func NewSidecar(
 		ProcessName:      processName,
 		TestName:         testName,
 		DockerClient:     dockerClient,
+		Runtime:          dockerutil.RuntimeFor(dockerClient),
 		NetworkID:        networkID,
 		Image:            image,
 		homeDir:          homeDir,
@@ -84,7 +86,7 @@ func NewSidecar(
 		startCmd:         startCmd,
 		env:              env,
 	}
-	s.containerLifecycle = dockerutil.NewContainerLifecycle(log, dockerClient, s.Name())
+	s.containerLifecycle = dockerutil.NewContainerLifecycle(log, s.Runtime, s.Name())
 
 	return s
 }
@@ -107,9 +109,25 @@ func (s *SidecarProcess) logger() *zap.Logger {
 }
 
 func (s *SidecarProcess) CreateContainer(ctx context.Context) error {
+	s.containerLifecycle.SetResources(s.resources())
 	return s.containerLifecycle.CreateContainer(ctx, s.TestName, s.NetworkID, s.Image, s.ports, s.Bind(), nil, s.HostName(), s.startCmd, s.env, []string{})
 }
 
+// resources returns the limits from the chain's configuration of this sidecar.
+func (s *SidecarProcess) resources() ibc.ResourceLimits {
+	for _, cfg := range s.Chain.Config().SidecarConfigs {
+		if cfg.ProcessName == s.ProcessName {
+			return cfg.Resources
+		}
+	}
+	return ibc.ResourceLimits{}
+}
+
+// Stats returns a single sample of the sidecar container's resource usage.
+func (s *SidecarProcess) Stats(ctx context.Context) (dockerutil.ContainerStats, error) {
+	return s.containerLifecycle.Stats(ctx)
+}
+
 func (s *SidecarProcess) StartContainer(ctx context.Context) error {
 	return s.containerLifecycle.StartContainer(ctx)
 }
@@ -152,7 +170,7 @@ func (s *SidecarProcess) GetHostPorts(ctx context.Context, portIDs ...string) ([
 // the docker filesystem. relPath describes the location of the file in the
 // docker volume relative to the home directory
 func (s *SidecarProcess) WriteFile(ctx context.Context, content []byte, relPath string) error {
-	fw := dockerutil.NewFileWriter(s.logger(), s.DockerClient, s.TestName)
+	fw := dockerutil.NewFileWriter(s.logger(), s.Runtime, s.TestName)
 	return fw.WriteFile(ctx, s.VolumeName, relPath, content)
 }
 
@@ -170,7 +188,7 @@ func (s *SidecarProcess) CopyFile(ctx context.Context, srcPath, dstPath string)
 // ReadFile reads the contents of a single file at the specified path in the docker filesystem.
 // relPath describes the location of the file in the docker volume relative to the home directory.
 func (s *SidecarProcess) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(s.logger(), s.DockerClient, s.TestName)
+	fr := dockerutil.NewFileRetriever(s.logger(), s.Runtime, s.TestName)
 	gen, err := fr.SingleFileContent(ctx, s.VolumeName, relPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file at %s: %w", relPath, err)
@@ -178,9 +196,32 @@ func (s *SidecarProcess) ReadFile(ctx context.Context, relPath string) ([]byte,
 	return gen, nil
 }
 
+// CopyDirToVolume copies the files of the host directory srcDir selected by filter to the docker filesystem.
+// relDir describes the location of the directory in the docker volume relative to the home directory.
+func (s *SidecarProcess) CopyDirToVolume(ctx context.Context, srcDir, relDir string, filter dockerutil.DirFilter) error {
+	return dockerutil.CopyDirToVolume(ctx, s.volumeDirOptions(filter), srcDir, relDir)
+}
+
+// CopyDirFromVolume copies the files of the directory at relDir in the docker filesystem selected by filter
+// to the host directory dstDir. relDir is relative to the home directory.
+func (s *SidecarProcess) CopyDirFromVolume(ctx context.Context, relDir, dstDir string, filter dockerutil.DirFilter) error {
+	return dockerutil.CopyDirFromVolume(ctx, s.volumeDirOptions(filter), relDir, dstDir)
+}
+
+func (s *SidecarProcess) volumeDirOptions(filter dockerutil.DirFilter) dockerutil.VolumeDirOptions {
+	return dockerutil.VolumeDirOptions{
+		Log:        s.logger(),
+		Runtime:    s.Runtime,
+		VolumeName: s.VolumeName,
+		TestName:   s.TestName,
+		UidGid:     s.Image.UidGid,
+		Filter:     filter,
+	}
+}
+
 // Exec enables the execution of arbitrary CLI cmds against the process.
 func (s *SidecarProcess) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(s.logger(), s.DockerClient, s.NetworkID, s.TestName, s.Image.Repository, s.Image.Version)
+	job := dockerutil.NewImage(s.logger(), s.Runtime, s.NetworkID, s.TestName, s.Image.Repository, s.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
 		Binds: s.Bind(),
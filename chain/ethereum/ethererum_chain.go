@@ -3,14 +3,11 @@ package ethereum
 import (
 	"context"
 	"fmt"
-	"io"
 	"time"
 
 	sdkmath "cosmossdk.io/math"
 
-	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/mount"
-	"github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -42,9 +39,9 @@ type EthereumChain struct {
 
 	log *zap.Logger
 
-	volumeName   string
-	networkID    string
-	dockerClient *dockerclient.Client
+	volumeName string
+	networkID  string
+	runtime    dockerutil.ContainerRuntime
 
 	containerLifecycle *dockerutil.ContainerLifecycle
 
@@ -69,28 +66,26 @@ func (c *EthereumChain) Initialize(ctx context.Context, testName string, cli *do
 	c.pullImages(ctx, cli)
 	image := chainCfg.Images[0]
 
-	c.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, cli, c.Name())
+	c.runtime = dockerutil.RuntimeFor(cli)
+	c.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, c.runtime, c.Name())
 
-	v, err := cli.VolumeCreate(ctx, volume.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel: testName,
+	volumeName, err := c.runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel: testName,
 
-			dockerutil.NodeOwnerLabel: c.Name(),
-		},
+		dockerutil.NodeOwnerLabel: c.Name(),
 	})
 	if err != nil {
 		return fmt.Errorf("creating volume for chain node: %w", err)
 	}
-	c.volumeName = v.Name
+	c.volumeName = volumeName
 	c.networkID = networkID
-	c.dockerClient = cli
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: c.log,
 
-		Client: cli,
+		Runtime: c.runtime,
 
-		VolumeName: v.Name,
+		VolumeName: volumeName,
 		ImageRef:   image.Ref(),
 		TestName:   testName,
 		UidGid:     image.UidGid,
@@ -115,20 +110,12 @@ func (c *EthereumChain) Bind() []string {
 
 func (c *EthereumChain) pullImages(ctx context.Context, cli *dockerclient.Client) {
 	for _, image := range c.Config().Images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			dockertypes.ImagePullOptions{},
-		)
-		if err != nil {
+		if err := dockerutil.PullImage(ctx, cli, image.Repository+":"+image.Version); err != nil {
 			c.log.Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
 			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
 		}
 	}
 }
@@ -151,6 +138,7 @@ func (c *EthereumChain) Start(ctx context.Context, cmd []string, mount []mount.M
 		fmt.Printf("Port Overrides: %v. Using: %v\n", c.cfg.HostPortOverride, usingPorts)
 	}
 
+	c.containerLifecycle.SetResources(c.cfg.Resources)
 	err := c.containerLifecycle.CreateContainer(ctx, c.testName, c.networkID, c.cfg.Images[0], usingPorts, c.Bind(), mount, c.HostName(), cmd, nil, []string{})
 	if err != nil {
 		return err
@@ -190,7 +178,7 @@ func (c *EthereumChain) HostName() string {
 }
 
 func (c *EthereumChain) NewJob() *dockerutil.Image {
-	return dockerutil.NewImage(c.Logger(), c.dockerClient, c.networkID, c.testName, c.cfg.Images[0].Repository, c.cfg.Images[0].Version)
+	return dockerutil.NewImage(c.Logger(), c.runtime, c.networkID, c.testName, c.cfg.Images[0].Repository, c.cfg.Images[0].Version)
 }
 
 func (c *EthereumChain) Exec(ctx context.Context, cmd []string, env []string) (stdout, stderr []byte, err error) {
@@ -0,0 +1,79 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+)
+
+// FindTxs implements blockdb.TxFinder.
+// Each transaction in the block at height is a blockdb.EVMTx, combined with its receipt.
+func (c *EthereumChain) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, error) {
+	latest, err := c.rpcClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get height: %w", err)
+	}
+	if uint64(height) > latest {
+		return nil, fmt.Errorf("block %d: %w", height, blockdb.ErrHeightNotReached)
+	}
+
+	block, err := c.rpcClient.BlockByNumber(ctx, big.NewInt(height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+
+	txs := make([]blockdb.Tx, 0, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		receipt, err := c.rpcClient.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt of tx %s: %w", tx.Hash(), err)
+		}
+		from, err := c.rpcClient.TransactionSender(ctx, tx, block.Hash(), uint(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sender of tx %s: %w", tx.Hash(), err)
+		}
+
+		decoded := blockdb.EVMTx{
+			Hash:     tx.Hash().Hex(),
+			From:     from.Hex(),
+			Value:    tx.Value().String(),
+			Nonce:    tx.Nonce(),
+			Gas:      tx.Gas(),
+			GasPrice: tx.GasPrice().String(),
+			GasUsed:  receipt.GasUsed,
+			Input:    hexutil.Encode(tx.Data()),
+			Status:   receipt.Status,
+			Logs:     make([]blockdb.EVMLog, 0, len(receipt.Logs)),
+		}
+		if tx.To() != nil {
+			decoded.To = tx.To().Hex()
+		}
+		if receipt.ContractAddress != (common.Address{}) {
+			decoded.ContractAddress = receipt.ContractAddress.Hex()
+		}
+		for _, l := range receipt.Logs {
+			topics := make([]string, len(l.Topics))
+			for j, topic := range l.Topics {
+				topics[j] = topic.Hex()
+			}
+			decoded.Logs = append(decoded.Logs, blockdb.EVMLog{
+				Index:   l.Index,
+				Address: l.Address.Hex(),
+				Topics:  topics,
+				Data:    hexutil.Encode(l.Data),
+			})
+		}
+
+		bz, err := json.Marshal(decoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tx %s: %w", tx.Hash(), err)
+		}
+		txs = append(txs, blockdb.Tx{Data: bz})
+	}
+	return txs, nil
+}
@@ -14,7 +14,6 @@ import (
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
-	volumetypes "github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"github.com/hashicorp/go-version"
@@ -33,6 +32,7 @@ type TendermintNode struct {
 	Chain        ibc.Chain
 	NetworkID    string
 	DockerClient *dockerclient.Client
+	Runtime      dockerutil.ContainerRuntime
 	Client       rpcclient.Client
 	TestName     string
 	Image        ibc.DockerImage
@@ -51,24 +51,22 @@ func NewTendermintNode(
 	image ibc.DockerImage,
 ) (*TendermintNode, error) {
 	tn := &TendermintNode{Log: log, Index: i, Chain: c,
-		DockerClient: dockerClient, NetworkID: networkID, TestName: testName, Image: image}
+		DockerClient: dockerClient, Runtime: dockerutil.RuntimeFor(dockerClient), NetworkID: networkID, TestName: testName, Image: image}
 
-	tn.containerLifecycle = dockerutil.NewContainerLifecycle(log, dockerClient, tn.Name())
+	tn.containerLifecycle = dockerutil.NewContainerLifecycle(log, tn.Runtime, tn.Name())
 
-	tv, err := dockerClient.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel:   testName,
-			dockerutil.NodeOwnerLabel: tn.Name(),
-		},
+	volumeName, err := tn.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel:   testName,
+		dockerutil.NodeOwnerLabel: tn.Name(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating tendermint volume: %w", err)
 	}
-	tn.VolumeName = tv.Name
+	tn.VolumeName = volumeName
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: log,
 
-		Client: dockerClient,
+		Runtime: tn.Runtime,
 
 		VolumeName: tn.VolumeName,
 		ImageRef:   tn.Image.Ref(),
@@ -143,7 +141,7 @@ func (tn *TendermintNode) GenesisFileContent(ctx context.Context) ([]byte, error
 // ReadFile reads the contents of a single file at the specified path in the docker filesystem.
 // relPath describes the location of the file in the docker volume relative to the home directory.
 func (tn *TendermintNode) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.Runtime, tn.TestName)
 	gen, err := fr.SingleFileContent(ctx, tn.VolumeName, relPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file at %s: %w", relPath, err)
@@ -152,7 +150,7 @@ func (tn *TendermintNode) ReadFile(ctx context.Context, relPath string) ([]byte,
 }
 
 func (tn *TendermintNode) OverwriteGenesisFile(ctx context.Context, content []byte) error {
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.Runtime, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, "config/genesis.json", content); err != nil {
 		return fmt.Errorf("overwriting genesis.json: %w", err)
 	}
@@ -222,7 +220,7 @@ func (tn *TendermintNode) SetConfigAndPeers(ctx context.Context, peers string) e
 	return testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
-		tn.DockerClient,
+		tn.Runtime,
 		tn.TestName,
 		tn.VolumeName,
 		"config/config.toml",
@@ -272,6 +270,7 @@ func (tn *TendermintNode) CreateNodeContainer(ctx context.Context, additionalFla
 	cmd := []string{chainCfg.Bin, "start", "--home", tn.HomeDir()}
 	cmd = append(cmd, additionalFlags...)
 
+	tn.containerLifecycle.SetResources(chainCfg.Resources)
 	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, sentryPorts, tn.Bind(), nil, tn.HostName(), cmd, nil, []string{})
 }
 
@@ -373,7 +372,7 @@ func (tn TendermintNodes) LogGenesisHashes(ctx context.Context) error {
 }
 
 func (tn *TendermintNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(tn.Log, tn.DockerClient, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
+	job := dockerutil.NewImage(tn.Log, tn.Runtime, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
 		Binds: tn.Bind(),
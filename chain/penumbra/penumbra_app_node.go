@@ -9,7 +9,6 @@ import (
 	"strings"
 
 	"github.com/BurntSushi/toml"
-	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
@@ -28,6 +27,7 @@ type PenumbraAppNode struct {
 	TestName     string
 	NetworkID    string
 	DockerClient *client.Client
+	Runtime      dockerutil.ContainerRuntime
 	Image        ibc.DockerImage
 
 	containerLifecycle *dockerutil.ContainerLifecycle
@@ -54,25 +54,23 @@ func NewPenumbraAppNode(
 	image ibc.DockerImage,
 ) (*PenumbraAppNode, error) {
 	pn := &PenumbraAppNode{log: log, Index: index, Chain: chain,
-		DockerClient: dockerClient, NetworkID: networkID, TestName: testName, Image: image}
+		DockerClient: dockerClient, Runtime: dockerutil.RuntimeFor(dockerClient), NetworkID: networkID, TestName: testName, Image: image}
 
-	pn.containerLifecycle = dockerutil.NewContainerLifecycle(log, dockerClient, pn.Name())
+	pn.containerLifecycle = dockerutil.NewContainerLifecycle(log, pn.Runtime, pn.Name())
 
-	pv, err := dockerClient.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel:   testName,
-			dockerutil.NodeOwnerLabel: pn.Name(),
-		},
+	volumeName, err := pn.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel:   testName,
+		dockerutil.NodeOwnerLabel: pn.Name(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating penumbra volume: %w", err)
 	}
 
-	pn.VolumeName = pv.Name
+	pn.VolumeName = volumeName
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: log,
 
-		Client: dockerClient,
+		Runtime: pn.Runtime,
 
 		VolumeName: pn.VolumeName,
 		ImageRef:   pn.Image.Ref(),
@@ -148,7 +146,7 @@ type PcliConfig struct {
 // ReadFile attempts to read a file from the Docker filesystem at the specified path.
 // relPath describes the location of the file in the Docker volume relative to the home directory.
 func (p *PenumbraAppNode) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(p.log, p.DockerClient, p.TestName)
+	fr := dockerutil.NewFileRetriever(p.log, p.Runtime, p.TestName)
 	fileBz, err := fr.SingleFileContent(ctx, p.VolumeName, relPath)
 	if err != nil {
 		return nil, err
@@ -228,7 +226,7 @@ func (p *PenumbraAppNode) AllocationsInputFileContainer() string {
 // genesisFileContent attempts to read the contents of the genesis.json file associated with the
 // network that we are attempting to initialize from genesis.
 func (p *PenumbraAppNode) genesisFileContent(ctx context.Context) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(p.log, p.DockerClient, p.TestName)
+	fr := dockerutil.NewFileRetriever(p.log, p.Runtime, p.TestName)
 	gen, err := fr.SingleFileContent(ctx, p.VolumeName, ".penumbra/testnet_data/node0/cometbft/config/genesis.json")
 	if err != nil {
 		return nil, fmt.Errorf("error getting genesis.json content: %w", err)
@@ -251,7 +249,7 @@ func (p *PenumbraAppNode) GenerateGenesisFile(
 		return fmt.Errorf("error marshalling validators to json: %w", err)
 	}
 
-	fw := dockerutil.NewFileWriter(p.log, p.DockerClient, p.TestName)
+	fw := dockerutil.NewFileWriter(p.log, p.Runtime, p.TestName)
 	if err := fw.WriteFile(ctx, p.VolumeName, "validators.json", validatorsJson); err != nil {
 		return fmt.Errorf("error writing validators to file: %w", err)
 	}
@@ -352,6 +350,7 @@ func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context, tendermintAdd
 		"--home", p.HomeDir(),
 	}
 
+	p.containerLifecycle.SetResources(p.Chain.Config().Resources)
 	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, exposedPorts, p.Bind(), nil, p.HostName(), cmd, p.Chain.Config().Env, []string{})
 }
 
@@ -380,7 +379,7 @@ func (p *PenumbraAppNode) StartContainer(ctx context.Context) error {
 
 // Exec run a container for a specific job and blocks until the container exits.
 func (p *PenumbraAppNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
+	job := dockerutil.NewImage(p.log, p.Runtime, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Binds: p.Bind(),
 		Env:   env,
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -17,7 +16,6 @@ import (
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
-	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/tendermint"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
@@ -302,20 +300,12 @@ func (c *PenumbraChain) initializeChainNodes(
 	count := c.numValidators + c.numFullNodes
 	chainCfg := c.Config()
 	for _, image := range chainCfg.Images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			types.ImagePullOptions{},
-		)
-		if err != nil {
+		if err := dockerutil.PullImage(ctx, cli, image.Repository+":"+image.Version); err != nil {
 			c.log.Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
 			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
 		}
 	}
 
@@ -376,7 +366,7 @@ func (c *PenumbraChain) Start(_ string, ctx context.Context, additionalGenesisWa
 				return fmt.Errorf("error initializing validator files: %v", err)
 			}
 
-			fr := dockerutil.NewFileRetriever(c.log, v.TendermintNode.DockerClient, v.TendermintNode.TestName)
+			fr := dockerutil.NewFileRetriever(c.log, v.TendermintNode.Runtime, v.TendermintNode.TestName)
 			privValKeyBytes, err := fr.SingleFileContent(egCtx, v.TendermintNode.VolumeName, "config/priv_validator_key.json")
 			if err != nil {
 				return fmt.Errorf("error reading tendermint privval key file: %v", err)
@@ -395,9 +385,9 @@ func (c *PenumbraChain) Start(_ string, ctx context.Context, additionalGenesisWa
 				return fmt.Errorf("error initializing validator template on penumbra node: %v", err)
 			}
 
-			// In all likelihood, the PenumbraAppNode and TendermintNode have the same DockerClient and TestName,
+			// In all likelihood, the PenumbraAppNode and TendermintNode have the same Runtime and TestName,
 			// but instantiate a new FileRetriever to be defensive.
-			fr = dockerutil.NewFileRetriever(c.log, v.PenumbraAppNode.DockerClient, v.PenumbraAppNode.TestName)
+			fr = dockerutil.NewFileRetriever(c.log, v.PenumbraAppNode.Runtime, v.PenumbraAppNode.TestName)
 			validatorTemplateDefinitionFileBytes, err := fr.SingleFileContent(egCtx, v.PenumbraAppNode.VolumeName, "validator.toml")
 			if err != nil {
 				return fmt.Errorf("error reading validator definition template file: %v", err)
@@ -481,13 +471,13 @@ func (c *PenumbraChain) Start(_ string, ctx context.Context, additionalGenesisWa
 		eg.Go(func() error {
 			firstValPrivKeyRelPath := fmt.Sprintf(".penumbra/testnet_data/node%d/cometbft/config/priv_validator_key.json", i)
 
-			fr := dockerutil.NewFileRetriever(c.log, firstVal.PenumbraAppNode.DockerClient, firstVal.PenumbraAppNode.TestName)
+			fr := dockerutil.NewFileRetriever(c.log, firstVal.PenumbraAppNode.Runtime, firstVal.PenumbraAppNode.TestName)
 			pk, err := fr.SingleFileContent(egCtx, firstVal.PenumbraAppNode.VolumeName, firstValPrivKeyRelPath)
 			if err != nil {
 				return fmt.Errorf("error getting validator private key content: %w", err)
 			}
 
-			fw := dockerutil.NewFileWriter(c.log, val.PenumbraAppNode.DockerClient, val.PenumbraAppNode.TestName)
+			fw := dockerutil.NewFileWriter(c.log, val.PenumbraAppNode.Runtime, val.PenumbraAppNode.TestName)
 			if err := fw.WriteFile(egCtx, val.TendermintNode.VolumeName, "config/priv_validator_key.json", pk); err != nil {
 				return fmt.Errorf("overwriting priv_validator_key.json: %w", err)
 			}
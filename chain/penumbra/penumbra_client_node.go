@@ -18,7 +18,6 @@ import (
 	//nolint:staticcheck
 	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
 
-	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	asset "github.com/strangelove-ventures/interchaintest/v8/chain/penumbra/core/asset/v1"
@@ -47,6 +46,7 @@ type PenumbraClientNode struct {
 	TestName     string
 	NetworkID    string
 	DockerClient *client.Client
+	Runtime      dockerutil.ContainerRuntime
 	Image        ibc.DockerImage
 
 	GRPCConn *grpc.ClientConn
@@ -83,28 +83,27 @@ func NewClientNode(
 		TestName:     testName,
 		Image:        image,
 		DockerClient: dockerClient,
+		Runtime:      dockerutil.RuntimeFor(dockerClient),
 		NetworkID:    networkID,
 		address:      address,
 		addrString:   addrString,
 	}
 
-	p.containerLifecycle = dockerutil.NewContainerLifecycle(log, dockerClient, p.Name())
+	p.containerLifecycle = dockerutil.NewContainerLifecycle(log, p.Runtime, p.Name())
 
-	tv, err := dockerClient.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel:   testName,
-			dockerutil.NodeOwnerLabel: p.Name(),
-		},
+	volumeName, err := p.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel:   testName,
+		dockerutil.NodeOwnerLabel: p.Name(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating pclientd volume: %w", err)
 	}
 
-	p.VolumeName = tv.Name
+	p.VolumeName = volumeName
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: log,
 
-		Client: dockerClient,
+		Runtime: p.Runtime,
 
 		VolumeName: p.VolumeName,
 		ImageRef:   image.Ref(),
@@ -530,7 +529,7 @@ func (p *PenumbraClientNode) GetDenomMetadata(ctx context.Context, assetId *asse
 // the Docker filesystem. relPath describes the location of the file in the
 // Docker volume relative to the home directory.
 func (p *PenumbraClientNode) WriteFile(ctx context.Context, content []byte, relPath string) error {
-	fw := dockerutil.NewFileWriter(p.log, p.DockerClient, p.TestName)
+	fw := dockerutil.NewFileWriter(p.log, p.Runtime, p.TestName)
 	return fw.WriteFile(ctx, p.VolumeName, relPath, content)
 }
 
@@ -561,6 +560,7 @@ func (p *PenumbraClientNode) CreateNodeContainer(ctx context.Context) error {
 		"start",
 	}
 
+	p.containerLifecycle.SetResources(p.Chain.Config().Resources)
 	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, pclientdPorts, p.Bind(), nil, p.HostName(), cmd, p.Chain.Config().Env, []string{})
 }
 
@@ -592,7 +592,7 @@ func (p *PenumbraClientNode) StartContainer(ctx context.Context) error {
 
 // Exec runs a container for a specific job and blocks until the container exits.
 func (p *PenumbraClientNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
+	job := dockerutil.NewImage(p.log, p.Runtime, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Binds: p.Bind(),
 		Env:   env,
@@ -0,0 +1,143 @@
+package polkadot
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+
+	gsrpc "github.com/misko9/go-substrate-rpc-client/v4"
+	gstypes "github.com/misko9/go-substrate-rpc-client/v4/types"
+	"github.com/misko9/go-substrate-rpc-client/v4/types/codec"
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+)
+
+// metadataCache caches runtime metadata by spec version,
+// so the extrinsics of many blocks can be decoded without fetching the metadata for each block.
+type metadataCache struct {
+	mu    sync.Mutex
+	metas map[gstypes.U32]*gstypes.Metadata
+}
+
+func (mc *metadataCache) get(api *gsrpc.SubstrateAPI, blockHash gstypes.Hash) (*gstypes.Metadata, error) {
+	rv, err := api.RPC.State.GetRuntimeVersion(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("get runtime version: %w", err)
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if meta, ok := mc.metas[rv.SpecVersion]; ok {
+		return meta, nil
+	}
+	meta, err := api.RPC.State.GetMetadata(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("get metadata: %w", err)
+	}
+	if mc.metas == nil {
+		mc.metas = make(map[gstypes.U32]*gstypes.Metadata)
+	}
+	mc.metas[rv.SpecVersion] = meta
+	return meta, nil
+}
+
+// findExtrinsics decodes each extrinsic in the block at height into a blockdb.SubstrateExtrinsic.
+func findExtrinsics(api *gsrpc.SubstrateAPI, metadata *metadataCache, height int64) ([]blockdb.Tx, error) {
+	header, err := api.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return nil, fmt.Errorf("get latest header: %w", err)
+	}
+	if height > int64(header.Number) {
+		return nil, fmt.Errorf("block %d: %w", height, blockdb.ErrHeightNotReached)
+	}
+
+	hash, err := api.RPC.Chain.GetBlockHash(uint64(height))
+	if err != nil {
+		return nil, fmt.Errorf("get block hash: %w", err)
+	}
+	block, err := api.RPC.Chain.GetBlock(hash)
+	if err != nil {
+		return nil, fmt.Errorf("get block: %w", err)
+	}
+	meta, err := metadata.get(api, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]blockdb.Tx, 0, len(block.Block.Extrinsics))
+	for i, ext := range block.Block.Extrinsics {
+		section, method := callName(meta, ext.Method.CallIndex)
+		decoded := blockdb.SubstrateExtrinsic{
+			Index:   i,
+			Section: section,
+			Method:  method,
+			Args:    codec.HexEncodeToString(ext.Method.Args),
+		}
+		if ext.IsSigned() {
+			decoded.Signer = signerAddress(ext.Signature.Signer)
+			decoded.Nonce = (*big.Int)(&ext.Signature.Nonce).Uint64()
+			decoded.Tip = (*big.Int)(&ext.Signature.Tip).String()
+		}
+
+		bz, err := json.Marshal(decoded)
+		if err != nil {
+			return nil, fmt.Errorf("marshal extrinsic %d: %w", i, err)
+		}
+		txs = append(txs, blockdb.Tx{Data: bz})
+	}
+	return txs, nil
+}
+
+// callName returns the pallet and call names for idx.
+// Calls not found in the metadata are named by their indexes.
+func callName(meta *gstypes.Metadata, idx gstypes.CallIndex) (section, method string) {
+	section = strconv.Itoa(int(idx.SectionIndex))
+	method = strconv.Itoa(int(idx.MethodIndex))
+	if meta.Version != 14 {
+		return section, method
+	}
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if !pallet.HasCalls || uint8(pallet.Index) != idx.SectionIndex {
+			continue
+		}
+		section = string(pallet.Name)
+		typ, ok := meta.AsMetadataV14.EfficientLookup[pallet.Calls.Type.Int64()]
+		if !ok {
+			break
+		}
+		for _, v := range typ.Def.Variant.Variants {
+			if uint8(v.Index) == idx.MethodIndex {
+				method = string(v.Name)
+				break
+			}
+		}
+		break
+	}
+	return section, method
+}
+
+// signerAddress returns the SS58 address of signer if possible, otherwise a hex or index representation.
+func signerAddress(signer gstypes.MultiAddress) string {
+	switch {
+	case signer.IsID:
+		if addr, err := EncodeAddressSS58(signer.AsID[:]); err == nil {
+			return addr
+		}
+		return codec.HexEncodeToString(signer.AsID[:])
+	case signer.IsAddress32:
+		if addr, err := EncodeAddressSS58(signer.AsAddress32[:]); err == nil {
+			return addr
+		}
+		return codec.HexEncodeToString(signer.AsAddress32[:])
+	case signer.IsAddress20:
+		return codec.HexEncodeToString(signer.AsAddress20[:])
+	case signer.IsRaw:
+		return codec.HexEncodeToString(signer.AsRaw)
+	case signer.IsIndex:
+		return "index " + strconv.FormatUint(uint64(signer.AsIndex), 10)
+	}
+	return ""
+}
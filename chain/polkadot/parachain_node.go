@@ -34,6 +34,7 @@ type ParachainNode struct {
 	containerLifecycle *dockerutil.ContainerLifecycle
 	VolumeName         string
 	DockerClient       *client.Client
+	Runtime            dockerutil.ContainerRuntime
 	Image              ibc.DockerImage
 
 	Chain           ibc.Chain
@@ -256,6 +257,7 @@ func (pn *ParachainNode) CreateNodeContainer(ctx context.Context) error {
 	cmd = append(cmd, "--", fmt.Sprintf("--chain=%s", pn.RawRelayChainSpecFilePathFull()))
 	cmd = append(cmd, pn.RelayChainFlags...)
 
+	pn.containerLifecycle.SetResources(pn.Chain.Config().Resources)
 	return pn.containerLifecycle.CreateContainer(ctx, pn.TestName, pn.NetworkID, pn.Image, exposedPorts, pn.Bind(), nil, pn.HostName(), cmd, nil, []string{})
 }
 
@@ -296,7 +298,7 @@ func (pn *ParachainNode) StartContainer(ctx context.Context) error {
 
 // Exec run a container for a specific job and block until the container exits.
 func (pn *ParachainNode) Exec(ctx context.Context, cmd []string, env []string) dockerutil.ContainerExecResult {
-	job := dockerutil.NewImage(pn.log, pn.DockerClient, pn.NetworkID, pn.TestName, pn.Image.Repository, pn.Image.Version)
+	job := dockerutil.NewImage(pn.log, pn.Runtime, pn.NetworkID, pn.TestName, pn.Image.Repository, pn.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Binds: pn.Bind(),
 		Env:   env,
@@ -6,7 +6,6 @@ import (
 	crand "crypto/rand"
 	"encoding/json"
 	"fmt"
-	"io"
 	"strings"
 
 	"cosmossdk.io/math"
@@ -14,8 +13,6 @@ import (
 	"github.com/StirlingMarketingGroup/go-namecase"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/go-bip39"
-	"github.com/docker/docker/api/types"
-	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/icza/dyno"
@@ -42,6 +39,7 @@ type PolkadotChain struct {
 	RelayChainNodes    RelayChainNodes
 	ParachainNodes     []ParachainNodes
 	keyring            keyring.Keyring
+	metadata           metadataCache
 }
 
 // PolkadotAuthority is used when constructing the validator authorities in the substrate chain spec.
@@ -144,6 +142,7 @@ func (c *PolkadotChain) NewRelayChainNode(
 		Index:             i,
 		Chain:             c,
 		DockerClient:      dockerClient,
+		Runtime:           dockerutil.RuntimeFor(dockerClient),
 		NetworkID:         networkID,
 		TestName:          testName,
 		Image:             image,
@@ -154,24 +153,22 @@ func (c *PolkadotChain) NewRelayChainNode(
 		EcdsaPrivateKey:   *ecdsaPrivKey,
 	}
 
-	pn.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, dockerClient, pn.Name())
+	pn.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, pn.Runtime, pn.Name())
 
-	v, err := dockerClient.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel: testName,
+	volumeName, err := pn.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel: testName,
 
-			dockerutil.NodeOwnerLabel: pn.Name(),
-		},
+		dockerutil.NodeOwnerLabel: pn.Name(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume for chain node: %w", err)
 	}
-	pn.VolumeName = v.Name
+	pn.VolumeName = volumeName
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log:        c.log,
-		Client:     dockerClient,
-		VolumeName: v.Name,
+		Runtime:    pn.Runtime,
+		VolumeName: volumeName,
 		ImageRef:   image.Ref(),
 		TestName:   testName,
 		UidGid:     image.UidGid,
@@ -199,6 +196,7 @@ func (c *PolkadotChain) NewParachainNode(
 		Index:           i,
 		Chain:           c,
 		DockerClient:    dockerClient,
+		Runtime:         dockerutil.RuntimeFor(dockerClient),
 		NetworkID:       networkID,
 		TestName:        testName,
 		NodeKey:         nodeKey,
@@ -209,24 +207,22 @@ func (c *PolkadotChain) NewParachainNode(
 		RelayChainFlags: parachainConfig.RelayChainFlags,
 	}
 
-	pn.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, dockerClient, pn.Name())
+	pn.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, pn.Runtime, pn.Name())
 
-	v, err := dockerClient.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel: testName,
+	volumeName, err := pn.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel: testName,
 
-			dockerutil.NodeOwnerLabel: pn.Name(),
-		},
+		dockerutil.NodeOwnerLabel: pn.Name(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume for chain node: %w", err)
 	}
-	pn.VolumeName = v.Name
+	pn.VolumeName = volumeName
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log:        c.log,
-		Client:     dockerClient,
-		VolumeName: v.Name,
+		Runtime:    pn.Runtime,
+		VolumeName: volumeName,
 		ImageRef:   parachainConfig.Image.Ref(),
 		TestName:   testName,
 		UidGid:     parachainConfig.Image.UidGid,
@@ -248,20 +244,12 @@ func (c *PolkadotChain) Initialize(ctx context.Context, testName string, cli *cl
 		images = append(images, parachain.Image)
 	}
 	for _, image := range images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			types.ImagePullOptions{},
-		)
-		if err != nil {
+		if err := dockerutil.PullImage(ctx, cli, image.Repository+":"+image.Version); err != nil {
 			c.log.Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
 			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
 		}
 	}
 	for i := 0; i < c.numRelayChainNodes; i++ {
@@ -429,7 +417,7 @@ func (c *PolkadotChain) Start(testName string, ctx context.Context, additionalGe
 			n := n
 			eg.Go(func() error {
 				c.logger().Info("Copying parachain chain spec", zap.String("container", n.Name()))
-				fw := dockerutil.NewFileWriter(n.logger(), n.DockerClient, n.TestName)
+				fw := dockerutil.NewFileWriter(n.logger(), n.Runtime, n.TestName)
 				return fw.WriteFile(ctx, n.VolumeName, n.ParachainChainSpecFileName(), parachainChainSpec)
 			})
 		}
@@ -443,8 +431,8 @@ func (c *PolkadotChain) Start(testName string, ctx context.Context, additionalGe
 	if err := firstNode.GenerateChainSpec(ctx); err != nil {
 		return fmt.Errorf("error generating chain spec: %w", err)
 	}
-	fr := dockerutil.NewFileRetriever(c.logger(), firstNode.DockerClient, c.testName)
-	fw := dockerutil.NewFileWriter(c.logger(), firstNode.DockerClient, c.testName)
+	fr := dockerutil.NewFileRetriever(c.logger(), firstNode.Runtime, c.testName)
+	fw := dockerutil.NewFileWriter(c.logger(), firstNode.Runtime, c.testName)
 
 	chainSpecBytes, err := fr.SingleFileContent(ctx, firstNode.VolumeName, firstNode.ChainSpecFilePathContainer())
 	if err != nil {
@@ -847,9 +835,13 @@ func (c *PolkadotChain) GetKeyringPair(keyName string) (signature.KeyringPair, e
 	return kp, nil
 }
 
-// FindTxs implements blockdb.BlockSaver (Not implemented yet for polkadot, but we don't want to exit)
+// FindTxs implements blockdb.TxFinder.
+// Each extrinsic in the block at height is a blockdb.SubstrateExtrinsic.
 func (c *PolkadotChain) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, error) {
-	return []blockdb.Tx{}, nil
+	if len(c.ParachainNodes) > 0 && len(c.ParachainNodes[0]) > 0 {
+		return findExtrinsics(c.ParachainNodes[0][0].api, &c.metadata, height)
+	}
+	return findExtrinsics(c.RelayChainNodes[0].api, &c.metadata, height)
 }
 
 // GetIbcBalance returns the Coins type of ibc coins in account
@@ -33,6 +33,7 @@ type RelayChainNode struct {
 	containerLifecycle *dockerutil.ContainerLifecycle
 	VolumeName         string
 	DockerClient       *client.Client
+	Runtime            dockerutil.ContainerRuntime
 	Image              ibc.DockerImage
 
 	Chain             ibc.Chain
@@ -169,7 +170,7 @@ func (p *RelayChainNode) GenerateChainSpec(ctx context.Context) error {
 	if res.Err != nil {
 		return res.Err
 	}
-	fw := dockerutil.NewFileWriter(p.logger(), p.DockerClient, p.TestName)
+	fw := dockerutil.NewFileWriter(p.logger(), p.Runtime, p.TestName)
 	return fw.WriteFile(ctx, p.VolumeName, p.ChainSpecFilePathContainer(), res.Stdout)
 }
 
@@ -187,7 +188,7 @@ func (p *RelayChainNode) GenerateChainSpecRaw(ctx context.Context) error {
 	if res.Err != nil {
 		return res.Err
 	}
-	fw := dockerutil.NewFileWriter(p.logger(), p.DockerClient, p.TestName)
+	fw := dockerutil.NewFileWriter(p.logger(), p.Runtime, p.TestName)
 	return fw.WriteFile(ctx, p.VolumeName, p.RawChainSpecFilePathRelative(), res.Stdout)
 }
 
@@ -224,6 +225,7 @@ func (p *RelayChainNode) CreateNodeContainer(ctx context.Context) error {
 		fmt.Sprintf("--public-addr=%s", multiAddress),
 		"--base-path", p.NodeHome(),
 	}
+	p.containerLifecycle.SetResources(p.Chain.Config().Resources)
 	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, exposedPorts, p.Bind(), nil, p.HostName(), cmd, nil, []string{})
 }
 
@@ -267,7 +269,7 @@ func (p *RelayChainNode) StartContainer(ctx context.Context) error {
 
 // Exec runs a container for a specific job and blocks until the container exits.
 func (p *RelayChainNode) Exec(ctx context.Context, cmd []string, env []string) dockerutil.ContainerExecResult {
-	job := dockerutil.NewImage(p.log, p.DockerClient, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
+	job := dockerutil.NewImage(p.log, p.Runtime, p.NetworkID, p.TestName, p.Image.Repository, p.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Binds: p.Bind(),
 		Env:   env,
@@ -106,7 +106,7 @@ func (b *Broadcaster) GetClientContext(ctx context.Context, user User) (client.C
 	if !ok {
 		localDir := b.t.TempDir()
 		containerKeyringDir := path.Join(cn.HomeDir(), "keyring-test")
-		kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, cn.DockerClient, localDir, containerKeyringDir, cn.containerLifecycle.ContainerID())
+		kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, cn.Runtime, localDir, containerKeyringDir, cn.containerLifecycle.ContainerID())
 		if err != nil {
 			return client.Context{}, err
 		}
@@ -36,6 +36,7 @@ type SidecarProcess struct {
 
 	VolumeName   string
 	DockerClient *dockerclient.Client
+	Runtime      dockerutil.ContainerRuntime
 	NetworkID    string
 	Image        ibc.DockerImage
 	ports        nat.PortMap
@@ -80,6 +81,7 @@ func NewSidecar(
 		ProcessName:      processName,
 		TestName:         testName,
 		DockerClient:     dockerClient,
+		Runtime:          dockerutil.RuntimeFor(dockerClient),
 		NetworkID:        networkID,
 		Image:            image,
 		homeDir:          homeDir,
@@ -87,7 +89,7 @@ func NewSidecar(
 		startCmd:         startCmd,
 		env:              env,
 	}
-	s.containerLifecycle = dockerutil.NewContainerLifecycle(log, dockerClient, s.Name())
+	s.containerLifecycle = dockerutil.NewContainerLifecycle(log, s.Runtime, s.Name())
 
 	return s
 }
@@ -115,9 +117,20 @@ func (s *SidecarProcess) CreateContainer(ctx context.Context, nodeVolume []strin
 	if nodeVolume != nil {
 		volumes = append(volumes, nodeVolume...)
 	}
+	s.containerLifecycle.SetResources(s.resources())
 	return s.containerLifecycle.CreateContainer(ctx, s.TestName, s.NetworkID, s.Image, s.ports, volumes, nil, s.HostName(), s.startCmd, s.env, []string{})
 }
 
+// resources returns the limits from the chain's configuration of this sidecar.
+func (s *SidecarProcess) resources() ibc.ResourceLimits {
+	for _, cfg := range s.Chain.Config().SidecarConfigs {
+		if cfg.ProcessName == s.ProcessName {
+			return cfg.Resources
+		}
+	}
+	return ibc.ResourceLimits{}
+}
+
 func (s *SidecarProcess) StartContainer(ctx context.Context) error {
 	return s.containerLifecycle.StartContainer(ctx)
 }
@@ -160,7 +173,7 @@ func (s *SidecarProcess) GetHostPorts(ctx context.Context, portIDs ...string) ([
 // the docker filesystem. relPath describes the location of the file in the
 // docker volume relative to the home directory
 func (s *SidecarProcess) WriteFile(ctx context.Context, content []byte, relPath string) error {
-	fw := dockerutil.NewFileWriter(s.logger(), s.DockerClient, s.TestName)
+	fw := dockerutil.NewFileWriter(s.logger(), s.Runtime, s.TestName)
 	return fw.WriteFile(ctx, s.VolumeName, relPath, content)
 }
 
@@ -178,7 +191,7 @@ func (s *SidecarProcess) CopyFile(ctx context.Context, srcPath, dstPath string)
 // ReadFile reads the contents of a single file at the specified path in the docker filesystem.
 // relPath describes the location of the file in the docker volume relative to the home directory.
 func (s *SidecarProcess) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(s.logger(), s.DockerClient, s.TestName)
+	fr := dockerutil.NewFileRetriever(s.logger(), s.Runtime, s.TestName)
 	gen, err := fr.SingleFileContent(ctx, s.VolumeName, relPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file at %s: %w", relPath, err)
@@ -188,7 +201,7 @@ func (s *SidecarProcess) ReadFile(ctx context.Context, relPath string) ([]byte,
 
 // Exec enables the execution of arbitrary CLI cmds against the process.
 func (s *SidecarProcess) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(s.logger(), s.DockerClient, s.NetworkID, s.TestName, s.Image.Repository, s.Image.Version)
+	job := dockerutil.NewImage(s.logger(), s.Runtime, s.NetworkID, s.TestName, s.Image.Repository, s.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
 		Binds: s.Bind(),
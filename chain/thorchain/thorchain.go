@@ -6,7 +6,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
 	"math"
 	"os"
 	"sort"
@@ -23,8 +22,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types" // nolint:staticcheck
 	chanTypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
-	dockertypes "github.com/docker/docker/api/types"
-	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
@@ -172,7 +169,7 @@ func (c *Thorchain) AddFullNodes(ctx context.Context, configFileOverrides map[st
 				if err := testutil.ModifyTomlConfigFile(
 					ctx,
 					fn.logger(),
-					fn.DockerClient,
+					fn.Runtime,
 					fn.TestName,
 					fn.VolumeName,
 					configFile,
@@ -446,20 +443,12 @@ func (c *Thorchain) pullImages(ctx context.Context, cli *client.Client) {
 		if image.Version == "local" {
 			continue
 		}
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			dockertypes.ImagePullOptions{},
-		)
-		if err != nil {
+		if err := dockerutil.PullImage(ctx, cli, image.Repository+":"+image.Version); err != nil {
 			c.log.Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
 			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
 		}
 	}
 }
@@ -480,27 +469,25 @@ func (c *Thorchain) NewChainNode(
 
 	tn.logger().Info("Creating volume")
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel: testName,
+	volumeName, err := tn.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel: testName,
 
-			dockerutil.NodeOwnerLabel: tn.Name(),
-		},
+		dockerutil.NodeOwnerLabel: tn.Name(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume for chain node: %w", err)
 	}
 
-	tn.logger().Info("Setting volume owner", zap.String("volume", v.Name))
+	tn.logger().Info("Setting volume owner", zap.String("volume", volumeName))
 
-	tn.VolumeName = v.Name
+	tn.VolumeName = volumeName
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: c.log,
 
-		Client: cli,
+		Runtime: tn.Runtime,
 
-		VolumeName: v.Name,
+		VolumeName: volumeName,
 		ImageRef:   image.Ref(),
 		TestName:   testName,
 		UidGid:     image.UidGid,
@@ -508,7 +495,7 @@ func (c *Thorchain) NewChainNode(
 		return nil, fmt.Errorf("set volume owner: %w", err)
 	}
 
-	tn.logger().Info("Created docker volume and set owner", zap.String("volume", v.Name))
+	tn.logger().Info("Created docker volume and set owner", zap.String("volume", volumeName))
 
 	for _, cfg := range c.cfg.SidecarConfigs {
 		if !cfg.ValidatorProcess {
@@ -547,23 +534,21 @@ func (c *Thorchain) NewSidecarProcess(
 	// The SidecarProcess's VolumeName cannot be set until after we create the volume.
 	s := NewSidecar(c.log, false, preStart, c, cli, networkID, processName, testName, image, homeDir, index, ports, startCmd, env)
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel:   testName,
-			dockerutil.NodeOwnerLabel: s.Name(),
-		},
+	volumeName, err := s.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel:   testName,
+		dockerutil.NodeOwnerLabel: s.Name(),
 	})
 	if err != nil {
 		return fmt.Errorf("creating volume for sidecar process: %w", err)
 	}
-	s.VolumeName = v.Name
+	s.VolumeName = volumeName
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: c.log,
 
-		Client: cli,
+		Runtime: s.Runtime,
 
-		VolumeName: v.Name,
+		VolumeName: volumeName,
 		ImageRef:   image.Ref(),
 		TestName:   testName,
 		UidGid:     image.UidGid,
@@ -702,7 +687,7 @@ func (c *Thorchain) prepNodes(ctx context.Context, genesisAmounts [][]types.Coin
 				if err := testutil.ModifyTomlConfigFile(
 					egCtx,
 					v.logger(),
-					v.DockerClient,
+					v.Runtime,
 					v.TestName,
 					v.VolumeName,
 					configFile,
@@ -764,7 +749,7 @@ func (c *Thorchain) prepNodes(ctx context.Context, genesisAmounts [][]types.Coin
 				if err := testutil.ModifyTomlConfigFile(
 					egCtx,
 					n.logger(),
-					n.DockerClient,
+					n.Runtime,
 					n.TestName,
 					n.VolumeName,
 					configFile,
@@ -1128,6 +1113,14 @@ func (c *Thorchain) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, er
 	return fn.FindTxs(ctx, height)
 }
 
+// FindBlock implements blockdb.BlockFinder.
+func (c *Thorchain) FindBlock(ctx context.Context, height int64) (blockdb.Block, error) {
+	fn := c.getFullNode()
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+	return fn.FindBlock(ctx, height)
+}
+
 // StopAllNodes stops and removes all long running containers (validators and full nodes)
 func (c *Thorchain) StopAllNodes(ctx context.Context) error {
 	var eg errgroup.Group
@@ -17,18 +17,19 @@ import (
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
 	tmjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/p2p"
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	comettypes "github.com/cometbft/cometbft/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
-	volumetypes "github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"github.com/icza/dyno"
@@ -51,6 +52,7 @@ type ChainNode struct {
 	Validator    bool
 	NetworkID    string
 	DockerClient *dockerclient.Client
+	Runtime      dockerutil.ContainerRuntime
 	Client       rpcclient.Client
 	GrpcConn     *grpc.ClientConn
 	TestName     string
@@ -86,13 +88,14 @@ func NewChainNode(log *zap.Logger, validator bool, chain *Thorchain, dockerClien
 
 		Chain:        chain,
 		DockerClient: dockerClient,
+		Runtime:      dockerutil.RuntimeFor(dockerClient),
 		NetworkID:    networkID,
 		TestName:     testName,
 		Image:        image,
 		Index:        index,
 	}
 
-	tn.containerLifecycle = dockerutil.NewContainerLifecycle(log, dockerClient, tn.Name())
+	tn.containerLifecycle = dockerutil.NewContainerLifecycle(log, tn.Runtime, tn.Name())
 
 	return tn
 }
@@ -168,23 +171,21 @@ func (tn *ChainNode) NewSidecarProcess(
 ) error {
 	s := NewSidecar(tn.log, true, preStart, tn.Chain, cli, networkID, processName, tn.TestName, image, homeDir, tn.Index, ports, startCmd, env)
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel:   tn.TestName,
-			dockerutil.NodeOwnerLabel: s.Name(),
-		},
+	volumeName, err := s.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel:   tn.TestName,
+		dockerutil.NodeOwnerLabel: s.Name(),
 	})
 	if err != nil {
 		return fmt.Errorf("creating volume for sidecar process: %w", err)
 	}
-	s.VolumeName = v.Name
+	s.VolumeName = volumeName
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: tn.log,
 
-		Client: cli,
+		Runtime: s.Runtime,
 
-		VolumeName: v.Name,
+		VolumeName: volumeName,
 		ImageRef:   image.Ref(),
 		TestName:   tn.TestName,
 		UidGid:     image.UidGid,
@@ -268,7 +269,7 @@ func (tn *ChainNode) PrivValFileContent(ctx context.Context) ([]byte, error) {
 }
 
 func (tn *ChainNode) OverwritePrivValFile(ctx context.Context, content []byte) error {
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.Runtime, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, "config/priv_validator_key.json", content); err != nil {
 		return fmt.Errorf("overwriting priv_validator_key.json: %w", err)
 	}
@@ -334,7 +335,7 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 	if err := testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
-		tn.DockerClient,
+		tn.Runtime,
 		tn.TestName,
 		tn.VolumeName,
 		"config/config.toml",
@@ -366,7 +367,7 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 	return testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
-		tn.DockerClient,
+		tn.Runtime,
 		tn.TestName,
 		tn.VolumeName,
 		"config/app.toml",
@@ -386,7 +387,7 @@ func (tn *ChainNode) SetPeers(ctx context.Context, peers string) error {
 	return testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
-		tn.DockerClient,
+		tn.Runtime,
 		tn.TestName,
 		tn.VolumeName,
 		"config/config.toml",
@@ -404,8 +405,18 @@ func (tn *ChainNode) Height(ctx context.Context) (int64, error) {
 	return height, nil
 }
 
-// FindTxs implements blockdb.BlockSaver.
+// FindTxs implements blockdb.TxFinder.
 func (tn *ChainNode) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, error) {
+	block, err := tn.FindBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return block.Txs, nil
+}
+
+// FindBlock implements blockdb.BlockFinder.
+// For compatibility with FindTxs, the block-level events are also included in an artificial finalize_block transaction.
+func (tn *ChainNode) FindBlock(ctx context.Context, height int64) (blockdb.Block, error) {
 	h := int64(height)
 	var eg errgroup.Group
 	var blockRes *coretypes.ResultBlockResults
@@ -419,7 +430,7 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, e
 		return err
 	})
 	if err := eg.Wait(); err != nil {
-		return nil, err
+		return blockdb.Block{}, err
 	}
 	interfaceRegistry := tn.Chain.Config().EncodingConfig.InterfaceRegistry
 	txs := make([]blockdb.Tx, 0, len(block.Block.Txs)+2)
@@ -461,23 +472,69 @@ func (tn *ChainNode) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, e
 		finalizeBlockTx := blockdb.Tx{
 			Data: []byte(`{"data":"finalize_block","note":"this is a transaction artificially created for debugging purposes"}`),
 		}
-		finalizeBlockTx.Events = make([]blockdb.Event, len(blockRes.FinalizeBlockEvents))
-		for i, e := range blockRes.FinalizeBlockEvents {
-			attrs := make([]blockdb.EventAttribute, len(e.Attributes))
-			for j, attr := range e.Attributes {
-				attrs[j] = blockdb.EventAttribute{
-					Key:   string(attr.Key),
-					Value: string(attr.Value),
-				}
+		finalizeBlockTx.Events = finalizeBlockEvents(blockRes.FinalizeBlockEvents)
+		txs = append(txs, finalizeBlockTx)
+	}
+
+	header := blockdb.BlockHeader{
+		Hash:            block.BlockID.Hash.String(),
+		Time:            block.Block.Time,
+		ProposerAddress: block.Block.ProposerAddress.String(),
+		AppHash:         block.Block.AppHash.String(),
+	}
+	for _, rTx := range blockRes.TxsResults {
+		header.GasUsed += rTx.GasUsed
+		header.GasWanted += rTx.GasWanted
+	}
+
+	var sigs []blockdb.CommitSig
+	if lastCommit := block.Block.LastCommit; lastCommit != nil {
+		sigs = make([]blockdb.CommitSig, len(lastCommit.Signatures))
+		for i, sig := range lastCommit.Signatures {
+			sigs[i] = blockdb.CommitSig{
+				ValidatorAddress: sig.ValidatorAddress.String(),
+				Flag:             commitFlag(sig.BlockIDFlag),
+				Timestamp:        sig.Timestamp,
 			}
-			finalizeBlockTx.Events[i] = blockdb.Event{
-				Type:       e.Type,
-				Attributes: attrs,
+		}
+	}
+
+	return blockdb.Block{
+		Height:     height,
+		Header:     header,
+		Txs:        txs,
+		Events:     finalizeBlockEvents(blockRes.FinalizeBlockEvents),
+		Signatures: sigs,
+	}, nil
+}
+
+func finalizeBlockEvents(events []abcitypes.Event) []blockdb.Event {
+	out := make([]blockdb.Event, len(events))
+	for i, e := range events {
+		attrs := make([]blockdb.EventAttribute, len(e.Attributes))
+		for j, attr := range e.Attributes {
+			attrs[j] = blockdb.EventAttribute{
+				Key:   attr.Key,
+				Value: attr.Value,
 			}
 		}
-		txs = append(txs, finalizeBlockTx)
+		out[i] = blockdb.Event{
+			Type:       e.Type,
+			Attributes: attrs,
+		}
+	}
+	return out
+}
+
+func commitFlag(flag comettypes.BlockIDFlag) string {
+	switch flag {
+	case comettypes.BlockIDFlagCommit:
+		return blockdb.CommitFlagCommit
+	case comettypes.BlockIDFlagNil:
+		return blockdb.CommitFlagNil
+	default:
+		return blockdb.CommitFlagAbsent
 	}
-	return txs, nil
 }
 
 // TxCommand is a helper to retrieve a full command for broadcasting a tx
@@ -678,7 +735,7 @@ func (tn *ChainNode) InitHomeFolder(ctx context.Context) error {
 // the docker filesystem. relPath describes the location of the file in the
 // docker volume relative to the home directory
 func (tn *ChainNode) WriteFile(ctx context.Context, content []byte, relPath string) error {
-	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.Runtime, tn.TestName)
 	return fw.WriteFile(ctx, tn.VolumeName, relPath, content)
 }
 
@@ -696,7 +753,7 @@ func (tn *ChainNode) CopyFile(ctx context.Context, srcPath, dstPath string) erro
 // ReadFile reads the contents of a single file at the specified path in the docker filesystem.
 // relPath describes the location of the file in the docker volume relative to the home directory.
 func (tn *ChainNode) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(tn.logger(), tn.DockerClient, tn.TestName)
+	fr := dockerutil.NewFileRetriever(tn.logger(), tn.Runtime, tn.TestName)
 	gen, err := fr.SingleFileContent(ctx, tn.VolumeName, relPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file at %s: %w", relPath, err)
@@ -1346,12 +1403,13 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 			TestName:           tn.TestName,
 			VolumeName:         tn.VolumeName,
 			DockerClient:       tn.DockerClient,
+			Runtime:            tn.Runtime,
 			NetworkID:          tn.NetworkID,
 			Index:              tn.Index,
 			homeDir:            tn.HomeDir(),
 			log:                tn.log,
 			env:                chainCfg.Env,
-			containerLifecycle: dockerutil.NewContainerLifecycle(tn.log, tn.DockerClient, containerName),
+			containerLifecycle: dockerutil.NewContainerLifecycle(tn.log, tn.Runtime, containerName),
 		})
 	}
 
@@ -1363,14 +1421,15 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 		usingPorts[nat.Port(port)] = []nat.PortBinding{}
 	}
 
-	// to prevent port binding conflicts, host port overrides are only exposed on the first validator node.
-	if tn.Validator && tn.Index == 0 && chainCfg.HostPortOverride != nil {
-		for intP, extP := range chainCfg.HostPortOverride {
-			usingPorts[nat.Port(fmt.Sprintf("%d/tcp", intP))] = []nat.PortBinding{
-				{
-					HostPort: fmt.Sprintf("%d", extP),
-				},
-			}
+	// To prevent port binding conflicts, host port overrides are only exposed on the first validator node,
+	// unless a stride shifts them for every node.
+	position := tn.Index
+	if !tn.Validator {
+		position += tn.Chain.(*Thorchain).NumValidators
+	}
+	if overrides := dockerutil.HostPortOverrideBindings(chainCfg, position); len(overrides) > 0 {
+		for p, b := range overrides {
+			usingPorts[p] = b
 		}
 
 		fmt.Printf("Port Overrides: %v. Using: %v\n", chainCfg.HostPortOverride, usingPorts)
@@ -1382,6 +1441,7 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 		env = append(env, fmt.Sprintf("SIGNER_SEED_PHRASE=%s", tn.ValidatorMnemonic))
 	}
 
+	tn.containerLifecycle.SetResources(chainCfg.Resources)
 	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, usingPorts, tn.Bind(), nil, tn.HostName(), cmd, env, []string{})
 }
 
@@ -1625,7 +1685,7 @@ func (nodes ChainNodes) logger() *zap.Logger {
 }
 
 func (tn *ChainNode) Exec(ctx context.Context, cmd []string, env []string) ([]byte, []byte, error) {
-	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
+	job := dockerutil.NewImage(tn.logger(), tn.Runtime, tn.NetworkID, tn.TestName, tn.Image.Repository, tn.Image.Version)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
 		Binds: tn.Bind(),
@@ -0,0 +1,151 @@
+package utxo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+)
+
+// FindTxs implements blockdb.TxFinder.
+// Each transaction in the block at height is a blockdb.UTXOTx.
+func (c *UtxoChain) FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, error) {
+	latest, err := c.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if height > latest {
+		return nil, fmt.Errorf("block %d: %w", height, blockdb.ErrHeightNotReached)
+	}
+
+	cmd := append(c.BaseCli, "getblockhash", strconv.FormatInt(height, 10))
+	stdout, _, err := c.Exec(ctx, cmd, nil)
+	if err != nil {
+		return nil, err
+	}
+	blockHash := strings.TrimSpace(string(stdout))
+
+	rawTxs, err := c.blockTxs(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]blockdb.Tx, 0, len(rawTxs))
+	for _, raw := range rawTxs {
+		bz, err := json.Marshal(raw.toBlockDB())
+		if err != nil {
+			return nil, fmt.Errorf("marshal tx %s: %w", raw.TxID, err)
+		}
+		txs = append(txs, blockdb.Tx{Data: bz})
+	}
+	return txs, nil
+}
+
+// blockTxs returns the decoded transactions of the block with blockHash.
+// Verbosity 2 of getblock includes decoded transactions, but is not supported by all utxo chains,
+// so each transaction is fetched separately as a fallback.
+func (c *UtxoChain) blockTxs(ctx context.Context, blockHash string) ([]rawTx, error) {
+	cmd := append(c.BaseCli, "getblock", blockHash, "2")
+	if stdout, _, err := c.Exec(ctx, cmd, nil); err == nil {
+		var block struct {
+			Tx []json.RawMessage `json:"tx"`
+		}
+		if err := json.Unmarshal(stdout, &block); err != nil {
+			return nil, fmt.Errorf("unmarshal block %s: %w", blockHash, err)
+		}
+		if len(block.Tx) == 0 || !bytes.HasPrefix(bytes.TrimSpace(block.Tx[0]), []byte(`"`)) {
+			txs := make([]rawTx, len(block.Tx))
+			for i, bz := range block.Tx {
+				if err := unmarshalRawTx(bz, &txs[i]); err != nil {
+					return nil, err
+				}
+			}
+			return txs, nil
+		}
+	}
+
+	cmd = append(c.BaseCli, "getblock", blockHash, "true")
+	stdout, _, err := c.Exec(ctx, cmd, nil)
+	if err != nil {
+		return nil, err
+	}
+	var block struct {
+		Tx []string `json:"tx"`
+	}
+	if err := json.Unmarshal(stdout, &block); err != nil {
+		return nil, fmt.Errorf("unmarshal block %s: %w", blockHash, err)
+	}
+
+	txs := make([]rawTx, len(block.Tx))
+	for i, txID := range block.Tx {
+		cmd := append(c.BaseCli, "getrawtransaction", txID, "1", blockHash)
+		stdout, _, err := c.Exec(ctx, cmd, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := unmarshalRawTx(stdout, &txs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
+
+func unmarshalRawTx(bz []byte, tx *rawTx) error {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber() // Keep values exact, rather than rounding through float64.
+	if err := dec.Decode(tx); err != nil {
+		return fmt.Errorf("unmarshal tx: %w", err)
+	}
+	return nil
+}
+
+// rawTx is a decoded transaction as returned by getrawtransaction and getblock.
+type rawTx struct {
+	TxID string `json:"txid"`
+	Vin  []struct {
+		TxID     string `json:"txid"`
+		Vout     uint32 `json:"vout"`
+		Coinbase string `json:"coinbase"`
+	} `json:"vin"`
+	Vout []struct {
+		Value        json.Number `json:"value"`
+		N            uint32      `json:"n"`
+		ScriptPubKey struct {
+			Address   string   `json:"address"`
+			Addresses []string `json:"addresses"` // Older versions only.
+			Type      string   `json:"type"`
+		} `json:"scriptPubKey"`
+	} `json:"vout"`
+}
+
+func (tx rawTx) toBlockDB() blockdb.UTXOTx {
+	out := blockdb.UTXOTx{
+		TxID:    tx.TxID,
+		Inputs:  make([]blockdb.UTXOInput, len(tx.Vin)),
+		Outputs: make([]blockdb.UTXOOutput, len(tx.Vout)),
+	}
+	for i, in := range tx.Vin {
+		out.Inputs[i] = blockdb.UTXOInput{
+			TxID:     in.TxID,
+			Vout:     in.Vout,
+			Coinbase: in.Coinbase,
+		}
+	}
+	for i, o := range tx.Vout {
+		addr := o.ScriptPubKey.Address
+		if addr == "" && len(o.ScriptPubKey.Addresses) > 0 {
+			addr = o.ScriptPubKey.Addresses[0]
+		}
+		out.Outputs[i] = blockdb.UTXOOutput{
+			N:       o.N,
+			Value:   o.Value.String(),
+			Address: addr,
+			Type:    o.ScriptPubKey.Type,
+		}
+	}
+	return out
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"fmt"
-	"io"
 	"math"
 	"time"
 
@@ -12,9 +11,7 @@ import (
 	"strings"
 
 	sdkmath "cosmossdk.io/math"
-	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/mount"
-	"github.com/docker/docker/api/types/volume"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
@@ -45,6 +42,7 @@ type UtxoChain struct {
 	VolumeName   string
 	NetworkID    string
 	DockerClient *dockerclient.Client
+	Runtime      dockerutil.ContainerRuntime
 
 	containerLifecycle *dockerutil.ContainerLifecycle
 
@@ -105,28 +103,27 @@ func (c *UtxoChain) Initialize(ctx context.Context, testName string, cli *docker
 	c.pullImages(ctx, cli)
 	image := chainCfg.Images[0]
 
-	c.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, cli, c.Name())
+	c.Runtime = dockerutil.RuntimeFor(cli)
+	c.containerLifecycle = dockerutil.NewContainerLifecycle(c.log, c.Runtime, c.Name())
 
-	v, err := cli.VolumeCreate(ctx, volume.CreateOptions{
-		Labels: map[string]string{
-			dockerutil.CleanupLabel: testName,
+	volumeName, err := c.Runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel: testName,
 
-			dockerutil.NodeOwnerLabel: c.Name(),
-		},
+		dockerutil.NodeOwnerLabel: c.Name(),
 	})
 	if err != nil {
 		return fmt.Errorf("creating volume for chain node: %w", err)
 	}
-	c.VolumeName = v.Name
+	c.VolumeName = volumeName
 	c.NetworkID = networkID
 	c.DockerClient = cli
 
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: c.log,
 
-		Client: cli,
+		Runtime: c.Runtime,
 
-		VolumeName: v.Name,
+		VolumeName: volumeName,
 		ImageRef:   image.Ref(),
 		TestName:   testName,
 		UidGid:     image.UidGid,
@@ -151,20 +148,12 @@ func (c *UtxoChain) Bind() []string {
 
 func (c *UtxoChain) pullImages(ctx context.Context, cli *dockerclient.Client) {
 	for _, image := range c.Config().Images {
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			dockertypes.ImagePullOptions{},
-		)
-		if err != nil {
+		if err := dockerutil.PullImage(ctx, cli, image.Repository+":"+image.Version); err != nil {
 			c.log.Error("Failed to pull image",
 				zap.Error(err),
 				zap.String("repository", image.Repository),
 				zap.String("tag", image.Version),
 			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
 		}
 	}
 }
@@ -218,6 +207,7 @@ func (c *UtxoChain) Start(testName string, ctx context.Context, additionalGenesi
 		cmd = append(cmd, fmt.Sprintf("--datadir=%s", c.HomeDir()))
 	}
 
+	c.containerLifecycle.SetResources(c.cfg.Resources)
 	err := c.containerLifecycle.CreateContainer(ctx, c.testName, c.NetworkID, c.cfg.Images[0],
 		usingPorts, c.Bind(), []mount.Mount{}, c.HostName(), cmd, env, entrypoint)
 	if err != nil {
@@ -336,7 +326,7 @@ func (c *UtxoChain) Exec(ctx context.Context, cmd []string, env []string) (stdou
 	if cmd[len(cmd)-1] != "getblockcount" && cmd[len(cmd)-3] != "generatetoaddress" { // too much logging, maybe switch to an rpc lib in the future
 		logger = c.logger()
 	}
-	job := dockerutil.NewImage(logger, c.DockerClient, c.NetworkID, c.testName, c.cfg.Images[0].Repository, c.cfg.Images[0].Version)
+	job := dockerutil.NewImage(logger, c.Runtime, c.NetworkID, c.testName, c.cfg.Images[0].Repository, c.cfg.Images[0].Version)
 	opts := dockerutil.ContainerOptions{
 		Env:   env,
 		Binds: c.Bind(),
@@ -12,6 +12,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
 	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -29,7 +30,11 @@ type chainSet struct {
 	// The following fields are set during TrackBlocks, and used in Close.
 	trackerEg  *errgroup.Group
 	db         *sql.DB
+	testCase   *blockdb.TestCase
 	collectors []*blockdb.Collector
+
+	// Set during TrackTimeline, and used in Close.
+	stopTimeline []func()
 }
 
 func newChainSet(log *zap.Logger, chains []ibc.Chain) *chainSet {
@@ -55,7 +60,9 @@ func (cs *chainSet) Initialize(ctx context.Context, testName string, cli *client
 	for c := range cs.chains {
 		c := c
 		cs.log.Info("Initializing chain", zap.String("chain_id", c.Config().ChainID))
-		eg.Go(func() error {
+		eg.Go(func() (err error) {
+			ctx, span := tracing.Start(ctx, "chain.initialize", tracing.AttrChainID.String(c.Config().ChainID))
+			defer func() { tracing.End(span, err) }()
 
 			if err := c.Initialize(ctx, testName, cli, networkID); err != nil {
 				return fmt.Errorf("failed to initialize chain %s: %w", c.Config().Name, err)
@@ -70,6 +77,34 @@ func (cs *chainSet) Initialize(ctx context.Context, testName string, cli *client
 	return eg.Wait()
 }
 
+// Attach rediscovers, concurrently, the chains started by an earlier process in the persistent network of testName.
+// Every chain must implement PersistentChain.
+func (cs *chainSet) Attach(ctx context.Context, testName string, cli *client.Client, networkID string) error {
+	var eg errgroup.Group
+
+	for c := range cs.chains {
+		c := c
+		pc, ok := c.(PersistentChain)
+		if !ok {
+			return fmt.Errorf("chain %s of type %T cannot be attached to", c.Config().Name, c)
+		}
+		eg.Go(func() (err error) {
+			ctx, span := tracing.Start(ctx, "chain.attach", tracing.AttrChainID.String(c.Config().ChainID))
+			defer func() { tracing.End(span, err) }()
+
+			if err := pc.Attach(ctx, testName, cli, networkID); err != nil {
+				return fmt.Errorf("failed to attach to chain %s: %w", c.Config().Name, err)
+			}
+
+			cs.log.Info("Attached to chain", zap.String("chain_id", c.Config().ChainID))
+
+			return nil
+		})
+	}
+
+	return eg.Wait()
+}
+
 // CreateCommonAccount creates a key with the given name on each chain in the set,
 // and returns the bech32 representation of each account created.
 // The typical use of CreateCommonAccount is to create a faucet account on each chain.
@@ -115,12 +150,16 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 			// wait for provider chains to be started up first
 			continue
 		}
-		eg.Go(func() error {
+		eg.Go(func() (err error) {
 			chainCfg := c.Config()
+
+			ctx, span := tracing.Start(egCtx, "chain.start", tracing.AttrChainID.String(chainCfg.ChainID))
+			defer func() { tracing.End(span, err) }()
+
 			if cosmosChain, ok := c.(*cosmos.CosmosChain); ok {
 				if len(cosmosChain.Consumers) > 0 {
 					// this is a provider chain
-					if err := cosmosChain.StartProvider(testName, egCtx, additionalGenesisWallets[c]...); err != nil {
+					if err := cosmosChain.StartProvider(testName, ctx, additionalGenesisWallets[c]...); err != nil {
 						return fmt.Errorf("failed to start provider chain %s: %w", chainCfg.Name, err)
 					}
 					return nil
@@ -128,7 +167,7 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 			}
 
 			// standard chain startup
-			if err := c.Start(testName, egCtx, additionalGenesisWallets[c]...); err != nil {
+			if err := c.Start(testName, ctx, additionalGenesisWallets[c]...); err != nil {
 				return fmt.Errorf("failed to start chain %s: %w", chainCfg.Name, err)
 			}
 
@@ -145,9 +184,12 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 	for c := range cs.chains {
 		c := c
 		if cosmosChain, ok := c.(*cosmos.CosmosChain); ok && cosmosChain.Provider != nil {
-			eg.Go(func() error {
+			eg.Go(func() (err error) {
+				ctx, span := tracing.Start(egCtx, "chain.start", tracing.AttrChainID.String(c.Config().ChainID))
+				defer func() { tracing.End(span, err) }()
+
 				// this is a consumer chain
-				if err := cosmosChain.StartConsumer(testName, egCtx, additionalGenesisWallets[c]...); err != nil {
+				if err := cosmosChain.StartConsumer(testName, ctx, additionalGenesisWallets[c]...); err != nil {
 					return fmt.Errorf("failed to start consumer chain %s: %w", c.Config().Name, err)
 				}
 
@@ -160,10 +202,11 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 
 // TrackBlocks initializes database tables and polls for transactions to be saved in the database.
 // This method is a nop if dbPath is blank.
+// The dbPath is a sqlite file path or a PostgreSQL DSN, as accepted by blockdb.ConnectDB.
 // The gitSha is used to pin a git commit to a test invocation. Thus, when a user is looking at historical
 // data they are able to determine which version of the code produced the results.
 // Expected to be called after Start.
-func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
+func (cs *chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
 	if len(dbPath) == 0 {
 		// nop
 		return nil
@@ -171,7 +214,7 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 
 	db, err := blockdb.ConnectDB(ctx, dbPath)
 	if err != nil {
-		return fmt.Errorf("connect to sqlite database %s: %w", dbPath, err)
+		return fmt.Errorf("connect to block database: %w", err)
 	}
 	cs.db = db
 
@@ -180,14 +223,15 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 	}
 
 	if err := blockdb.Migrate(db, gitSha); err != nil {
-		return fmt.Errorf("migrate sqlite database %s; deleting file recommended: %w", dbPath, err)
+		return fmt.Errorf("migrate block database; deleting sqlite file recommended: %w", err)
 	}
 
 	testCase, err := blockdb.CreateTestCase(ctx, db, testName, gitSha)
 	if err != nil {
 		_ = db.Close()
-		return fmt.Errorf("create test case in sqlite database: %w", err)
+		return fmt.Errorf("create test case in block database: %w", err)
 	}
+	cs.testCase = testCase
 
 	// TODO (nix - 6/1/22) Need logger instead of fmt.Fprint
 	cs.trackerEg = new(errgroup.Group)
@@ -198,8 +242,8 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 		id := c.Config().ChainID
 		finder, ok := c.(blockdb.TxFinder)
 		if !ok {
-			fmt.Fprintf(os.Stderr, `Chain %s is not configured to save blocks; must implement "FindTxs(ctx context.Context, height int64) ([][]byte, error)"`+"\n", id)
-			return nil
+			fmt.Fprintf(os.Stderr, `Chain %s is not configured to save blocks; must implement "FindTxs(ctx context.Context, height int64) ([]blockdb.Tx, error)"`+"\n", id)
+			continue
 		}
 		j := i // Avoid closure on loop variable.
 		cs.trackerEg.Go(func() error {
@@ -222,9 +266,12 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 
 // Close frees any resources associated with the chainSet.
 //
-// Currently, it only frees resources from TrackBlocks.
+// Currently, it only frees resources from TrackBlocks and TrackTimeline.
 // Close is safe to call even if TrackBlocks was not called.
 func (cs *chainSet) Close() error {
+	for _, stop := range cs.stopTimeline {
+		stop()
+	}
 	for _, c := range cs.collectors {
 		if c != nil {
 			c.Stop()
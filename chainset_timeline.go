@@ -0,0 +1,104 @@
+package interchaintest
+
+import (
+	"context"
+	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"go.uber.org/zap"
+)
+
+// TrackTimeline saves container executions, relayer executions, and test events for testName and its subtests
+// to the database opened by TrackBlocks, so they can be correlated with blocks.
+// This method is a nop if TrackBlocks did not open a database.
+// rep may be nil, in which case only container executions are tracked.
+func (cs *chainSet) TrackTimeline(rep *testreporter.RelayerExecReporter, testName string) {
+	if cs.testCase == nil {
+		// nop
+		return
+	}
+
+	// The callbacks have no context of their own; writes are short-lived and end when the database is closed.
+	ctx := context.Background()
+	log := cs.log.With(zap.String("test", testName))
+
+	cs.stopTimeline = append(cs.stopTimeline, dockerutil.ObserveExecs(testName, func(rec dockerutil.ExecRecord) {
+		exec := blockdb.Exec{
+			Kind:          blockdb.ExecKindContainer,
+			ContainerName: rec.ContainerName,
+			Command:       rec.Command,
+			Stdout:        string(rec.Result.Stdout),
+			Stderr:        string(rec.Result.Stderr),
+			ExitCode:      rec.Result.ExitCode,
+			StartedAt:     rec.StartedAt,
+			FinishedAt:    rec.FinishedAt,
+		}
+		if rec.Result.Err != nil {
+			exec.Error = rec.Result.Err.Error()
+		}
+		if err := cs.testCase.SaveExec(ctx, exec); err != nil {
+			log.Info("Failed to save container exec", zap.Error(err))
+		}
+	}))
+
+	if rep == nil {
+		return
+	}
+
+	cs.stopTimeline = append(cs.stopTimeline, rep.Reporter().Subscribe(func(m testreporter.Message) {
+		if err := cs.saveReporterMessage(ctx, testName, m); err != nil {
+			log.Info("Failed to save reporter message", zap.Error(err))
+		}
+	}))
+}
+
+// saveReporterMessage saves m if it belongs to testName or one of its subtests.
+func (cs *chainSet) saveReporterMessage(ctx context.Context, testName string, m testreporter.Message) error {
+	ownTest := func(name string) bool {
+		return name == testName || strings.HasPrefix(name, testName+"/")
+	}
+
+	var event blockdb.TestEvent
+	switch m := m.(type) {
+	case testreporter.RelayerExecMessage:
+		if !ownTest(m.Name) {
+			return nil
+		}
+		return cs.testCase.SaveExec(ctx, blockdb.Exec{
+			Kind:          blockdb.ExecKindRelayer,
+			ContainerName: m.ContainerName,
+			Command:       m.Command,
+			Stdout:        m.Stdout,
+			Stderr:        m.Stderr,
+			ExitCode:      m.ExitCode,
+			Error:         m.Error,
+			StartedAt:     m.StartedAt,
+			FinishedAt:    m.FinishedAt,
+		})
+	case testreporter.BeginTestMessage:
+		event = blockdb.TestEvent{Type: "BeginTest", Name: m.Name, When: m.StartedAt}
+	case testreporter.FinishTestMessage:
+		status := "passed"
+		switch {
+		case m.Failed:
+			status = "failed"
+		case m.Skipped:
+			status = "skipped"
+		}
+		event = blockdb.TestEvent{Type: "FinishTest", Name: m.Name, Message: status, When: m.FinishedAt}
+	case testreporter.TestErrorMessage:
+		event = blockdb.TestEvent{Type: "TestError", Name: m.Name, Message: m.Message, When: m.When}
+	case testreporter.TestSkipMessage:
+		event = blockdb.TestEvent{Type: "TestSkip", Name: m.Name, Message: m.Message, When: m.When}
+	default:
+		// Suite and parallel scheduling messages are not specific to the test.
+		return nil
+	}
+
+	if !ownTest(event.Name) {
+		return nil
+	}
+	return cs.testCase.SaveTestEvent(ctx, event)
+}
@@ -0,0 +1,54 @@
+package interchaintest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChainSet_SaveReporterMessage(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := blockdb.ConnectDB(ctx, ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, blockdb.Migrate(db, "sha"))
+
+	tc, err := blockdb.CreateTestCase(ctx, db, "TestFoo", "sha")
+	require.NoError(t, err)
+
+	cs := &chainSet{log: zap.NewNop(), testCase: tc}
+
+	now := time.Now()
+	for _, m := range []testreporter.Message{
+		testreporter.BeginSuiteMessage{StartedAt: now},
+		testreporter.BeginTestMessage{Name: "TestFoo/sub", StartedAt: now},
+		testreporter.TestErrorMessage{Name: "TestFoo", Message: "boom", When: now},
+		testreporter.FinishTestMessage{Name: "TestFoo/sub", FinishedAt: now, Failed: true},
+		testreporter.RelayerExecMessage{Name: "TestFoo", Command: []string{"rly", "start"}, StartedAt: now, FinishedAt: now},
+		// Other tests sharing the reporter are ignored.
+		testreporter.BeginTestMessage{Name: "TestFooBar", StartedAt: now},
+		testreporter.RelayerExecMessage{Name: "TestBar", Command: []string{"rly", "start"}, StartedAt: now, FinishedAt: now},
+	} {
+		require.NoError(t, cs.saveReporterMessage(ctx, "TestFoo", m))
+	}
+
+	results, err := blockdb.NewQuery(db).Timeline(ctx, 1)
+	require.NoError(t, err)
+
+	got := make([][3]string, len(results))
+	for i, r := range results {
+		got[i] = [3]string{r.Kind, r.Source, r.Summary}
+	}
+	require.ElementsMatch(t, [][3]string{
+		{"test", "TestFoo/sub", "BeginTest"},
+		{"test", "TestFoo", "TestError"},
+		{"test", "TestFoo/sub", "FinishTest"},
+		{"relayer", "", "rly start"},
+	}, got)
+}
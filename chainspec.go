@@ -156,6 +156,9 @@ func (s *ChainSpec) applyConfigOverrides(cfg ibc.ChainConfig) (*ibc.ChainConfig,
 	if s.HostPortOverride != nil {
 		cfg.HostPortOverride = s.HostPortOverride
 	}
+	if s.HostPortOverrideStride != 0 {
+		cfg.HostPortOverrideStride = s.HostPortOverrideStride
+	}
 
 	if s.Genesis != nil {
 		cfg.Genesis = s.Genesis
@@ -255,3 +258,9 @@ func (s *ChainSpec) suffix() string {
 
 // suffixCounter is a package-level counter for safely generating unique suffixes per execution environment.
 var suffixCounter int32
+
+// UseImage replaces the images of s with img, overriding the images and version of a built-in config.
+func (s *ChainSpec) UseImage(img ibc.DockerImage) {
+	s.ChainConfig.Images = []ibc.DockerImage{img}
+	s.Version = img.Version
+}
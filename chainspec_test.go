@@ -145,6 +145,55 @@ func TestChainSpec_Config(t *testing.T) {
 
 			require.Equal(t, m, cfg.NoHostMount)
 		})
+
+		t.Run("Resources", func(t *testing.T) {
+			limits := ibc.ResourceLimits{CPUs: 0.5, Memory: 512 << 20}
+
+			s := interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					Resources: limits,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, limits, cfg.Resources)
+		})
+
+		t.Run("HostPortOverrideStride", func(t *testing.T) {
+			s := interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+
+				ChainConfig: ibc.ChainConfig{
+					HostPortOverride:       map[int]int{26657: 36657},
+					HostPortOverrideStride: 10,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, map[int]int{26657: 36657}, cfg.HostPortOverride)
+			require.Equal(t, 10, cfg.HostPortOverrideStride)
+		})
+
+		t.Run("UseImage", func(t *testing.T) {
+			s := interchaintest.ChainSpec{
+				Name:    "gaia",
+				Version: "v7.0.1",
+			}
+			s.UseImage(ibc.NewDockerImage("gaia", "local", "1025:1025"))
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, []ibc.DockerImage{{Repository: "gaia", Version: "local", UidGid: "1025:1025"}}, cfg.Images)
+		})
 	})
 
 	t.Run("error cases", func(t *testing.T) {
@@ -18,7 +18,15 @@ type mainFlags struct {
 	LogLevel          string
 	MatrixFile        string
 	ReportFile        string
+	JUnitFile         string
+	HTMLReportFile    string
+	TraceFile         string
+	OTLPEndpoint      string
 	BlockDatabaseFile string
+	ExplorerAddr      string
+	ImagesExport      string
+	ImagesImport      string
+	ImagesCheck       bool
 }
 
 func (f mainFlags) Logger() (lc LoggerCloser, _ error) {
@@ -3,10 +3,15 @@ package interchaintest
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"testing"
 	"time"
@@ -15,10 +20,13 @@ import (
 	interchaintest "github.com/strangelove-ventures/interchaintest/v8"
 	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
 	blockdbtui "github.com/strangelove-ventures/interchaintest/v8/blockdb/tui"
+	blockdbweb "github.com/strangelove-ventures/interchaintest/v8/blockdb/web"
 	"github.com/strangelove-ventures/interchaintest/v8/conformance"
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/relayer"
 	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
 	"go.uber.org/zap"
 )
 
@@ -34,6 +42,18 @@ func init() {
 `)
 		debugFlagSet.PrintDefaults()
 		fmt.Fprint(out, `
+  explore  Serve a web UI to explore blocks and transactions.
+`)
+		exploreFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
+  report  Convert a JSON test report to JUnit XML and/or HTML.
+`)
+		reportFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
+  images  Pull, check, export or import the images needed by the test matrix.
+`)
+		imagesFlagSet.PrintDefaults()
+		fmt.Fprint(out, `
   version  Prints git commit that produced executable.
 `)
 	}
@@ -46,7 +66,12 @@ var testMatrix struct {
 	ChainSets [][]*interchaintest.ChainSpec
 }
 
-var debugFlagSet = flag.NewFlagSet("debug", flag.ExitOnError)
+var (
+	debugFlagSet   = flag.NewFlagSet("debug", flag.ExitOnError)
+	exploreFlagSet = flag.NewFlagSet("explore", flag.ExitOnError)
+	reportFlagSet  = flag.NewFlagSet("report", flag.ExitOnError)
+	imagesFlagSet  = flag.NewFlagSet("images", flag.ExitOnError)
+)
 
 func TestMain(m *testing.M) {
 	addFlags()
@@ -61,6 +86,24 @@ func TestMain(m *testing.M) {
 			os.Exit(1)
 		}
 		os.Exit(0)
+	case "explore":
+		if err := runExplorer(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to run explore: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "report":
+		if err := convertReport(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to convert report: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "images":
+		if err := manageImages(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to manage images: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
 	case "version":
 		fmt.Fprintln(os.Stderr, interchaintest.GitSha)
 		os.Exit(0)
@@ -81,13 +124,31 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		OTLPEndpoint: extraFlags.OTLPEndpoint,
+		File:         extraFlags.TraceFile,
+		ServiceName:  tracing.ConfigFromEnv().ServiceName,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failure configuring tracing: %v\n", err)
+		os.Exit(1)
+	}
+
 	code := m.Run()
 
+	if err := shutdownTracing(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "Failure flushing traces: %v\n", err)
+	}
+
 	if err := reporter.Close(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failure closing test reporter: %v\n", err)
 		// Don't os.Exit here, since we already have an exit code from running the tests.
 	}
 
+	if err := writeSummary(summary); err != nil {
+		fmt.Fprintf(os.Stderr, "Failure writing test summary: %v\n", err)
+	}
+
 	os.Exit(code)
 }
 
@@ -142,7 +203,10 @@ func validateTestMatrix() error {
 	return nil
 }
 
-var reporter *testreporter.Reporter
+var (
+	reporter *testreporter.Reporter
+	summary  *testreporter.Summary
+)
 
 func configureTestReporter() error {
 	home, err := os.UserHomeDir()
@@ -163,6 +227,113 @@ func configureTestReporter() error {
 	fmt.Fprintf(os.Stderr, "Writing report to %s\n", f.Name())
 
 	reporter = testreporter.NewReporter(f)
+	summary = reporter.TrackSummary()
+	return nil
+}
+
+// writeSummary writes s to the JUnit and HTML files requested by flags, if any.
+func writeSummary(s *testreporter.Summary) error {
+	if extraFlags.JUnitFile != "" {
+		if err := writeFile(extraFlags.JUnitFile, s.WriteJUnit); err != nil {
+			return fmt.Errorf("write junit report: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote JUnit report to %s\n", extraFlags.JUnitFile)
+	}
+	if extraFlags.HTMLReportFile != "" {
+		if err := writeFile(extraFlags.HTMLReportFile, s.WriteHTML); err != nil {
+			return fmt.Errorf("write html report: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote HTML report to %s\n", extraFlags.HTMLReportFile)
+	}
+	return nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// convertReport converts the JSON report from a previous run to the formats requested by flags.
+func convertReport() error {
+	if extraFlags.ReportFile == "" {
+		return errors.New("-report-file is required")
+	}
+	if extraFlags.JUnitFile == "" && extraFlags.HTMLReportFile == "" {
+		return errors.New("at least one of -junit-file or -html-file is required")
+	}
+
+	f, err := os.Open(extraFlags.ReportFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	msgs, err := testreporter.ReadMessages(f)
+	if err != nil {
+		return fmt.Errorf("read report %s: %w", extraFlags.ReportFile, err)
+	}
+	return writeSummary(testreporter.NewSummary(msgs))
+}
+
+// manageImages makes the images needed by the test matrix available locally,
+// or checks, exports or imports them, as requested by flags.
+func manageImages(ctx context.Context) error {
+	cli, _, err := dockerutil.NewRuntimeClient(dockerutil.ContainerRuntimeName)
+	if err != nil {
+		return err
+	}
+	log := zap.NewNop()
+	m := dockerutil.NewImageManager(log, dockerutil.RuntimeFor(cli))
+
+	if extraFlags.ImagesImport != "" {
+		return m.Import(ctx, extraFlags.ImagesImport)
+	}
+
+	if err := setUpTestMatrix(); err != nil {
+		return fmt.Errorf("build test matrix: %w", err)
+	}
+	var specs []*interchaintest.ChainSpec
+	for _, cs := range testMatrix.ChainSets {
+		specs = append(specs, cs...)
+	}
+	var factories []interchaintest.RelayerFactory
+	for _, r := range testMatrix.Relayers {
+		f, err := getRelayerFactory(r, log)
+		if err != nil {
+			return err
+		}
+		factories = append(factories, f)
+	}
+
+	refs, err := interchaintest.RequiredImages(log, specs, factories...)
+	if err != nil {
+		return err
+	}
+
+	if extraFlags.ImagesCheck {
+		if err := m.EnsureLocal(ctx, refs); err != nil {
+			return err
+		}
+	} else if err := m.Prepare(ctx, refs); err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		fmt.Fprintln(os.Stderr, ref)
+	}
+
+	if extraFlags.ImagesExport != "" {
+		if err := m.Export(ctx, extraFlags.ImagesExport, refs); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d images to %s\n", len(refs), extraFlags.ImagesExport)
+	}
 	return nil
 }
 
@@ -172,8 +343,10 @@ func getRelayerFactory(name string, logger *zap.Logger) (interchaintest.RelayerF
 		return interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, logger, relayer.StartupFlags("-b", "100")), nil
 	case "hermes":
 		return interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, logger), nil
+	case "rlylib":
+		return interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRlyInProcess, logger), nil
 	default:
-		return nil, fmt.Errorf("unknown relayer type %q (valid types: rly, hermes)", name)
+		return nil, fmt.Errorf("unknown relayer type %q (valid types: rly, hermes, rlylib)", name)
 	}
 }
 
@@ -244,8 +417,25 @@ func addFlags() {
 	flag.StringVar(&extraFlags.LogFormat, "log-format", "console", "Chain and relayer log format: console|json")
 	flag.StringVar(&extraFlags.LogLevel, "log-level", "info", "Chain and relayer log level: debug|info|error")
 	flag.StringVar(&extraFlags.ReportFile, "report-file", "", "Path where test report will be stored. Defaults to $HOME/.interchaintest/reports/$TIMESTAMP.json")
+	flag.StringVar(&extraFlags.JUnitFile, "junit-file", "", "If set, path where a JUnit XML test report will be written after tests finish.")
+	flag.StringVar(&extraFlags.HTMLReportFile, "html-file", "", "If set, path where an HTML test report will be written after tests finish.")
+	traceCfg := tracing.ConfigFromEnv()
+	flag.StringVar(&extraFlags.TraceFile, "trace-file", traceCfg.File, "If set, path where OpenTelemetry spans will be written as JSON. Defaults to $ICTEST_TRACE_FILE.")
+	flag.StringVar(&extraFlags.OTLPEndpoint, "otlp-endpoint", traceCfg.OTLPEndpoint, "If set, host:port or URL of an OTLP/HTTP collector receiving OpenTelemetry spans. Defaults to $OTEL_EXPORTER_OTLP_ENDPOINT.")
+
+	debugFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file, or PostgreSQL DSN, that tracks blocks and transactions.")
+
+	exploreFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file, or PostgreSQL DSN, that tracks blocks and transactions.")
+	exploreFlagSet.StringVar(&extraFlags.ExplorerAddr, "addr", "localhost:8080", "Address for the web UI to listen on.")
 
-	debugFlagSet.StringVar(&extraFlags.BlockDatabaseFile, "block-db", interchaintest.DefaultBlockDatabaseFilepath(), "Path to database sqlite file that tracks blocks and transactions.")
+	reportFlagSet.StringVar(&extraFlags.ReportFile, "report-file", "", "Path to the JSON test report to convert.")
+	reportFlagSet.StringVar(&extraFlags.JUnitFile, "junit-file", "", "Path where the JUnit XML report will be written.")
+	reportFlagSet.StringVar(&extraFlags.HTMLReportFile, "html-file", "", "Path where the HTML report will be written.")
+
+	imagesFlagSet.StringVar(&extraFlags.MatrixFile, "matrix", "", "Path to matrix file defining the chains and relayers whose images are needed.")
+	imagesFlagSet.BoolVar(&extraFlags.ImagesCheck, "check", false, "Only check that the images are present locally, listing any that are missing.")
+	imagesFlagSet.StringVar(&extraFlags.ImagesExport, "export", "", "If set, path of a tarball to export the images to, gzip-compressed if ending in .gz.")
+	imagesFlagSet.StringVar(&extraFlags.ImagesImport, "import", "", "If set, path of a tarball from -export to import images from, instead of pulling.")
 }
 
 func parseFlags() {
@@ -254,6 +444,15 @@ func parseFlags() {
 	case "debug":
 		// Ignore errors because configured with flag.ExitOnError.
 		_ = debugFlagSet.Parse(os.Args[2:])
+	case "explore":
+		// Ignore errors because configured with flag.ExitOnError.
+		_ = exploreFlagSet.Parse(os.Args[2:])
+	case "report":
+		// Ignore errors because configured with flag.ExitOnError.
+		_ = reportFlagSet.Parse(os.Args[2:])
+	case "images":
+		// Ignore errors because configured with flag.ExitOnError.
+		_ = imagesFlagSet.Parse(os.Args[2:])
 	}
 }
 
@@ -264,19 +463,14 @@ func subcommand() string {
 func runDebugTerminalUI(ctx context.Context) error {
 	dbPath := extraFlags.BlockDatabaseFile
 
-	// Explicitly check for file existence otherwise blockdb.ConnectDB implicitly creates and migrates a sqlite file.
-	if _, err := os.Stat(dbPath); err != nil {
-		return err
-	}
-
-	db, err := blockdb.ConnectDB(ctx, dbPath)
+	db, err := connectBlockDB(ctx, dbPath)
 	if err != nil {
-		return fmt.Errorf("connect to database %s: %w", dbPath, err)
+		return err
 	}
 	defer db.Close()
 
 	if err = blockdb.Migrate(db, interchaintest.GitSha); err != nil {
-		return fmt.Errorf("migrate database %s: %w", dbPath, err)
+		return fmt.Errorf("migrate database: %w", err)
 	}
 
 	querySvc := blockdb.NewQuery(db)
@@ -291,13 +485,63 @@ func runDebugTerminalUI(ctx context.Context) error {
 		return fmt.Errorf("query recent test cases: %w", err)
 	}
 	if len(testCases) == 0 {
-		return fmt.Errorf("no test cases found in database %s", dbPath)
+		return errors.New("no test cases found in database")
 	}
 
 	app := tview.NewApplication()
-	model := blockdbtui.NewModel(blockdb.NewQuery(db), dbPath, schemaInfo.GitSha, schemaInfo.CreatedAt, testCases)
+	model := blockdbtui.NewModel(blockdb.NewQuery(db), blockdb.RedactDSN(dbPath), schemaInfo.GitSha, schemaInfo.CreatedAt, testCases)
 	return app.
 		SetInputCapture(model.Update(ctx)).
 		SetRoot(model.RootView(), true).
 		Run()
 }
+
+func runExplorer(ctx context.Context) error {
+	dbPath := extraFlags.BlockDatabaseFile
+
+	db, err := connectBlockDB(ctx, dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// The explorer only reads the database, which may be shared with running tests, so it is never migrated.
+	if err := blockdb.CheckSchema(ctx, db); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:              extraFlags.ExplorerAddr,
+		Handler:           blockdbweb.NewExplorer(blockdb.NewQuery(db), blockdb.RedactDSN(dbPath)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving block explorer at http://%s\n", srv.Addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// connectBlockDB connects to an existing block database.
+func connectBlockDB(ctx context.Context, dbPath string) (*sql.DB, error) {
+	// Explicitly check for file existence otherwise blockdb.ConnectDB implicitly creates and migrates a sqlite file.
+	if !blockdb.IsPostgresDSN(dbPath) {
+		if _, err := os.Stat(dbPath); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := blockdb.ConnectDB(ctx, dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	return db, nil
+}
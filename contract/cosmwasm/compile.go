@@ -3,18 +3,15 @@ package cosmwasm
 import (
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/mount"
-	"github.com/docker/docker/client"
-	"github.com/docker/docker/errdefs"
-	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/hashicorp/go-version"
+
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 )
 
 // compile will compile the specified repo using the specified docker image and version
@@ -48,24 +45,18 @@ func compile(image string, optVersion string, repoPath string) (string, error) {
 	repoPathFull := filepath.Join(pwd, repoPath)
 
 	ctx := context.Background()
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	cli, rt, err := dockerutil.NewRuntimeClient(dockerutil.ContainerRuntimeName)
 	if err != nil {
-		return "", fmt.Errorf("new client with opts: %w", err)
+		// The kubernetes and process runtimes cannot mount the contract sources.
+		return "", fmt.Errorf("compiling contracts requires the %s or %s runtime: %w", dockerutil.RuntimeDocker, dockerutil.RuntimePodman, err)
 	}
-	defer cli.Close()
+	defer func() { _ = dockerutil.CloseRuntimeClient(cli) }()
 
-	reader, err := cli.ImagePull(ctx, imageFull, types.ImagePullOptions{})
-	if err != nil {
+	if err := dockerutil.PullImage(ctx, cli, imageFull); err != nil {
 		return "", fmt.Errorf("pull image %s: %w", imageFull, err)
 	}
 
-	defer reader.Close()
-	_, err = io.Copy(os.Stdout, reader)
-	if err != nil {
-		return "", fmt.Errorf("io copy %s: %w", imageFull, err)
-	}
-
-	resp, err := cli.ContainerCreate(ctx, &container.Config{
+	id, err := rt.CreateContainer(ctx, "", &container.Config{
 		Image: imageFull,
 		Tty:   false,
 	}, &container.HostConfig{
@@ -86,47 +77,27 @@ func compile(image string, optVersion string, repoPath string) (string, error) {
 				Target: "/usr/local/cargo/registry",
 			},
 		},
-	}, nil, nil, "")
+	}, nil)
 	if err != nil {
 		return "", fmt.Errorf("create container %s: %w", imageFull, err)
 	}
+	defer func() { _ = rt.RemoveContainer(ctx, id) }()
 
-	if err := cli.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
+	if err := rt.StartContainer(ctx, id); err != nil {
 		return "", fmt.Errorf("start container %s: %w", imageFull, err)
 	}
 
-	statusCh, errCh := cli.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
-	select {
-	case err := <-errCh:
-		if err != nil {
-			return "", fmt.Errorf("wait container %s: %w", imageFull, err)
-		}
-	case <-statusCh:
+	if _, err := rt.WaitContainer(ctx, id); err != nil {
+		return "", fmt.Errorf("wait container %s: %w", imageFull, err)
 	}
 
-	out, err := cli.ContainerLogs(ctx, resp.ID, types.ContainerLogsOptions{ShowStdout: true})
+	stdout, _, err := rt.ContainerLogs(ctx, id, 0)
 	if err != nil {
 		return "", fmt.Errorf("logs container %s: %w", imageFull, err)
 	}
+	_, _ = os.Stdout.Write(stdout)
 
-	_, err = stdcopy.StdCopy(os.Stdout, os.Stderr, out)
-	if err != nil {
-		return "", fmt.Errorf("std copy %s: %w", imageFull, err)
-	}
-
-	err = cli.ContainerStop(ctx, resp.ID, container.StopOptions{})
-	if err != nil {
-		// Only return the error if it didn't match an already stopped, or a missing container.
-		if !(errdefs.IsNotModified(err) || errdefs.IsNotFound(err)) {
-			return "", fmt.Errorf("stop container %s: %w", imageFull, err)
-		}
-	}
-
-	err = cli.ContainerRemove(ctx, resp.ID, types.ContainerRemoveOptions{
-		Force:         true,
-		RemoveVolumes: true,
-	})
-	if err != nil && !errdefs.IsNotFound(err) {
+	if err := rt.RemoveContainer(ctx, id); err != nil {
 		return "", fmt.Errorf("remove container %s: %w", imageFull, err)
 	}
 
@@ -0,0 +1,327 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// CollectArtifactsOnFailure determines whether DockerCleanup collects container logs
+// and node files for a failed test before its docker resources are removed.
+//
+// The value is true by default, but can be initialized to false by setting the
+// environment variable ICTEST_SKIP_ARTIFACTS to a non-empty value.
+var CollectArtifactsOnFailure = os.Getenv("ICTEST_SKIP_ARTIFACTS") == ""
+
+// ArtifactsDir is the directory in which a new directory of artifacts is created for each failed test.
+// The value is initialized from the environment variable ICTEST_ARTIFACTS_DIR.
+// If empty, the system's temporary directory is used.
+//
+// Unlike (*testing.T).TempDir, the artifacts directory is never removed automatically.
+var ArtifactsDir = os.Getenv("ICTEST_ARTIFACTS_DIR")
+
+// ArtifactsMaxBytes caps the total size of artifacts collected for a single failed test.
+// Node files are collected first, then container logs share the remaining budget and keep their most recent output,
+// and finally files added with AddArtifactFile are copied if they fit.
+var ArtifactsMaxBytes int64 = 256 << 20
+
+// ArtifactVolumePaths are the files, relative to the root of a volume, collected from every volume of a failed test.
+// Files that do not exist in a particular volume are ignored.
+var ArtifactVolumePaths = []string{
+	// Cosmos nodes.
+	"config/config.toml",
+	"config/app.toml",
+	"config/client.toml",
+	"config/genesis.json",
+
+	// The Go relayer.
+	"config/config.yaml",
+
+	// Hermes.
+	".hermes/config.toml",
+}
+
+var artifactRegistry = struct {
+	mu        sync.Mutex
+	files     map[string][]string
+	reporters map[string][]func(path string, size int64)
+}{
+	files:     make(map[string][]string),
+	reporters: make(map[string][]func(path string, size int64)),
+}
+
+// AddArtifactFile registers a file on the host to be copied into the artifacts of testName if the test fails,
+// e.g. the block database.
+func AddArtifactFile(testName, hostPath string) {
+	artifactRegistry.mu.Lock()
+	defer artifactRegistry.mu.Unlock()
+	artifactRegistry.files[testName] = append(artifactRegistry.files[testName], hostPath)
+}
+
+// OnArtifact registers fn to be called with the path and size of every artifact collected for testName.
+func OnArtifact(testName string, fn func(path string, size int64)) {
+	artifactRegistry.mu.Lock()
+	defer artifactRegistry.mu.Unlock()
+	artifactRegistry.reporters[testName] = append(artifactRegistry.reporters[testName], fn)
+}
+
+// takeArtifactRegistrations returns and forgets everything registered for testName.
+func takeArtifactRegistrations(testName string) (files []string, reporters []func(string, int64)) {
+	artifactRegistry.mu.Lock()
+	defer artifactRegistry.mu.Unlock()
+	files, reporters = artifactRegistry.files[testName], artifactRegistry.reporters[testName]
+	delete(artifactRegistry.files, testName)
+	delete(artifactRegistry.reporters, testName)
+	return files, reporters
+}
+
+// artifactCollector writes artifacts for a single test into dir, within a total byte budget.
+type artifactCollector struct {
+	t  DockerSetupTestingT
+	rt ContainerRuntime
+
+	dir       string
+	remaining int64
+
+	reporters []func(string, int64)
+	collected int
+}
+
+// collectArtifacts dumps the logs of every container, key files from every volume,
+// and the given host files of the failed test t into a new artifacts directory.
+func collectArtifacts(ctx context.Context, t DockerSetupTestingT, rt ContainerRuntime, files []string, reporters []func(string, int64)) {
+	dir, err := os.MkdirTemp(ArtifactsDir, SanitizeContainerName(t.Name())+"-artifacts-")
+	if err != nil {
+		t.Logf("Failed to create artifacts directory: %v", err)
+		return
+	}
+
+	c := &artifactCollector{
+		t:  t,
+		rt: rt,
+
+		dir:       dir,
+		remaining: ArtifactsMaxBytes,
+
+		reporters: reporters,
+	}
+
+	c.collectVolumeFiles(ctx)
+	c.collectContainerLogs(ctx)
+	c.collectHostFiles(files)
+
+	t.Logf("Collected %d test artifacts in %s", c.collected, dir)
+}
+
+func (c *artifactCollector) collectVolumeFiles(ctx context.Context) {
+	vs, err := c.rt.ListVolumes(ctx, map[string]string{CleanupLabel: c.t.Name()})
+	if err != nil {
+		c.t.Logf("Failed to list volumes for artifacts: %v", err)
+		return
+	}
+	if len(vs) == 0 {
+		return
+	}
+
+	if err := EnsureBusybox(ctx, c.rt); err != nil {
+		c.t.Logf("Failed to collect volume artifacts: %v", err)
+		return
+	}
+
+	for _, v := range vs {
+		owner := v.Labels[NodeOwnerLabel]
+		if owner == "" {
+			owner = v.Name
+		}
+		if err := c.collectVolume(ctx, v.Name, filepath.Join("volumes", SanitizeContainerName(owner))); err != nil {
+			c.t.Logf("Failed to collect artifacts from volume %s: %v", v.Name, err)
+		}
+	}
+}
+
+// collectVolume copies the ArtifactVolumePaths present in volumeName into the relative directory destDir.
+func (c *artifactCollector) collectVolume(ctx context.Context, volumeName, destDir string) error {
+	const mountPath = "/mnt/dockervolume"
+
+	containerName := fmt.Sprintf("%s-artifacts-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+	rt := c.rt
+	id, err := rt.CreateContainer(
+		ctx,
+		containerName,
+		&container.Config{
+			Image: BusyboxRef,
+
+			// Use root user to avoid permission issues when reading files from the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: c.t.Name()},
+		},
+		&container.HostConfig{
+			Binds: []string{volumeName + ":" + mountPath},
+		},
+		nil, // No networking necessary.
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+	defer func() {
+		if err := rt.RemoveContainer(ctx, id); err != nil {
+			c.t.Logf("Failed to remove artifacts container %s: %v", id, err)
+		}
+	}()
+
+	for _, relPath := range ArtifactVolumePaths {
+		rc, err := rt.CopyFromContainer(ctx, id, path.Join(mountPath, relPath))
+		if err != nil {
+			// Most volumes only contain a few of the paths.
+			continue
+		}
+		err = c.writeFirstTarEntry(rc, filepath.Join(destDir, filepath.FromSlash(relPath)))
+		_ = rc.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", relPath, err)
+		}
+	}
+	return nil
+}
+
+func (c *artifactCollector) writeFirstTarEntry(r io.Reader, relPath string) error {
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil {
+		return fmt.Errorf("reading tar from container: %w", err)
+	}
+	if hdr.Typeflag != tar.TypeReg {
+		return nil
+	}
+	if hdr.Size > c.remaining {
+		c.t.Logf("Skipping artifact %s of %d bytes: exceeds remaining artifacts budget", relPath, hdr.Size)
+		return nil
+	}
+	return c.write(relPath, tr)
+}
+
+func (c *artifactCollector) collectContainerLogs(ctx context.Context) {
+	cs, err := c.rt.ListContainers(ctx, map[string]string{CleanupLabel: c.t.Name()})
+	if err != nil {
+		c.t.Logf("Failed to list containers for artifacts: %v", err)
+		return
+	}
+
+	for i, ctr := range cs {
+		// Split the remaining budget evenly between the remaining containers.
+		limit := c.remaining / int64(len(cs)-i)
+		if limit <= 0 {
+			c.t.Logf("Skipping remaining container logs: artifacts budget exhausted")
+			return
+		}
+
+		name := ctr.ID
+		if len(ctr.Names) > 0 {
+			name = strings.TrimPrefix(ctr.Names[0], "/")
+		}
+
+		tw := newTailWriter(limit)
+		if err := c.rt.WriteLogs(ctx, ctr.ID, tw); err != nil {
+			c.t.Logf("Failed to get logs of container %s for artifacts: %v", name, err)
+			continue
+		}
+
+		if err := c.write(filepath.Join("logs", SanitizeContainerName(name)+".log"), tw); err != nil {
+			c.t.Logf("Failed to write logs of container %s to artifacts: %v", name, err)
+		}
+	}
+}
+
+func (c *artifactCollector) collectHostFiles(files []string) {
+	for _, src := range files {
+		fi, err := os.Stat(src)
+		if err != nil {
+			c.t.Logf("Failed to collect artifact %s: %v", src, err)
+			continue
+		}
+		if fi.Size() > c.remaining {
+			c.t.Logf("Skipping artifact %s of %d bytes: exceeds remaining artifacts budget", src, fi.Size())
+			continue
+		}
+
+		f, err := os.Open(src)
+		if err != nil {
+			c.t.Logf("Failed to collect artifact %s: %v", src, err)
+			continue
+		}
+		err = c.write(filepath.Join("files", filepath.Base(src)), f)
+		_ = f.Close()
+		if err != nil {
+			c.t.Logf("Failed to collect artifact %s: %v", src, err)
+		}
+	}
+}
+
+// write copies r into relPath under the artifacts directory,
+// never writing more than the remaining budget, and reports the artifact.
+func (c *artifactCollector) write(relPath string, r io.Reader) error {
+	dst := filepath.Join(c.dir, relPath)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, io.LimitReader(r, c.remaining))
+	c.remaining -= n
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	c.collected++
+	for _, fn := range c.reporters {
+		fn(dst, n)
+	}
+	return nil
+}
+
+// tailWriter retains at most the last limit bytes written to it.
+type tailWriter struct {
+	limit int
+	buf   []byte
+}
+
+func newTailWriter(limit int64) *tailWriter {
+	return &tailWriter{limit: int(limit)}
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	// Compact only once the buffer has doubled, to avoid copying on every write.
+	if len(w.buf) > 2*w.limit {
+		w.buf = append(w.buf[:0], w.buf[len(w.buf)-w.limit:]...)
+	}
+	return len(p), nil
+}
+
+// Read drains the retained bytes.
+func (w *tailWriter) Read(p []byte) (int, error) {
+	if len(w.buf) > w.limit {
+		w.buf = w.buf[len(w.buf)-w.limit:]
+	}
+	if len(w.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, w.buf)
+	w.buf = w.buf[n:]
+	return n, nil
+}
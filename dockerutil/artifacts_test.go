@@ -0,0 +1,80 @@
+package dockerutil
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/mocktesting"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailWriter(t *testing.T) {
+	tw := newTailWriter(5)
+	for _, s := range []string{"abc", "defgh", "ijklmnopq", "r"} {
+		n, err := tw.Write([]byte(s))
+		require.NoError(t, err)
+		require.Equal(t, len(s), n)
+	}
+
+	got, err := io.ReadAll(tw)
+	require.NoError(t, err)
+	require.Equal(t, "nopqr", string(got))
+
+	got, err = io.ReadAll(newTailWriter(5))
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestArtifactRegistrations(t *testing.T) {
+	AddArtifactFile(t.Name(), "/tmp/a.db")
+	AddArtifactFile(t.Name(), "/tmp/b.db")
+	OnArtifact(t.Name(), func(string, int64) {})
+
+	files, reporters := takeArtifactRegistrations(t.Name())
+	require.Equal(t, []string{"/tmp/a.db", "/tmp/b.db"}, files)
+	require.Len(t, reporters, 1)
+
+	files, reporters = takeArtifactRegistrations(t.Name())
+	require.Empty(t, files)
+	require.Empty(t, reporters)
+}
+
+func TestArtifactCollector_HostFiles(t *testing.T) {
+	src := t.TempDir()
+	small := filepath.Join(src, "small.db")
+	large := filepath.Join(src, "large.db")
+	require.NoError(t, os.WriteFile(small, []byte("small"), 0o600))
+	require.NoError(t, os.WriteFile(large, []byte(strings.Repeat("x", 100)), 0o600))
+
+	type reported struct {
+		path string
+		size int64
+	}
+	var got []reported
+
+	c := &artifactCollector{
+		t:         mocktesting.NewT("TestFoo"),
+		dir:       t.TempDir(),
+		remaining: 50,
+		reporters: []func(string, int64){func(path string, size int64) {
+			got = append(got, reported{path, size})
+		}},
+	}
+	c.collectHostFiles([]string{large, small, filepath.Join(src, "missing.db")})
+
+	// The large file exceeds the budget and the missing file is skipped.
+	dst := filepath.Join(c.dir, "files", "small.db")
+	require.Equal(t, []reported{{dst, 5}}, got)
+	require.EqualValues(t, 45, c.remaining)
+	require.Equal(t, 1, c.collected)
+
+	b, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "small", string(b))
+
+	_, err = os.Stat(filepath.Join(c.dir, "files", "large.db"))
+	require.True(t, os.IsNotExist(err))
+}
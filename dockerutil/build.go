@@ -0,0 +1,445 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/docker/docker/api/types"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
+	"go.uber.org/zap"
+)
+
+// Defaults of the Dockerfile generated by BuildImage.
+const (
+	DefaultBuildGoVersion = "1.22"
+	DefaultBuildBaseImage = "debian:bookworm-slim"
+)
+
+// generatedDockerfile is the name of the generated Dockerfile within the build context.
+// It is unlikely to collide with a file of the repository being built.
+const generatedDockerfile = ".interchaintest.Dockerfile"
+
+// BuildOptions configures BuildImage.
+type BuildOptions struct {
+	// ContextDir is the root of the repository to build, e.g. "../" from a test in the chain's repository.
+	// Required.
+	ContextDir string
+
+	// Repository and Tag name the built image.
+	// Repository is required. Tag defaults to "local", so the image is never pulled.
+	Repository string
+	Tag        string
+
+	// UidGid is the user of the chain's containers, as in ibc.DockerImage.
+	// Defaults to the heighliner user, 1025:1025.
+	UidGid string
+
+	// Dockerfile is the path, relative to ContextDir, of a Dockerfile to build.
+	// If empty, a Dockerfile for a cosmos-sdk app is generated from CosmosDockerfileTemplate.
+	Dockerfile string
+
+	// BuildArgs are passed to the build as --build-arg values.
+	BuildArgs map[string]string
+
+	// Target is the stage to build, for multi-stage Dockerfiles.
+	Target string
+
+	// NoCache disables the build cache, including the cache mounts of the generated Dockerfile.
+	NoCache bool
+
+	// LegacyBuilder builds with the classic builder instead of BuildKit,
+	// for engines without BuildKit support.
+	// The generated Dockerfile then omits cache mounts, so every build downloads modules and compiles from scratch.
+	LegacyBuilder bool
+
+	// The following fields only apply to the generated Dockerfile.
+
+	// Binary is the name of the chain binary, installed as /bin/<Binary>.
+	// Required if Dockerfile is empty.
+	Binary string
+
+	// Package is the Go package of the binary's main, relative to ContextDir.
+	// Defaults to "./cmd/<Binary>".
+	Package string
+
+	// GoVersion is the tag of the golang image used to compile. Defaults to DefaultBuildGoVersion.
+	GoVersion string
+
+	// BaseImage is the image the binary is copied into. Defaults to DefaultBuildBaseImage.
+	BaseImage string
+
+	// BuildTags and LDFlags are passed to `go build` as -tags and -ldflags.
+	BuildTags []string
+	LDFlags   string
+
+	// CgoDisabled builds with CGO_ENABLED=0.
+	// Chains linking libwasmvm require cgo, and must provide the library in BaseImage.
+	CgoDisabled bool
+
+	// Packages are installed with apt-get in both stages, e.g. build dependencies of cgo.
+	Packages []string
+}
+
+// Image returns the reference of the image built with o.
+func (o BuildOptions) Image() ibc.DockerImage {
+	tag := o.Tag
+	if tag == "" {
+		tag = "local"
+	}
+	uidGid := o.UidGid
+	if uidGid == "" {
+		uidGid = GetHeighlinerUserString()
+	}
+	return ibc.NewDockerImage(o.Repository, tag, uidGid)
+}
+
+func (o BuildOptions) validate() error {
+	if o.ContextDir == "" {
+		return errors.New("ContextDir must be set")
+	}
+	if o.Repository == "" {
+		return errors.New("Repository must be set")
+	}
+	if o.Dockerfile == "" && o.Binary == "" {
+		return errors.New("Binary must be set when Dockerfile is empty")
+	}
+	return nil
+}
+
+// CosmosDockerfileTemplate is the text/template of the Dockerfile generated by BuildImage,
+// executed with a cosmosDockerfileData.
+//
+// Module downloads and the Go build cache are kept in cache mounts across builds,
+// so rebuilding after a small change to the chain only recompiles the affected packages.
+const CosmosDockerfileTemplate = `FROM golang:{{ .GoVersion }} AS build
+{{- if .Packages }}
+RUN apt-get update && apt-get install -y --no-install-recommends {{ .Packages }} && rm -rf /var/lib/apt/lists/*
+{{- end }}
+WORKDIR /src
+COPY go.mod go.sum ./
+RUN {{ .CacheMounts }}go mod download
+COPY . .
+RUN {{ .CacheMounts }}CGO_ENABLED={{ .Cgo }} go build -trimpath{{ if .BuildTags }} -tags "{{ .BuildTags }}"{{ end }}{{ if .LDFlags }} -ldflags "{{ .LDFlags }}"{{ end }} -o /out/{{ .Binary }} {{ .Package }}
+
+FROM {{ .BaseImage }}
+{{- if .Packages }}
+RUN apt-get update && apt-get install -y --no-install-recommends {{ .Packages }} && rm -rf /var/lib/apt/lists/*
+{{- end }}
+COPY --from=build /out/{{ .Binary }} /bin/{{ .Binary }}
+`
+
+type cosmosDockerfileData struct {
+	GoVersion, BaseImage string
+	Binary, Package      string
+	BuildTags, LDFlags   string
+	Packages             string
+	Cgo                  int
+	CacheMounts          string
+}
+
+var cosmosDockerfile = template.Must(template.New("Dockerfile").Parse(CosmosDockerfileTemplate))
+
+// GenerateDockerfile renders CosmosDockerfileTemplate for o.
+func GenerateDockerfile(o BuildOptions) ([]byte, error) {
+	d := cosmosDockerfileData{
+		GoVersion: o.GoVersion,
+		BaseImage: o.BaseImage,
+		Binary:    o.Binary,
+		Package:   o.Package,
+		BuildTags: strings.Join(o.BuildTags, ","),
+		LDFlags:   strings.ReplaceAll(o.LDFlags, `"`, `\"`),
+		Packages:  strings.Join(o.Packages, " "),
+		Cgo:       1,
+	}
+	if d.GoVersion == "" {
+		d.GoVersion = DefaultBuildGoVersion
+	}
+	if d.BaseImage == "" {
+		d.BaseImage = DefaultBuildBaseImage
+	}
+	if d.Package == "" {
+		d.Package = "./cmd/" + o.Binary
+	}
+	if o.CgoDisabled {
+		d.Cgo = 0
+	}
+	if !o.LegacyBuilder && !o.NoCache {
+		d.CacheMounts = "--mount=type=cache,target=/go/pkg/mod --mount=type=cache,target=/root/.cache/go-build "
+	}
+
+	var buf bytes.Buffer
+	if err := cosmosDockerfile.Execute(&buf, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// BuildImage builds an image from the repository at opts.ContextDir with rt and returns its reference,
+// which may be used as a ChainSpec image so tests run against uncommitted changes of a chain.
+//
+// The build output is logged at debug level. If the build fails, the error includes the last lines of output.
+//
+// For the process runtime, the binary is built on the host into the runtime's BinDir instead.
+func BuildImage(ctx context.Context, log *zap.Logger, rt ContainerRuntime, opts BuildOptions) (_ ibc.DockerImage, err error) {
+	img := opts.Image()
+	ctx, span := tracing.Start(ctx, "docker.image.build", tracing.AttrImage.String(img.Ref()))
+	defer func() { tracing.End(span, err) }()
+
+	if err := opts.validate(); err != nil {
+		return ibc.DockerImage{}, fmt.Errorf("invalid build options: %w", err)
+	}
+	if err := rt.BuildChain(ctx, log, opts); err != nil {
+		return ibc.DockerImage{}, err
+	}
+	return img, nil
+}
+
+// buildChainImage builds the image of opts with rt.BuildImage,
+// from a generated Dockerfile unless opts.Dockerfile is set.
+func buildChainImage(ctx context.Context, log *zap.Logger, rt ContainerRuntime, opts BuildOptions) error {
+	img := opts.Image()
+
+	var extra map[string][]byte
+	dockerfile := opts.Dockerfile
+	if dockerfile == "" {
+		b, err := GenerateDockerfile(opts)
+		if err != nil {
+			return fmt.Errorf("generate Dockerfile: %w", err)
+		}
+		dockerfile = generatedDockerfile
+		extra = map[string][]byte{generatedDockerfile: b}
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(TarBuildContext(pw, opts.ContextDir, extra))
+	}()
+	defer pr.Close()
+
+	buildArgs := make(map[string]*string, len(opts.BuildArgs))
+	for k, v := range opts.BuildArgs {
+		v := v
+		buildArgs[k] = &v
+	}
+
+	version := types.BuilderBuildKit
+	if opts.LegacyBuilder {
+		version = types.BuilderV1
+	}
+
+	log.Info("Building image", zap.String("image", img.Ref()), zap.String("context", opts.ContextDir))
+	rc, err := rt.BuildImage(ctx, pr, types.ImageBuildOptions{
+		Tags:        []string{img.Ref()},
+		Dockerfile:  dockerfile,
+		BuildArgs:   buildArgs,
+		Target:      opts.Target,
+		NoCache:     opts.NoCache,
+		Remove:      true,
+		ForceRemove: true,
+		Version:     version,
+	})
+	if err != nil {
+		return fmt.Errorf("build image %s: %w", img.Ref(), err)
+	}
+	defer rc.Close()
+
+	if err := readBuildOutput(log, rc); err != nil {
+		return fmt.Errorf("build image %s: %w", img.Ref(), err)
+	}
+	return nil
+}
+
+// buildOutputTail is the number of lines of build output included in a build error.
+const buildOutputTail = 30
+
+// readBuildOutput consumes the JSON message stream of an image build,
+// returning an error if the stream reports one.
+func readBuildOutput(log *zap.Logger, r io.Reader) error {
+	var tail []string
+	addLines := func(s string) {
+		for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+			if line = strings.TrimSpace(line); line == "" {
+				continue
+			}
+			log.Debug(line)
+			tail = append(tail, line)
+			if len(tail) > buildOutputTail {
+				tail = tail[1:]
+			}
+		}
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var msg struct {
+			Stream      string `json:"stream"`
+			Status      string `json:"status"`
+			Error       string `json:"error"`
+			ErrorDetail *struct {
+				Message string `json:"message"`
+			} `json:"errorDetail"`
+			Aux json.RawMessage `json:"aux"`
+		}
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("read build output: %w", err)
+		}
+
+		addLines(msg.Stream)
+		addLines(msg.Status)
+
+		errMsg := msg.Error
+		if msg.ErrorDetail != nil && msg.ErrorDetail.Message != "" {
+			errMsg = msg.ErrorDetail.Message
+		}
+		if errMsg != "" {
+			if len(tail) == 0 {
+				return errors.New(errMsg)
+			}
+			return fmt.Errorf("%s\n%s", errMsg, strings.Join(tail, "\n"))
+		}
+	}
+}
+
+// TarBuildContext writes a tar stream of dir to w, along with the extra files keyed by name.
+//
+// Paths matched by patterns in dir/.dockerignore are skipped, as is the .git directory.
+// Patterns are matched with path.Match against slash-separated paths relative to dir;
+// a matched directory excludes its content, and patterns starting with "!" re-include paths.
+func TarBuildContext(w io.Writer, dir string, extra map[string][]byte) error {
+	ignore, err := readDockerignore(filepath.Join(dir, ".dockerignore"))
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if rel == ".git" || ignore.excludes(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+		// Ownership and timestamps of the host would only invalidate the build cache.
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+		hdr.ModTime = hdr.ModTime.Truncate(1e9)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("archive build context %s: %w", dir, err)
+	}
+
+	for name, content := range extra {
+		if err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0o644,
+			Size: int64(len(content)),
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+type dockerignore []string
+
+func readDockerignore(p string) (dockerignore, error) {
+	b, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns dockerignore
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		neg := strings.HasPrefix(line, "!")
+		line = path.Clean(strings.TrimPrefix(strings.TrimPrefix(line, "!"), "/"))
+		if neg {
+			line = "!" + line
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, nil
+}
+
+// excludes reports whether rel is excluded; the last matching pattern wins.
+func (ig dockerignore) excludes(rel string) bool {
+	excluded := false
+	for _, p := range ig {
+		neg := strings.HasPrefix(p, "!")
+		p = strings.TrimPrefix(p, "!")
+		if ok, _ := path.Match(p, rel); ok {
+			excluded = !neg
+		}
+	}
+	return excluded
+}
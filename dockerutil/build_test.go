@@ -0,0 +1,150 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGenerateDockerfile(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		b, err := GenerateDockerfile(BuildOptions{Binary: "simd"})
+		require.NoError(t, err)
+
+		df := string(b)
+		require.Contains(t, df, "FROM golang:"+DefaultBuildGoVersion+" AS build\n")
+		require.Contains(t, df, "FROM "+DefaultBuildBaseImage+"\n")
+		require.Contains(t, df, "RUN --mount=type=cache,target=/go/pkg/mod --mount=type=cache,target=/root/.cache/go-build go mod download\n")
+		require.Contains(t, df, "CGO_ENABLED=1 go build -trimpath -o /out/simd ./cmd/simd\n")
+		require.Contains(t, df, "COPY --from=build /out/simd /bin/simd\n")
+		require.NotContains(t, df, "apt-get")
+	})
+
+	t.Run("options", func(t *testing.T) {
+		b, err := GenerateDockerfile(BuildOptions{
+			Binary:        "gaiad",
+			Package:       "./cmd/gaia",
+			GoVersion:     "1.21-bookworm",
+			BaseImage:     "ubuntu:22.04",
+			BuildTags:     []string{"netgo", "ledger"},
+			LDFlags:       `-X main.Version=dev -X "main.Name=gaia"`,
+			CgoDisabled:   true,
+			LegacyBuilder: true,
+			Packages:      []string{"git", "make"},
+		})
+		require.NoError(t, err)
+
+		df := string(b)
+		require.Contains(t, df, "FROM golang:1.21-bookworm AS build\n")
+		require.Contains(t, df, "FROM ubuntu:22.04\n")
+		require.Contains(t, df, "RUN go mod download\n")
+		require.Contains(t, df, `RUN CGO_ENABLED=0 go build -trimpath -tags "netgo,ledger" -ldflags "-X main.Version=dev -X \"main.Name=gaia\"" -o /out/gaiad ./cmd/gaia`+"\n")
+		require.Equal(t, 2, strings.Count(df, "apt-get install -y --no-install-recommends git make"))
+		require.NotContains(t, df, "--mount")
+	})
+}
+
+func TestBuildOptions(t *testing.T) {
+	img := BuildOptions{Repository: "simd"}.Image()
+	require.Equal(t, "simd:local", img.Ref())
+	require.NoError(t, img.Validate())
+
+	require.Equal(t, "simd:dev", BuildOptions{Repository: "simd", Tag: "dev"}.Image().Ref())
+
+	require.ErrorContains(t, BuildOptions{Repository: "simd", Binary: "simd"}.validate(), "ContextDir")
+	require.ErrorContains(t, BuildOptions{ContextDir: ".", Binary: "simd"}.validate(), "Repository")
+	require.ErrorContains(t, BuildOptions{ContextDir: ".", Repository: "simd"}.validate(), "Binary")
+	require.NoError(t, BuildOptions{ContextDir: ".", Repository: "simd", Dockerfile: "Dockerfile"}.validate())
+}
+
+func TestTarBuildContext(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		".dockerignore":      "# comment\n/build\n*.log\n!keep.log\n",
+		"go.mod":             "module example.com/simapp\n",
+		"cmd/simd/main.go":   "package main\n",
+		"build/simd":         "binary",
+		"debug.log":          "log",
+		"keep.log":           "log",
+		".git/HEAD":          "ref: refs/heads/main\n",
+		"x/bank/keeper.go":   "package keeper\n",
+		"x/bank/keeper.log":  "nested logs are not matched by *.log",
+		"build.go.unrelated": "kept",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, TarBuildContext(&buf, dir, map[string][]byte{generatedDockerfile: []byte("FROM scratch\n")}))
+
+	files := make(map[string]string)
+	var dirs []string
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.Zero(t, hdr.Uid)
+		if hdr.Typeflag == tar.TypeDir {
+			dirs = append(dirs, hdr.Name)
+			continue
+		}
+		b, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(b)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	require.Equal(t, []string{
+		".dockerignore",
+		generatedDockerfile,
+		"build.go.unrelated",
+		"cmd/simd/main.go",
+		"go.mod",
+		"keep.log",
+		"x/bank/keeper.go",
+		"x/bank/keeper.log",
+	}, names)
+	require.Equal(t, "FROM scratch\n", files[generatedDockerfile])
+	require.ElementsMatch(t, []string{"cmd/", "cmd/simd/", "x/", "x/bank/"}, dirs)
+}
+
+func TestReadBuildOutput(t *testing.T) {
+	log := zap.NewNop()
+
+	ok := `{"stream":"Step 1/2 : FROM golang\n"}
+{"aux":{"ID":"sha256:abc"}}
+{"stream":"Successfully built abc\n"}
+`
+	require.NoError(t, readBuildOutput(log, strings.NewReader(ok)))
+
+	failed := `{"stream":"Step 2/2 : RUN go build\n"}
+{"stream":"./main.go:3:1: syntax error\n"}
+{"errorDetail":{"code":1,"message":"The command '/bin/sh -c go build' returned a non-zero code: 1"},"error":"The command returned a non-zero code: 1"}
+`
+	err := readBuildOutput(log, strings.NewReader(failed))
+	require.EqualError(t, err, "The command '/bin/sh -c go build' returned a non-zero code: 1\nStep 2/2 : RUN go build\n./main.go:3:1: syntax error")
+
+	err = readBuildOutput(log, strings.NewReader(`{"error":"boom"}`))
+	require.EqualError(t, err, "boom")
+
+	err = readBuildOutput(log, strings.NewReader(`{"stream":`))
+	require.ErrorContains(t, err, "read build output")
+}
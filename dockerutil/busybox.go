@@ -3,12 +3,7 @@ package dockerutil
 import (
 	"context"
 	"fmt"
-	"io"
 	"sync"
-
-	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/client"
 )
 
 // Allow multiple goroutines to check for busybox
@@ -21,9 +16,11 @@ var (
 	hasBusybox      bool
 )
 
-const busyboxRef = "busybox:stable"
+// BusyboxRef is the image used for auxiliary containers, such as those that read or write volume files.
+const BusyboxRef = "busybox:stable"
 
-func EnsureBusybox(ctx context.Context, cli *client.Client) error {
+// EnsureBusybox pulls BusyboxRef with rt if it is not present locally.
+func EnsureBusybox(ctx context.Context, rt ContainerRuntime) error {
 	ensureBusyboxMu.Lock()
 	defer ensureBusyboxMu.Unlock()
 
@@ -31,26 +28,10 @@ func EnsureBusybox(ctx context.Context, cli *client.Client) error {
 		return nil
 	}
 
-	images, err := cli.ImageList(ctx, types.ImageListOptions{
-		Filters: filters.NewArgs(filters.Arg("reference", busyboxRef)),
-	})
-	if err != nil {
-		return fmt.Errorf("listing images to check busybox presence: %w", err)
-	}
-
-	if len(images) > 0 {
-		hasBusybox = true
-		return nil
+	if err := ensureImage(ctx, rt, BusyboxRef); err != nil {
+		return fmt.Errorf("ensuring busybox presence: %w", err)
 	}
 
-	rc, err := cli.ImagePull(ctx, busyboxRef, types.ImagePullOptions{})
-	if err != nil {
-		return err
-	}
-
-	_, _ = io.Copy(io.Discard, rc)
-	_ = rc.Close()
-
 	hasBusybox = true
 	return nil
 }
@@ -3,43 +3,60 @@ package dockerutil
 import (
 	"context"
 	"fmt"
-	"io"
-	"net"
 	"regexp"
 	"strings"
 	"time"
 
-	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
-	dockerclient "github.com/docker/docker/client"
-	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-connections/nat"
 	"go.uber.org/zap"
 
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
 )
 
 // Example Go/Cosmos-SDK panic format is `panic: bad Duration: time: invalid duration "bad"\n`
 var panicRe = regexp.MustCompile(`panic:.*\n`)
 
 type ContainerLifecycle struct {
-	log               *zap.Logger
-	client            *dockerclient.Client
-	containerName     string
-	id                string
-	preStartListeners Listeners
+	log           *zap.Logger
+	rt            ContainerRuntime
+	containerName string
+	id            string
+	resources     ibc.ResourceLimits
+	labels        map[string]string
+
+	// create recreates the container with new host ports, after a port conflict on start.
+	create func(ctx context.Context) error
+	// Host ports allocated for the container, released when it is removed.
+	allocatedPorts []int
 }
 
-func NewContainerLifecycle(log *zap.Logger, client *dockerclient.Client, containerName string) *ContainerLifecycle {
+// maxStartAttempts is the number of times a container is created and started
+// when its start fails because a host port is taken.
+const maxStartAttempts = 3
+
+// NewContainerLifecycle returns a ContainerLifecycle for the container named containerName, run by rt.
+func NewContainerLifecycle(log *zap.Logger, rt ContainerRuntime, containerName string) *ContainerLifecycle {
 	return &ContainerLifecycle{
 		log:           log,
-		client:        client,
+		rt:            rt,
 		containerName: containerName,
 	}
 }
 
+// SetResources sets the CPU and memory limits of containers subsequently created by CreateContainer.
+func (c *ContainerLifecycle) SetResources(limits ibc.ResourceLimits) {
+	c.resources = limits
+}
+
+// SetLabels sets labels, in addition to CleanupLabel, on containers subsequently created by CreateContainer.
+func (c *ContainerLifecycle) SetLabels(labels map[string]string) {
+	c.labels = labels
+}
+
 func (c *ContainerLifecycle) CreateContainer(
 	ctx context.Context,
 	testName string,
@@ -52,8 +69,14 @@ func (c *ContainerLifecycle) CreateContainer(
 	cmd []string,
 	env []string,
 	entrypoint []string,
-) error {
+) (err error) {
 	imageRef := image.Ref()
+
+	ctx, span := tracing.Start(ctx, "docker.container.create",
+		tracing.AttrImage.String(imageRef),
+		tracing.AttrContainer.String(c.containerName),
+	)
+	defer func() { tracing.End(span, err) }()
 	c.log.Info(
 		"Will run command",
 		zap.String("image", imageRef),
@@ -61,7 +84,7 @@ func (c *ContainerLifecycle) CreateContainer(
 		zap.String("command", strings.Join(cmd, " ")),
 	)
 
-	if err := image.PullImage(ctx, c.client); err != nil {
+	if err := ensureImage(ctx, c.rt, imageRef); err != nil {
 		return err
 	}
 
@@ -70,70 +93,89 @@ func (c *ContainerLifecycle) CreateContainer(
 		pS[k] = struct{}{}
 	}
 
-	pb, listeners, err := GeneratePortBindings(ports)
-	if err != nil {
-		return fmt.Errorf("failed to generate port bindings: %w", err)
+	labels := map[string]string{CleanupLabel: testName}
+	for k, v := range c.labels {
+		labels[k] = v
 	}
 
-	c.preStartListeners = listeners
+	c.create = func(ctx context.Context) error {
+		pb, _, err := GeneratePortBindings(ports)
+		if err != nil {
+			return fmt.Errorf("failed to generate port bindings: %w", err)
+		}
+		allocated := allocatedPorts(ports, pb)
 
-	cc, err := c.client.ContainerCreate(
-		ctx,
-		&container.Config{
-			Image: imageRef,
+		id, err := c.rt.CreateContainer(
+			ctx,
+			c.containerName,
+			&container.Config{
+				Image: imageRef,
 
-			Entrypoint: entrypoint,
-			Cmd:        cmd,
-			Env:        env,
+				Entrypoint: entrypoint,
+				Cmd:        cmd,
+				Env:        env,
 
-			Hostname: hostName,
+				Hostname: hostName,
 
-			Labels: map[string]string{CleanupLabel: testName},
+				Labels: labels,
 
-			ExposedPorts: pS,
-		},
-		&container.HostConfig{
-			Binds:           volumeBinds,
-			PortBindings:    pb,
-			PublishAllPorts: true,
-			AutoRemove:      false,
-			DNS:             []string{},
-			Mounts:          mounts,
-		},
-		&network.NetworkingConfig{
-			EndpointsConfig: map[string]*network.EndpointSettings{
-				networkID: {},
+				ExposedPorts: pS,
 			},
-		},
-		nil,
-		c.containerName,
-	)
-	if err != nil {
-		listeners.CloseAll()
-		c.preStartListeners = []net.Listener{}
-		return err
+			&container.HostConfig{
+				Binds:           volumeBinds,
+				PortBindings:    pb,
+				PublishAllPorts: true,
+				AutoRemove:      false,
+				DNS:             []string{},
+				Mounts:          mounts,
+				Resources:       HostResources(c.resources),
+			},
+			&network.NetworkingConfig{
+				EndpointsConfig: map[string]*network.EndpointSettings{
+					networkID: {},
+				},
+			},
+		)
+		if err != nil {
+			DefaultPortAllocator.Release(allocated...)
+			return err
+		}
+		c.id = id
+		c.allocatedPorts = allocated
+		return nil
 	}
-	c.id = cc.ID
-	return nil
+	return c.create(ctx)
 }
 
 func (c *ContainerLifecycle) StartContainer(ctx context.Context) error {
-	// lock port allocation for the time between freeing the ports from the
-	// temporary listeners to the consumption of the ports by the container
-	mu.RLock()
-	defer mu.RUnlock()
-
-	c.preStartListeners.CloseAll()
-	c.preStartListeners = []net.Listener{}
-
-	if err := StartContainer(ctx, c.client, c.id); err != nil {
-		return err
+	for attempt := 1; ; attempt++ {
+		err := StartContainer(ctx, c.rt, c.id)
+		if err == nil {
+			break
+		}
+		// Ports are reserved among interchaintest processes, but other programs may still take them.
+		if !IsPortConflict(err) || c.create == nil || attempt == maxStartAttempts {
+			return err
+		}
+
+		c.log.Warn(
+			"Host port taken while starting container, recreating it with new ports",
+			zap.String("container", c.containerName),
+			zap.Error(err),
+		)
+		if err := c.RemoveContainer(ctx); err != nil {
+			return err
+		}
+		if err := c.create(ctx); err != nil {
+			return fmt.Errorf("recreate container %s: %w", c.containerName, err)
+		}
 	}
 
 	if err := c.CheckForFailedStart(ctx, time.Second*1); err != nil {
 		return err
 	}
 
+	containersOf(c.rt).add(c.id, c.containerName)
 	c.log.Info("Container started", zap.String("container", c.containerName))
 	return nil
 }
@@ -142,20 +184,14 @@ func (c *ContainerLifecycle) StartContainer(ctx context.Context) error {
 // panic message after a wait period to allow the container to start.
 func (c *ContainerLifecycle) CheckForFailedStart(ctx context.Context, wait time.Duration) error {
 	time.Sleep(wait)
-	containerLogs, err := c.client.ContainerLogs(ctx, c.id, dockertypes.ContainerLogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-	})
+	stdout, stderr, err := c.rt.ContainerLogs(ctx, c.id, 0)
 	if err != nil {
 		return fmt.Errorf("failed to read logs from container %s: %w", c.containerName, err)
 	}
-	defer containerLogs.Close()
 
 	logs := new(strings.Builder)
-	_, err = io.Copy(logs, containerLogs)
-	if err != nil {
-		return fmt.Errorf("failed to read logs from container %s: %w", c.containerName, err)
-	}
+	logs.Write(stdout)
+	logs.Write(stderr)
 
 	if err := ParseSDKPanicFromText(logs.String()); err != nil {
 		// Must use Println and not the logger as there are ascii escape codes in the logs.
@@ -183,39 +219,78 @@ func ParseSDKPanicFromText(text string) error {
 	return nil
 }
 
+// Attach makes c manage the existing container id, such as one created by an earlier process,
+// starting it if it is not running.
+func (c *ContainerLifecycle) Attach(ctx context.Context, id string) error {
+	cjson, err := c.rt.InspectContainer(ctx, id)
+	if err != nil {
+		return fmt.Errorf("inspect container %s: %w", id, err)
+	}
+	c.id = cjson.ID
+	c.containerName = strings.TrimPrefix(cjson.Name, "/")
+
+	switch {
+	case cjson.State.Paused:
+		if err := c.UnpauseContainer(ctx); err != nil {
+			return err
+		}
+		containersOf(c.rt).add(c.id, c.containerName)
+		return nil
+	case cjson.State.Running:
+		containersOf(c.rt).add(c.id, c.containerName)
+		c.log.Info("Attached to container", zap.String("container", c.containerName))
+		return nil
+	default:
+		return c.StartContainer(ctx)
+	}
+}
+
 func (c *ContainerLifecycle) PauseContainer(ctx context.Context) error {
-	return c.client.ContainerPause(ctx, c.id)
+	return c.rt.PauseContainer(ctx, c.id)
 }
 
 func (c *ContainerLifecycle) UnpauseContainer(ctx context.Context) error {
-	return c.client.ContainerUnpause(ctx, c.id)
+	return c.rt.UnpauseContainer(ctx, c.id)
 }
 
 func (c *ContainerLifecycle) StopContainer(ctx context.Context) error {
-	var timeout container.StopOptions
-	timeoutSec := 30
-	timeout.Timeout = &timeoutSec
-
-	return c.client.ContainerStop(ctx, c.id, timeout)
+	c.ExpectExit()
+	return c.rt.StopContainer(ctx, c.id, 30*time.Second)
 }
 
 func (c *ContainerLifecycle) RemoveContainer(ctx context.Context) error {
-	err := c.client.ContainerRemove(ctx, c.id, dockertypes.ContainerRemoveOptions{
-		Force:         true,
-		RemoveVolumes: true,
-	})
-	if err != nil && !errdefs.IsNotFound(err) {
+	c.ExpectExit()
+	if err := c.rt.RemoveContainer(ctx, c.id); err != nil {
 		return fmt.Errorf("remove container %s: %w", c.containerName, err)
 	}
+	DefaultPortAllocator.Release(c.allocatedPorts...)
+	c.allocatedPorts = nil
 	return nil
 }
 
+// ExpectExit tells supervisors that the container is about to exit on purpose,
+// such as when it is killed without going through c or halts at an upgrade height.
+// It is supervised again once started through c.
+func (c *ContainerLifecycle) ExpectExit() {
+	containersOf(c.rt).remove(c.id)
+}
+
+// Stats returns a single sample of the container's resource usage.
+func (c *ContainerLifecycle) Stats(ctx context.Context) (ContainerStats, error) {
+	s, err := c.rt.Stats(ctx, c.id)
+	if err != nil {
+		return ContainerStats{}, fmt.Errorf("stats of container %s: %w", c.containerName, err)
+	}
+	s.Name = c.containerName
+	return s, nil
+}
+
 func (c *ContainerLifecycle) ContainerID() string {
 	return c.id
 }
 
 func (c *ContainerLifecycle) GetHostPorts(ctx context.Context, portIDs ...string) ([]string, error) {
-	cjson, err := c.client.ContainerInspect(ctx, c.id)
+	cjson, err := c.rt.InspectContainer(ctx, c.id)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +304,7 @@ func (c *ContainerLifecycle) GetHostPorts(ctx context.Context, portIDs ...string
 // Running will inspect the container and check its state to determine if it is currently running.
 // If the container is running nil will be returned, otherwise an error is returned.
 func (c *ContainerLifecycle) Running(ctx context.Context) error {
-	cjson, err := c.client.ContainerInspect(ctx, c.id)
+	cjson, err := c.rt.InspectContainer(ctx, c.id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,69 @@
+package dockerutil
+
+import (
+	"sync"
+	"time"
+)
+
+// ExecRecord describes a command run to completion with (*Image).Run.
+type ExecRecord struct {
+	// The testName given to NewImage.
+	TestName string
+
+	// Empty if the container failed to start.
+	ContainerName string
+
+	Command []string
+
+	StartedAt, FinishedAt time.Time
+
+	Result ContainerExecResult
+}
+
+var execObservers = struct {
+	mu     sync.Mutex
+	nextID int
+	byTest map[string]map[int]func(ExecRecord)
+}{
+	byTest: make(map[string]map[int]func(ExecRecord)),
+}
+
+// ObserveExecs calls fn after every (*Image).Run for images created with testName.
+// fn is called synchronously from the goroutine calling Run, so it should return quickly.
+//
+// The returned function stops calling fn, and is safe to be called multiple times.
+func ObserveExecs(testName string, fn func(ExecRecord)) (stop func()) {
+	execObservers.mu.Lock()
+	defer execObservers.mu.Unlock()
+
+	id := execObservers.nextID
+	execObservers.nextID++
+
+	if execObservers.byTest[testName] == nil {
+		execObservers.byTest[testName] = make(map[int]func(ExecRecord))
+	}
+	execObservers.byTest[testName][id] = fn
+
+	return func() {
+		execObservers.mu.Lock()
+		defer execObservers.mu.Unlock()
+
+		delete(execObservers.byTest[testName], id)
+		if len(execObservers.byTest[testName]) == 0 {
+			delete(execObservers.byTest, testName)
+		}
+	}
+}
+
+func notifyExecObservers(rec ExecRecord) {
+	execObservers.mu.Lock()
+	fns := make([]func(ExecRecord), 0, len(execObservers.byTest[rec.TestName]))
+	for _, fn := range execObservers.byTest[rec.TestName] {
+		fns = append(fns, fn)
+	}
+	execObservers.mu.Unlock()
+
+	for _, fn := range fns {
+		fn(rec)
+	}
+}
@@ -0,0 +1,29 @@
+package dockerutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveExecs(t *testing.T) {
+	var got1, got2 []ExecRecord
+	stop1 := ObserveExecs(t.Name(), func(rec ExecRecord) { got1 = append(got1, rec) })
+	stop2 := ObserveExecs(t.Name(), func(rec ExecRecord) { got2 = append(got2, rec) })
+	defer stop2()
+
+	notifyExecObservers(ExecRecord{TestName: t.Name(), ContainerName: "a"})
+	notifyExecObservers(ExecRecord{TestName: "other", ContainerName: "b"})
+
+	stop1()
+	stop1() // Safe to call multiple times.
+
+	notifyExecObservers(ExecRecord{TestName: t.Name(), ContainerName: "c"})
+
+	require.Len(t, got1, 1)
+	require.Equal(t, "a", got1[0].ContainerName)
+
+	require.Len(t, got2, 2)
+	require.Equal(t, "a", got2[0].ContainerName)
+	require.Equal(t, "c", got2[1].ContainerName)
+}
@@ -42,7 +42,7 @@ func CopyFile(src, dst string) (int64, error) {
 }
 
 func CopyCoverageFromContainer(ctx context.Context, t *testing.T, client *client.Client, containerId string, internalGoCoverDir string, extHostGoCoverDir string) {
-	r, _, err := client.CopyFromContainer(ctx, containerId, internalGoCoverDir)
+	r, err := RuntimeFor(client).CopyFromContainer(ctx, containerId, internalGoCoverDir)
 	require.NoError(t, err)
 	defer r.Close()
 
@@ -8,9 +8,7 @@ import (
 	"path"
 	"time"
 
-	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/client"
 	"go.uber.org/zap"
 )
 
@@ -19,14 +17,14 @@ import (
 type FileRetriever struct {
 	log *zap.Logger
 
-	cli *client.Client
+	rt ContainerRuntime
 
 	testName string
 }
 
 // NewFileRetriever returns a new FileRetriever.
-func NewFileRetriever(log *zap.Logger, cli *client.Client, testName string) *FileRetriever {
-	return &FileRetriever{log: log, cli: cli, testName: testName}
+func NewFileRetriever(log *zap.Logger, rt ContainerRuntime, testName string) *FileRetriever {
+	return &FileRetriever{log: log, rt: rt, testName: testName}
 }
 
 // SingleFileContent returns the content of the file named at relPath,
@@ -34,16 +32,18 @@ func NewFileRetriever(log *zap.Logger, cli *client.Client, testName string) *Fil
 func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPath string) ([]byte, error) {
 	const mountPath = "/mnt/dockervolume"
 
-	if err := EnsureBusybox(ctx, r.cli); err != nil {
+	if err := EnsureBusybox(ctx, r.rt); err != nil {
 		return nil, err
 	}
 
 	containerName := fmt.Sprintf("%s-getfile-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
 
-	cc, err := r.cli.ContainerCreate(
+	rt := r.rt
+	id, err := rt.CreateContainer(
 		ctx,
+		containerName,
 		&container.Config{
-			Image: busyboxRef,
+			Image: BusyboxRef,
 
 			// Use root user to avoid permission issues when reading files from the volume.
 			User: GetRootUserString(),
@@ -55,22 +55,18 @@ func (r *FileRetriever) SingleFileContent(ctx context.Context, volumeName, relPa
 			AutoRemove: true,
 		},
 		nil, // No networking necessary.
-		nil,
-		containerName,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("creating container: %w", err)
 	}
 
 	defer func() {
-		if err := r.cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
-			Force: true,
-		}); err != nil {
-			r.log.Warn("Failed to remove file content container", zap.String("container_id", cc.ID), zap.Error(err))
+		if err := rt.RemoveContainer(ctx, id); err != nil {
+			r.log.Warn("Failed to remove file content container", zap.String("container_id", id), zap.Error(err))
 		}
 	}()
 
-	rc, _, err := r.cli.CopyFromContainer(ctx, cc.ID, path.Join(mountPath, relPath))
+	rc, err := rt.CopyFromContainer(ctx, id, path.Join(mountPath, relPath))
 	if err != nil {
 		return nil, fmt.Errorf("copying from container: %w", err)
 	}
@@ -19,6 +19,7 @@ func TestFileRetriever(t *testing.T) {
 	t.Parallel()
 
 	cli, network := interchaintest.DockerSetup(t)
+	rt := dockerutil.RuntimeFor(cli)
 
 	ctx := context.Background()
 	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
@@ -28,7 +29,7 @@ func TestFileRetriever(t *testing.T) {
 
 	img := dockerutil.NewImage(
 		zaptest.NewLogger(t),
-		cli,
+		rt,
 		network,
 		t.Name(),
 		"busybox", "stable",
@@ -53,7 +54,7 @@ func TestFileRetriever(t *testing.T) {
 	)
 	require.NoError(t, res.Err)
 
-	fr := dockerutil.NewFileRetriever(zaptest.NewLogger(t), cli, t.Name())
+	fr := dockerutil.NewFileRetriever(zaptest.NewLogger(t), rt, t.Name())
 
 	t.Run("top-level file", func(t *testing.T) {
 		b, err := fr.SingleFileContent(ctx, v.Name, "hello.txt")
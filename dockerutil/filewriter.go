@@ -7,9 +7,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/client"
 	"go.uber.org/zap"
 )
 
@@ -18,30 +16,32 @@ import (
 type FileWriter struct {
 	log *zap.Logger
 
-	cli *client.Client
+	rt ContainerRuntime
 
 	testName string
 }
 
 // NewFileWriter returns a new FileWriter.
-func NewFileWriter(log *zap.Logger, cli *client.Client, testName string) *FileWriter {
-	return &FileWriter{log: log, cli: cli, testName: testName}
+func NewFileWriter(log *zap.Logger, rt ContainerRuntime, testName string) *FileWriter {
+	return &FileWriter{log: log, rt: rt, testName: testName}
 }
 
 // WriteFile writes the single file containing content, at relPath within the given volume.
 func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string, content []byte) error {
 	const mountPath = "/mnt/dockervolume"
 
-	if err := EnsureBusybox(ctx, w.cli); err != nil {
+	if err := EnsureBusybox(ctx, w.rt); err != nil {
 		return err
 	}
 
 	containerName := fmt.Sprintf("%s-writefile-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
 
-	cc, err := w.cli.ContainerCreate(
+	rt := w.rt
+	id, err := rt.CreateContainer(
 		ctx,
+		containerName,
 		&container.Config{
-			Image: busyboxRef,
+			Image: BusyboxRef,
 
 			Entrypoint: []string{"sh", "-c"},
 			Cmd: []string{
@@ -63,8 +63,6 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 			AutoRemove: true,
 		},
 		nil, // No networking necessary.
-		nil,
-		containerName,
 	)
 	if err != nil {
 		return fmt.Errorf("creating container: %w", err)
@@ -77,10 +75,8 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 			return
 		}
 
-		if err := w.cli.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
-			Force: true,
-		}); err != nil {
-			w.log.Warn("Failed to remove file content container", zap.String("container_id", cc.ID), zap.Error(err))
+		if err := rt.RemoveContainer(ctx, id); err != nil {
+			w.log.Warn("Failed to remove file content container", zap.String("container_id", id), zap.Error(err))
 		}
 	}()
 
@@ -106,36 +102,23 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 		return fmt.Errorf("closing tar writer: %w", err)
 	}
 
-	if err := w.cli.CopyToContainer(
-		ctx,
-		cc.ID,
-		mountPath,
-		&buf,
-		types.CopyToContainerOptions{},
-	); err != nil {
+	if err := rt.CopyToContainer(ctx, id, mountPath, &buf); err != nil {
 		return fmt.Errorf("copying tar to container: %w", err)
 	}
 
-	if err := w.cli.ContainerStart(ctx, cc.ID, types.ContainerStartOptions{}); err != nil {
+	if err := rt.StartContainer(ctx, id); err != nil {
 		return fmt.Errorf("starting write-file container: %w", err)
 	}
 
-	waitCh, errCh := w.cli.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case err := <-errCh:
-		return err
-	case res := <-waitCh:
+	exitCode, err := rt.WaitContainer(ctx, id)
+	if exitCode >= 0 {
 		autoRemoved = true
-
-		if res.Error != nil {
-			return fmt.Errorf("waiting for write-file container: %s", res.Error.Message)
-		}
-
-		if res.StatusCode != 0 {
-			return fmt.Errorf("chown on new file exited %d", res.StatusCode)
-		}
+	}
+	if err != nil {
+		return fmt.Errorf("waiting for write-file container: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("chown on new file exited %d", exitCode)
 	}
 
 	return nil
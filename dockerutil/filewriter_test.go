@@ -19,6 +19,7 @@ func TestFileWriter(t *testing.T) {
 	t.Parallel()
 
 	cli, network := interchaintest.DockerSetup(t)
+	rt := dockerutil.RuntimeFor(cli)
 
 	ctx := context.Background()
 	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
@@ -28,13 +29,13 @@ func TestFileWriter(t *testing.T) {
 
 	img := dockerutil.NewImage(
 		zaptest.NewLogger(t),
-		cli,
+		rt,
 		network,
 		t.Name(),
 		"busybox", "stable",
 	)
 
-	fw := dockerutil.NewFileWriter(zaptest.NewLogger(t), cli, t.Name())
+	fw := dockerutil.NewFileWriter(zaptest.NewLogger(t), rt, t.Name())
 
 	t.Run("top-level file", func(t *testing.T) {
 		require.NoError(t, fw.WriteFile(context.Background(), v.Name, "hello.txt", []byte("hello world")))
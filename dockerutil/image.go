@@ -1,30 +1,26 @@
 package dockerutil
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"io"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
-	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
 	"go.uber.org/zap"
 )
 
 // Image is a docker image.
 type Image struct {
-	log    *zap.Logger
-	client *client.Client
+	log *zap.Logger
+	rt  ContainerRuntime
 
 	// NOTE: it might make sense for Image to have an ibc.DockerImage field,
 	// but for now it is probably better to not have internal/dockerutil depend on ibc.
@@ -42,12 +38,12 @@ type Image struct {
 // Most arguments (except tag) must be non-zero values or this function panics.
 // If tag is absent, defaults to "latest".
 // Currently, only public docker images are supported.
-func NewImage(logger *zap.Logger, cli *client.Client, networkID string, testName string, repository, tag string) *Image {
+func NewImage(logger *zap.Logger, rt ContainerRuntime, networkID string, testName string, repository, tag string) *Image {
 	if logger == nil {
 		panic(errors.New("nil logger"))
 	}
-	if cli == nil {
-		panic(errors.New("client cannot be nil"))
+	if rt == nil {
+		panic(errors.New("runtime cannot be nil"))
 	}
 	if networkID == "" {
 		panic(errors.New("networkID cannot be empty"))
@@ -63,7 +59,7 @@ func NewImage(logger *zap.Logger, cli *client.Client, networkID string, testName
 	}
 
 	i := &Image{
-		client:     cli,
+		rt:         rt,
 		networkID:  networkID,
 		repository: repository,
 		tag:        tag,
@@ -96,6 +92,9 @@ type ContainerOptions struct {
 
 	// working directory to launch cmd from
 	WorkingDir string
+
+	// CPU and memory limits of the container.
+	Resources ibc.ResourceLimits
 }
 
 // ContainerExecResult is a wrapper type that wraps an exit code and associated output from stderr & stdout, along with
@@ -110,17 +109,40 @@ type ContainerExecResult struct {
 //
 // Run blocks until the command completes. Thus, Run is not suitable for daemons or servers. Use Start instead.
 // A non-zero status code returns an error.
+//
+// Observers registered with ObserveExecs are notified once the command completes.
 func (image *Image) Run(ctx context.Context, cmd []string, opts ContainerOptions) ContainerExecResult {
+	ctx, span := tracing.Start(ctx, "docker.run",
+		tracing.AttrImage.String(image.imageRef()),
+		tracing.AttrCommand.StringSlice(cmd),
+	)
+
+	rec := ExecRecord{
+		TestName:  image.testName,
+		Command:   cmd,
+		StartedAt: time.Now(),
+	}
+	defer func() {
+		rec.FinishedAt = time.Now()
+		notifyExecObservers(rec)
+
+		span.SetAttributes(tracing.AttrContainer.String(rec.ContainerName))
+		tracing.End(span, rec.Result.Err)
+	}()
+
 	c, err := image.Start(ctx, cmd, opts)
 	if err != nil {
-		return ContainerExecResult{
+		rec.Result = ContainerExecResult{
 			Err:      err,
 			ExitCode: -1,
 			Stdout:   nil,
 			Stderr:   nil,
 		}
+		return rec.Result
 	}
-	return c.Wait(ctx, opts.LogTail)
+	rec.ContainerName = c.Name
+	rec.Result = c.Wait(ctx, opts.LogTail)
+	return rec.Result
 }
 
 func (image *Image) imageRef() string {
@@ -129,42 +151,74 @@ func (image *Image) imageRef() string {
 
 // EnsurePulled can only pull public images.
 func (image *Image) EnsurePulled(ctx context.Context) error {
-	ref := image.imageRef()
-	_, _, err := image.client.ImageInspectWithRaw(ctx, ref)
+	return ensureImage(ctx, image.rt, image.imageRef())
+}
+
+func init() {
+	ibc.EnsureImage = EnsureImage
+}
+
+// EnsureImage pulls the public image ref if it is not present locally.
+func EnsureImage(ctx context.Context, cli *client.Client, ref string) error {
+	return ensureImage(ctx, RuntimeFor(cli), ref)
+}
+
+func ensureImage(ctx context.Context, rt ContainerRuntime, ref string) error {
+	exists, err := rt.ImageExists(ctx, ref)
 	if err != nil {
-		rc, err := image.client.ImagePull(ctx, ref, types.ImagePullOptions{})
+		return fmt.Errorf("inspect image %s: %w", ref, err)
+	}
+	if exists {
+		return nil
+	}
+	if err := pullImage(ctx, rt, ref); err != nil {
+		return fmt.Errorf("pull image %s: %w", ref, err)
+	}
+	return nil
+}
+
+// PullImage pulls the public image ref, blocking until the pull completes.
+//
+// If OfflineImages is set, PullImage does not pull, and returns a *MissingImagesError if ref is not present locally.
+func PullImage(ctx context.Context, cli *client.Client, ref string) error {
+	return pullImage(ctx, RuntimeFor(cli), ref)
+}
+
+func pullImage(ctx context.Context, rt ContainerRuntime, ref string) (err error) {
+	ctx, span := tracing.Start(ctx, "docker.image.pull", tracing.AttrImage.String(ref))
+	defer func() { tracing.End(span, err) }()
+
+	if OfflineImages {
+		exists, err := rt.ImageExists(ctx, ref)
 		if err != nil {
-			return fmt.Errorf("pull image %s: %w", ref, err)
+			return err
+		}
+		if !exists {
+			return &MissingImagesError{Refs: []string{ref}}
 		}
-		_, _ = io.Copy(io.Discard, rc)
-		_ = rc.Close()
+		return nil
 	}
-	return nil
+	return rt.PullImage(ctx, ref)
 }
 
-func (image *Image) CreateContainer(ctx context.Context, containerName, hostName string, cmd []string, opts ContainerOptions) (string, error) {
+func (image *Image) CreateContainer(ctx context.Context, containerName, hostName string, cmd []string, opts ContainerOptions) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "docker.container.create",
+		tracing.AttrImage.String(image.imageRef()),
+		tracing.AttrContainer.String(containerName),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// Although this shouldn't happen because the name includes randomness, in reality there seems to intermittent
 	// chances of collisions.
 
-	containers, err := image.client.ContainerList(ctx, types.ContainerListOptions{
-		All:     true,
-		Filters: filters.NewArgs(filters.Arg("name", containerName)),
-	})
-	if err != nil {
-		return "", fmt.Errorf("unable to list containers: %w", err)
-	}
-
-	for _, c := range containers {
-		if err := image.client.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{
-			RemoveVolumes: true,
-			Force:         true,
-		}); err != nil {
-			return "", fmt.Errorf("unable to remove container %s: %w", containerName, err)
-		}
+	rt := image.rt
+	if err := rt.RemoveContainer(ctx, containerName); err != nil {
+		return "", fmt.Errorf("unable to remove container %s: %w", containerName, err)
 	}
 
-	cc, err := image.client.ContainerCreate(
+	return rt.CreateContainer(
 		ctx,
+		containerName,
 		&container.Config{
 			Image: image.imageRef(),
 
@@ -184,19 +238,14 @@ func (image *Image) CreateContainer(ctx context.Context, containerName, hostName
 			PublishAllPorts: true, // Because we publish all ports, no need to expose specific ports.
 			AutoRemove:      false,
 			Mounts:          opts.Mounts,
+			Resources:       HostResources(opts.Resources),
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
 				image.networkID: {},
 			},
 		},
-		nil,
-		containerName,
 	)
-	if err != nil {
-		return "", err
-	}
-	return cc.ID, nil
 }
 
 // Start pulls the image if not present, creates a container, and runs it.
@@ -226,7 +275,7 @@ func (image *Image) Start(ctx context.Context, cmd []string, opts ContainerOptio
 
 	logger.Info("About to start container")
 
-	err = StartContainer(ctx, image.client, cID)
+	err = StartContainer(ctx, image.rt, cID)
 	if err != nil {
 		return nil, image.WrapErr(fmt.Errorf("start container %s: %w", containerName, err))
 	}
@@ -260,50 +309,11 @@ type Container struct {
 // Wait implicitly calls Stop.
 // If logTail is non-zero, the stdout and stderr logs will be truncated at the end to that number of lines.
 func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResult {
-	waitCh, errCh := c.image.client.ContainerWait(ctx, c.containerID, container.WaitConditionNotRunning)
-	var exitCode int
-	select {
-	case <-ctx.Done():
-		return ContainerExecResult{
-			Err:      ctx.Err(),
-			ExitCode: 1,
-			Stdout:   nil,
-			Stderr:   nil,
-		}
-	case err := <-errCh:
-		return ContainerExecResult{
-			Err:      err,
-			ExitCode: 1,
-			Stdout:   nil,
-			Stderr:   nil,
-		}
-	case res := <-waitCh:
-		exitCode = int(res.StatusCode)
-		if res.Error != nil {
-			return ContainerExecResult{
-				Err:      errors.New(res.Error.Message),
-				ExitCode: exitCode,
-				Stdout:   nil,
-				Stderr:   nil,
-			}
-		}
-	}
-
-	var (
-		stdoutBuf = new(bytes.Buffer)
-		stderrBuf = new(bytes.Buffer)
-	)
-
-	logOpts := types.ContainerLogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-	}
-	if logTail != 0 {
-		logOpts.Tail = strconv.FormatUint(logTail, 10)
-	}
-
-	rc, err := c.image.client.ContainerLogs(ctx, c.containerID, logOpts)
+	exitCode, err := c.image.rt.WaitContainer(ctx, c.containerID)
 	if err != nil {
+		if exitCode < 0 {
+			exitCode = 1
+		}
 		return ContainerExecResult{
 			Err:      err,
 			ExitCode: exitCode,
@@ -311,10 +321,8 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 			Stderr:   nil,
 		}
 	}
-	defer func() { _ = rc.Close() }()
 
-	// Logs are multiplexed into one stream; see docs for ContainerLogs.
-	_, err = stdcopy.StdCopy(stdoutBuf, stderrBuf, rc)
+	stdout, stderr, err := c.image.rt.ContainerLogs(ctx, c.containerID, int(logTail))
 	if err != nil {
 		return ContainerExecResult{
 			Err:      err,
@@ -323,7 +331,6 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 			Stderr:   nil,
 		}
 	}
-	_ = rc.Close()
 
 	err = c.Stop(10 * time.Second)
 	if err != nil {
@@ -331,7 +338,7 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 	}
 
 	if exitCode != 0 {
-		out := strings.Join([]string{stdoutBuf.String(), stderrBuf.String()}, " ")
+		out := strings.Join([]string{string(stdout), string(stderr)}, " ")
 		return ContainerExecResult{
 			Err:      fmt.Errorf("exit code %d: %s", exitCode, out),
 			ExitCode: exitCode,
@@ -343,8 +350,8 @@ func (c *Container) Wait(ctx context.Context, logTail uint64) ContainerExecResul
 	return ContainerExecResult{
 		Err:      nil,
 		ExitCode: exitCode,
-		Stdout:   stdoutBuf.Bytes(),
-		Stderr:   stderrBuf.Bytes(),
+		Stdout:   stdout,
+		Stderr:   stderr,
 	}
 }
 
@@ -354,23 +361,17 @@ func (c *Container) Stop(timeout time.Duration) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout*2)
 	defer cancel()
 
-	var stopOptions container.StopOptions
-	timeoutRound := int(timeout.Round(time.Second))
-	stopOptions.Timeout = &timeoutRound
-	err := c.image.client.ContainerStop(ctx, c.containerID, stopOptions)
+	rt := c.image.rt
+	err := rt.StopContainer(ctx, c.containerID, timeout)
 	if err != nil {
-		// Only return the error if it didn't match an already stopped, or a missing container.
-		if !(errdefs.IsNotModified(err) || errdefs.IsNotFound(err)) {
+		// Only return the error if it didn't match a missing container.
+		if !errdefs.IsNotFound(err) {
 			return c.image.WrapErr(fmt.Errorf("stop container %s: %w", c.Name, err))
 		}
 	}
 
-	// RemoveContainerOptions duplicates (*dockertest.Resource).Prune.
-	err = c.image.client.ContainerRemove(ctx, c.containerID, types.ContainerRemoveOptions{
-		Force:         true,
-		RemoveVolumes: true,
-	})
-	if err != nil && !errdefs.IsNotFound(err) {
+	err = rt.RemoveContainer(ctx, c.containerID)
+	if err != nil {
 		return c.image.WrapErr(fmt.Errorf("remove container %s: %w", c.Name, err))
 	}
 
@@ -10,7 +10,6 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/client"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -27,20 +26,21 @@ func TestNewImage(t *testing.T) {
 	t.Parallel()
 
 	cl, networkID := DockerSetup(t)
+	rt := RuntimeFor(cl)
 
 	for _, tt := range []struct {
-		Client     *client.Client
+		Runtime    ContainerRuntime
 		NetworkID  string
 		Repository string
 		TestName   string
 	}{
 		{nil, networkID, "repo", t.Name()},
-		{cl, "", "repo", t.Name()},
-		{cl, networkID, "", t.Name()},
-		{cl, networkID, "repo", ""},
+		{rt, "", "repo", t.Name()},
+		{rt, networkID, "", t.Name()},
+		{rt, networkID, "repo", ""},
 	} {
 		require.Panics(t, func() {
-			NewImage(zap.NewNop(), tt.Client, tt.NetworkID, tt.TestName, tt.Repository, "")
+			NewImage(zap.NewNop(), tt.Runtime, tt.NetworkID, tt.TestName, tt.Repository, "")
 		}, tt)
 	}
 }
@@ -53,7 +53,7 @@ func TestImage_Run(t *testing.T) {
 
 	ctx := context.Background()
 	client, networkID := DockerSetup(t)
-	image := NewImage(zap.NewNop(), client, networkID, t.Name(), testDockerImage, testDockerTag)
+	image := NewImage(zap.NewNop(), RuntimeFor(client), networkID, t.Name(), testDockerImage, testDockerTag)
 
 	t.Run("happy path", func(t *testing.T) {
 		res := image.Run(ctx, []string{"echo", "-n", "hello"}, ContainerOptions{})
@@ -135,7 +135,7 @@ func TestContainer(t *testing.T) {
 
 	ctx := context.Background()
 	cl, networkID := DockerSetup(t)
-	image := NewImage(zap.NewNop(), cl, networkID, t.Name(), testDockerImage, testDockerTag)
+	image := NewImage(zap.NewNop(), RuntimeFor(cl), networkID, t.Name(), testDockerImage, testDockerTag)
 
 	t.Run("wait", func(t *testing.T) {
 		c, err := image.Start(ctx, []string{"echo", "-n", "started"}, ContainerOptions{})
@@ -151,7 +151,7 @@ func TestContainer(t *testing.T) {
 		require.Equal(t, "started", string(stdout))
 		require.Empty(t, stderr)
 
-		containers, err := image.client.ContainerList(ctx, types.ContainerListOptions{
+		containers, err := cl.ContainerList(ctx, types.ContainerListOptions{
 			All:     true,
 			Filters: filters.NewArgs(filters.Arg("name", c.Name)),
 		})
@@ -167,7 +167,7 @@ func TestContainer(t *testing.T) {
 		require.NoError(t, c.Stop(10*time.Second))
 		require.NoError(t, c.Stop(10*time.Second)) // assert idempotent
 
-		containers, err := image.client.ContainerList(ctx, types.ContainerListOptions{
+		containers, err := cl.ContainerList(ctx, types.ContainerListOptions{
 			All:     true,
 			Filters: filters.NewArgs(filters.Arg("name", c.Name)),
 		})
@@ -0,0 +1,208 @@
+package dockerutil
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// OfflineImages disables image pulls.
+// Images that are not present locally cause an error instead of a pull attempt,
+// so tests on hosts without network access fail fast rather than waiting on registry timeouts.
+//
+// The value is false by default, but can be initialized to true by setting the
+// environment variable ICTEST_OFFLINE_IMAGES to a non-empty value.
+var OfflineImages = os.Getenv("ICTEST_OFFLINE_IMAGES") != ""
+
+// MissingImagesError lists images that are required but not present locally.
+type MissingImagesError struct {
+	Refs []string
+}
+
+func (e *MissingImagesError) Error() string {
+	return fmt.Sprintf(
+		"%d image(s) not present locally: %s; pull them, or import an exported tarball with ImageManager.Import",
+		len(e.Refs), strings.Join(e.Refs, ", "),
+	)
+}
+
+// ImageManager checks, pulls, exports and imports the set of images used by a test suite.
+type ImageManager struct {
+	log *zap.Logger
+	rt  ContainerRuntime
+
+	// Concurrency is the maximum number of simultaneous pulls.
+	Concurrency int
+}
+
+// NewImageManager returns an ImageManager using rt.
+func NewImageManager(log *zap.Logger, rt ContainerRuntime) *ImageManager {
+	return &ImageManager{log: log, rt: rt, Concurrency: 4}
+}
+
+// UniqueImageRefs returns the non-empty refs, sorted and without duplicates.
+func UniqueImageRefs(refs []string) []string {
+	seen := make(map[string]bool, len(refs))
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if ref == "" || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		out = append(out, ref)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// isLocalImage reports whether ref is tagged "local",
+// the convention for images built on the host that cannot be pulled.
+func isLocalImage(ref string) bool {
+	return strings.HasSuffix(ref, ":local")
+}
+
+// Missing returns the refs that are not present locally.
+func (m *ImageManager) Missing(ctx context.Context, refs []string) ([]string, error) {
+	rt := m.rt
+	var missing []string
+	for _, ref := range UniqueImageRefs(refs) {
+		exists, err := rt.ImageExists(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("inspect image %s: %w", ref, err)
+		}
+		if !exists {
+			missing = append(missing, ref)
+		}
+	}
+	return missing, nil
+}
+
+// EnsureLocal returns a *MissingImagesError listing every ref not present locally.
+func (m *ImageManager) EnsureLocal(ctx context.Context, refs []string) error {
+	missing, err := m.Missing(ctx, refs)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return &MissingImagesError{Refs: missing}
+	}
+	return nil
+}
+
+// Pull pulls, in parallel, the refs that are not present locally.
+// Every pull is attempted; the returned error describes all failures.
+// Images tagged "local" are never pulled and are reported as missing.
+func (m *ImageManager) Pull(ctx context.Context, refs []string) error {
+	missing, err := m.Missing(ctx, refs)
+	if err != nil {
+		return err
+	}
+
+	var (
+		mu       sync.Mutex
+		unpulled []string
+		errs     []error
+	)
+
+	var eg errgroup.Group
+	if m.Concurrency > 0 {
+		eg.SetLimit(m.Concurrency)
+	}
+	for _, ref := range missing {
+		ref := ref
+		if isLocalImage(ref) {
+			unpulled = append(unpulled, ref)
+			continue
+		}
+		eg.Go(func() error {
+			m.log.Info("Pulling image", zap.String("image", ref))
+			if err := pullImage(ctx, m.rt, ref); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("pull image %s: %w", ref, err))
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	if len(unpulled) > 0 {
+		errs = append(errs, &MissingImagesError{Refs: unpulled})
+	}
+	return errors.Join(errs...)
+}
+
+// Prepare makes refs available locally.
+// It pulls any missing images, or, if OfflineImages is set, reports them with a *MissingImagesError.
+func (m *ImageManager) Prepare(ctx context.Context, refs []string) error {
+	if OfflineImages {
+		return m.EnsureLocal(ctx, refs)
+	}
+	return m.Pull(ctx, refs)
+}
+
+// Export writes refs to a tarball at path, for Import on another host.
+// The tarball is gzip-compressed if path ends in ".gz" or ".tgz".
+func (m *ImageManager) Export(ctx context.Context, path string, refs []string) (err error) {
+	refs = UniqueImageRefs(refs)
+	if err := m.EnsureLocal(ctx, refs); err != nil {
+		return err
+	}
+
+	rc, err := m.rt.SaveImages(ctx, refs)
+	if err != nil {
+		return fmt.Errorf("save images: %w", err)
+	}
+	defer rc.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	var w io.Writer = f
+	if strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".tgz") {
+		gz := gzip.NewWriter(f)
+		defer func() {
+			if cerr := gz.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		w = gz
+	}
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	m.log.Info("Exported images", zap.String("path", path), zap.Strings("images", refs))
+	return nil
+}
+
+// Import loads the images of a tarball written by Export, or by `docker save`.
+func (m *ImageManager) Import(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := m.rt.LoadImages(ctx, f); err != nil {
+		return fmt.Errorf("load images from %s: %w", path, err)
+	}
+	m.log.Info("Imported images", zap.String("path", path))
+	return nil
+}
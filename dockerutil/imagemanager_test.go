@@ -0,0 +1,25 @@
+package dockerutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniqueImageRefs(t *testing.T) {
+	require.Equal(t,
+		[]string{"busybox:stable", "ghcr.io/cosmos/relayer:v2.5.2"},
+		UniqueImageRefs([]string{"ghcr.io/cosmos/relayer:v2.5.2", "", "busybox:stable", "ghcr.io/cosmos/relayer:v2.5.2"}),
+	)
+	require.Empty(t, UniqueImageRefs(nil))
+}
+
+func TestMissingImagesError(t *testing.T) {
+	err := &MissingImagesError{Refs: []string{"gaia:v15", "osmosis:local"}}
+	require.EqualError(t, err, "2 image(s) not present locally: gaia:v15, osmosis:local; pull them, or import an exported tarball with ImageManager.Import")
+}
+
+func TestIsLocalImage(t *testing.T) {
+	require.True(t, isLocalImage("hyperspace:local"))
+	require.False(t, isLocalImage("ghcr.io/strangelove-ventures/heighliner/gaia:v15.0.0"))
+}
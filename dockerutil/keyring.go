@@ -13,13 +13,12 @@ import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
-	"github.com/docker/docker/client"
 )
 
 // NewLocalKeyringFromDockerContainer copies the contents of the given container directory into a specified local directory.
 // This allows test hosts to sign transactions on behalf of test users.
-func NewLocalKeyringFromDockerContainer(ctx context.Context, dc *client.Client, localDirectory, containerKeyringDir, containerId string) (keyring.Keyring, error) {
-	reader, _, err := dc.CopyFromContainer(ctx, containerId, containerKeyringDir)
+func NewLocalKeyringFromDockerContainer(ctx context.Context, rt ContainerRuntime, localDirectory, containerKeyringDir, containerId string) (keyring.Keyring, error) {
+	reader, err := rt.CopyFromContainer(ctx, containerId, containerKeyringDir)
 	if err != nil {
 		return nil, err
 	}
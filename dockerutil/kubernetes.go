@@ -0,0 +1,1099 @@
+package dockerutil
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"go.uber.org/zap"
+)
+
+// KubernetesRuntime is the ContainerRuntime for a Kubernetes cluster, driven through kubectl.
+// Every object of a test lives in a namespace of its own, created by KubernetesSetup.
+//
+// Docker concepts map to Kubernetes objects as follows:
+//   - A container is a pod, created when the container is started and deleted when it is stopped,
+//     so that it can be started again like a Docker container.
+//   - Containers exposing ports get a service, and are reachable from containers started later
+//     by their host and container names, which are added to /etc/hosts.
+//   - Published host ports are forwarded to the pod with `kubectl port-forward`.
+//   - A volume is a persistent volume claim.
+//   - A network is the namespace.
+//
+// Each pod runs a busybox init container that provides tar, env and sh to the main container,
+// and keeps its stderr apart from its stdout in the pod logs.
+//
+// Host bind mounts, pausing containers, and building, saving or loading images are not supported.
+// Nodes pull images themselves, so images built locally must be loaded into the cluster,
+// e.g. with `kind load docker-image`.
+type KubernetesRuntime struct {
+	cli       *client.Client
+	kubectl   string
+	namespace string
+
+	// StorageClass of the persistent volume claims backing volumes.
+	// The cluster's default storage class is used if empty.
+	StorageClass string
+	// VolumeSize is the storage requested by each volume.
+	VolumeSize string
+
+	mu            sync.Mutex
+	pods          map[string]*kubePod
+	hosts         map[string]string // Host and container names, to the cluster IPs of their services.
+	networkLabels map[string]string // Labels of the namespace, once created by CreateNetwork.
+}
+
+// kubePod is a container of a KubernetesRuntime.
+type kubePod struct {
+	name    string
+	cfg     *container.Config
+	hostCfg *container.HostConfig
+	volumes []kubeVolumeMount
+
+	clusterIP string
+
+	// Tar streams copied into the container before it was started, extracted by the init container.
+	staged []kubeStagedCopy
+
+	running  bool
+	forward  *exec.Cmd
+	ports    nat.PortMap
+	exited   *kubeContainerState // Last state, kept once the pod is stopped.
+	lastLogs []byte
+}
+
+type kubeStagedCopy struct {
+	dir     string
+	content []byte
+}
+
+// Defaults of KubernetesRuntime's settings.
+const (
+	DefaultKubernetesVolumeSize = "1Gi"
+
+	// kubeToolsDir holds the busybox binary provided by the init container.
+	kubeToolsDir = "/.interchaintest"
+	kubeBusybox  = kubeToolsDir + "/busybox"
+	kubeReady    = kubeToolsDir + "/ready"
+
+	kubeToolsContainer = "tools"
+	kubeMainContainer  = "main"
+
+	// kubePodLabel selects the pod of a container, for its service.
+	kubePodLabel = LabelPrefix + "pod"
+
+	// kubeStderrMark prefixes the lines that the main container writes to stderr in the pod logs.
+	kubeStderrMark = '\x1e'
+)
+
+// kubeWrapper runs the main container's command,
+// marking its stderr lines and forwarding termination signals.
+var kubeWrapper = `B=` + kubeBusybox + `
+$B mkfifo ` + kubeToolsDir + `/stderr
+$B sed 's/^/` + string(kubeStderrMark) + `/' <` + kubeToolsDir + `/stderr >&2 &
+"$@" 2>` + kubeToolsDir + `/stderr &
+pid=$!
+trap '$B kill -TERM $pid' TERM INT
+while :; do
+	wait $pid
+	rc=$?
+	$B kill -0 $pid 2>/dev/null || break
+done
+wait
+exit $rc`
+
+// kubeToolsScript copies busybox for the main container and adds the names of earlier containers to /etc/hosts.
+// If content was copied into the container before it started, it waits for the copies to be extracted.
+const kubeToolsScript = `cp /bin/busybox ` + kubeBusybox + ` &&
+printf '%s' "$ICTEST_HOSTS" >>/etc/hosts &&
+if [ -n "$ICTEST_WAIT" ]; then while [ ! -e ` + kubeReady + ` ]; do sleep 0.1; done; fi`
+
+// NewKubernetesRuntime returns a KubernetesRuntime for the namespace,
+// using the kubectl found by KubectlPath and its current context.
+//
+// The storage class and size of volumes can be initialized by setting the environment variables
+// ICTEST_K8S_STORAGE_CLASS and ICTEST_K8S_VOLUME_SIZE.
+func NewKubernetesRuntime(namespace string) (*KubernetesRuntime, error) {
+	kubectl, err := KubectlPath()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only a handle to find the runtime with RuntimeFor; there is no Docker daemon behind it.
+	cli, err := client.NewClientWithOpts(client.WithHost("unix:///interchaintest/kubernetes.sock"))
+	if err != nil {
+		return nil, fmt.Errorf("create kubernetes client handle: %w", err)
+	}
+
+	size := os.Getenv("ICTEST_K8S_VOLUME_SIZE")
+	if size == "" {
+		size = DefaultKubernetesVolumeSize
+	}
+	return &KubernetesRuntime{
+		cli:          cli,
+		kubectl:      kubectl,
+		namespace:    namespace,
+		StorageClass: os.Getenv("ICTEST_K8S_STORAGE_CLASS"),
+		VolumeSize:   size,
+		pods:         make(map[string]*kubePod),
+		hosts:        make(map[string]string),
+	}, nil
+}
+
+// KubernetesSetup returns a client handle and a namespace of the cluster of kubectl's current context,
+// associated with t, to be used in place of the client and network ID returned by DockerSetup.
+// DockerSetup returns them itself when ContainerRuntimeName is "kubernetes".
+//
+// The namespace is deleted when t completes,
+// unless KEEP_CONTAINERS is set or volumes are kept on failure.
+//
+// If any part of the setup fails, KubernetesSetup panics because the test cannot continue.
+func KubernetesSetup(t DockerSetupTestingT) (*client.Client, string) {
+	t.Helper()
+
+	namespace := kubeName(fmt.Sprintf("%s-%s-%s", ICTDockerPrefix, RandLowerCaseLetterString(6), t.Name()))
+	rt, err := NewKubernetesRuntime(namespace)
+	if err != nil {
+		panic(err)
+	}
+	cli := rt.Client()
+	runtimes.Store(cli, rt)
+	// Registered first so that it runs after the namespace is deleted.
+	t.Cleanup(func() { forgetClient(cli) })
+	t.Cleanup(rt.cleanup(t))
+
+	if _, err := rt.CreateNetwork(context.TODO(), namespace, map[string]string{CleanupLabel: t.Name()}); err != nil {
+		panic(fmt.Errorf("failed to create kubernetes namespace: %v", err))
+	}
+	return cli, namespace
+}
+
+// cleanup collects artifacts and shows the logs of the containers of t as DockerCleanup does, then deletes the namespace.
+func (r *KubernetesRuntime) cleanup(t DockerSetupTestingT) func() {
+	return func() {
+		showContainerLogs := os.Getenv("SHOW_CONTAINER_LOGS")
+		keepContainers := os.Getenv("KEEP_CONTAINERS") != ""
+		logTail := 50
+		if n, err := strconv.Atoi(os.Getenv("CONTAINER_LOG_TAIL")); err == nil {
+			logTail = n
+		}
+
+		ctx := context.TODO()
+
+		// Collect artifacts before any containers or volumes are removed.
+		artifactFiles, artifactReporters := takeArtifactRegistrations(t.Name())
+		if t.Failed() && CollectArtifactsOnFailure {
+			collectArtifacts(ctx, t, r, artifactFiles, artifactReporters)
+		}
+
+		r.mu.Lock()
+		pods := make([]*kubePod, 0, len(r.pods))
+		for _, p := range r.pods {
+			pods = append(pods, p)
+		}
+		r.mu.Unlock()
+		sort.Slice(pods, func(i, j int) bool { return pods[i].name < pods[j].name })
+
+		for _, p := range pods {
+			if (t.Failed() && showContainerLogs == "") || showContainerLogs == "always" {
+				stdout, stderr, err := r.ContainerLogs(ctx, p.name, logTail)
+				if err == nil {
+					t.Logf("\n\nContainer logs - {%s}\n%s%s", p.name, stdout, stderr)
+				}
+			}
+			r.stopForwarding(p)
+			DefaultPortAllocator.Release(allocatedPorts(nil, p.hostCfg.PortBindings)...)
+		}
+
+		if keepContainers || (KeepVolumesOnFailure && t.Failed()) {
+			t.Logf("Keeping kubernetes namespace %s", r.namespace)
+			return
+		}
+		if _, err := r.run(ctx, nil, "delete", "namespace", r.namespace, "--wait=false"); err != nil {
+			t.Logf("Failed to delete kubernetes namespace %s: %v", r.namespace, err)
+		}
+	}
+}
+
+// KubectlPath returns the kubectl binary named by ICTEST_KUBECTL, or else found in PATH.
+func KubectlPath() (string, error) {
+	name := os.Getenv("ICTEST_KUBECTL")
+	if name == "" {
+		name = "kubectl"
+	}
+	p, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("kubectl is required for the kubernetes runtime: %w", err)
+	}
+	return p, nil
+}
+
+// Client returns the handle passed in place of a Docker client to functions taking one.
+func (r *KubernetesRuntime) Client() *client.Client { return r.cli }
+
+// Namespace returns the namespace holding the objects of the runtime.
+func (r *KubernetesRuntime) Namespace() string { return r.namespace }
+
+func (r *KubernetesRuntime) Name() string { return RuntimeKubernetes }
+
+func (r *KubernetesRuntime) RunsOnHost() bool { return false }
+
+// run runs kubectl in the namespace of r and returns its stdout.
+func (r *KubernetesRuntime) run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.kubectl, append([]string{"--namespace", r.namespace}, args...)...)
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "(NotFound)") {
+			return stdout.Bytes(), errdefs.NotFound(fmt.Errorf("kubectl %s: %s", args[0], msg))
+		}
+		return stdout.Bytes(), fmt.Errorf("kubectl %s: %w: %s", args[0], err, msg)
+	}
+	return stdout.Bytes(), nil
+}
+
+// create creates the object obj, passing args to kubectl create.
+func (r *KubernetesRuntime) create(ctx context.Context, obj kubeObject, args ...string) ([]byte, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s %s: %w", obj.Kind, obj.Metadata.Name, err)
+	}
+	return r.run(ctx, bytes.NewReader(b), append([]string{"create", "-f", "-"}, args...)...)
+}
+
+// ImageExists reports true, because every node pulls the images of its pods.
+func (r *KubernetesRuntime) ImageExists(context.Context, string) (bool, error) { return true, nil }
+
+// PullImage does nothing, because every node pulls the images of its pods.
+func (r *KubernetesRuntime) PullImage(context.Context, string) error { return nil }
+
+func (r *KubernetesRuntime) SaveImages(context.Context, []string) (io.ReadCloser, error) {
+	return nil, errKubernetesUnsupported("saving images")
+}
+
+func (r *KubernetesRuntime) LoadImages(context.Context, io.Reader) error {
+	return errKubernetesUnsupported("loading images")
+}
+
+func (r *KubernetesRuntime) BuildImage(context.Context, io.Reader, types.ImageBuildOptions) (io.ReadCloser, error) {
+	return nil, errKubernetesUnsupported("building images")
+}
+
+func (r *KubernetesRuntime) BuildChain(context.Context, *zap.Logger, BuildOptions) error {
+	return errKubernetesUnsupported("building images")
+}
+
+func errKubernetesUnsupported(what string) error {
+	return errdefs.NotImplemented(fmt.Errorf("%s is not supported by the kubernetes runtime", what))
+}
+
+// CreateContainer records the container, to be created as a pod when it is started.
+// If it exposes ports, its service is created right away,
+// so that containers started before it can reach it.
+func (r *KubernetesRuntime) CreateContainer(ctx context.Context, name string, cfg *container.Config, hostCfg *container.HostConfig, _ *network.NetworkingConfig) (string, error) {
+	if hostCfg == nil {
+		hostCfg = &container.HostConfig{}
+	}
+	volumes, err := kubeVolumeMounts(hostCfg)
+	if err != nil {
+		return "", err
+	}
+
+	p := &kubePod{
+		name:    kubeName(name),
+		cfg:     cfg,
+		hostCfg: hostCfg,
+		volumes: volumes,
+	}
+
+	r.mu.Lock()
+	if _, ok := r.pods[p.name]; ok {
+		r.mu.Unlock()
+		return "", errdefs.Conflict(fmt.Errorf("container %s already exists", name))
+	}
+	r.pods[p.name] = p
+	r.mu.Unlock()
+
+	if len(cfg.ExposedPorts) > 0 {
+		out, err := r.create(ctx, kubeServiceManifest(p.name, cfg.ExposedPorts), "-o", "jsonpath={.spec.clusterIP}")
+		if err != nil {
+			r.mu.Lock()
+			delete(r.pods, p.name)
+			r.mu.Unlock()
+			return "", fmt.Errorf("create service of container %s: %w", name, err)
+		}
+
+		r.mu.Lock()
+		p.clusterIP = strings.TrimSpace(string(out))
+		r.hosts[name] = p.clusterIP
+		if cfg.Hostname != "" {
+			r.hosts[cfg.Hostname] = p.clusterIP
+		}
+		r.mu.Unlock()
+	}
+
+	return p.name, nil
+}
+
+func (r *KubernetesRuntime) pod(id string) (*kubePod, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.pods[kubeName(id)]
+	if !ok {
+		return nil, errdefs.NotFound(fmt.Errorf("no such container: %s", id))
+	}
+	return p, nil
+}
+
+// hostsFile returns the /etc/hosts entries of the containers with services.
+func (r *KubernetesRuntime) hostsFile() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.hosts))
+	for n := range r.hosts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, n := range names {
+		fmt.Fprintf(&b, "%s\t%s\n", r.hosts[n], n)
+	}
+	return b.String()
+}
+
+func (r *KubernetesRuntime) StartContainer(ctx context.Context, id string) error {
+	p, err := r.pod(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	staged := p.staged
+	p.staged = nil
+	r.mu.Unlock()
+
+	if _, err := r.create(ctx, kubePodManifest(p, r.hostsFile(), len(staged) > 0)); err != nil {
+		return fmt.Errorf("create pod %s: %w", p.name, err)
+	}
+
+	r.mu.Lock()
+	p.running = true
+	p.exited = nil
+	p.lastLogs = nil
+	r.mu.Unlock()
+
+	if len(staged) > 0 {
+		if _, err := r.waitPod(ctx, p.name, func(s kubePodStatus) bool {
+			return s.tools().Running != nil
+		}); err != nil {
+			return err
+		}
+		for _, c := range staged {
+			if _, err := r.run(ctx, bytes.NewReader(c.content), "exec", "-i", p.name, "-c", kubeToolsContainer, "--", "tar", "-x", "-C", c.dir); err != nil {
+				return fmt.Errorf("copy to container %s: %w", p.name, err)
+			}
+		}
+		if _, err := r.run(ctx, nil, "exec", p.name, "-c", kubeToolsContainer, "--", "touch", kubeReady); err != nil {
+			return fmt.Errorf("start container %s: %w", p.name, err)
+		}
+	}
+
+	s, err := r.waitPod(ctx, p.name, func(s kubePodStatus) bool {
+		return s.Status.Phase != "Pending"
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.main().Running != nil {
+		return r.forwardPorts(ctx, p)
+	}
+	return nil
+}
+
+// forwardPorts forwards the published host ports of p to its pod, until it is stopped.
+func (r *KubernetesRuntime) forwardPorts(ctx context.Context, p *kubePod) error {
+	var mappings []string
+	ports := make(nat.PortMap)
+	for port, bindings := range p.hostCfg.PortBindings {
+		if len(bindings) == 0 || bindings[0].HostPort == "" || port.Proto() != "tcp" {
+			continue
+		}
+		mappings = append(mappings, bindings[0].HostPort+":"+port.Port())
+		ports[port] = []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: bindings[0].HostPort}}
+	}
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	args := append([]string{"--namespace", r.namespace, "port-forward", "pod/" + p.name, "--address", "127.0.0.1"}, mappings...)
+	cmd := exec.Command(r.kubectl, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("forward ports of container %s: %w", p.name, err)
+	}
+
+	// kubectl prints a line per forwarded address once it listens.
+	ready := make(chan struct{})
+	go func() {
+		sc := bufio.NewScanner(stdout)
+		n := 0
+		for sc.Scan() {
+			if strings.HasPrefix(sc.Text(), "Forwarding from") {
+				if n++; n == len(mappings) {
+					close(ready)
+				}
+			}
+		}
+		_, _ = io.Copy(io.Discard, stdout)
+	}()
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	select {
+	case <-ready:
+	case err := <-exited:
+		return fmt.Errorf("forward ports of container %s: %v: %s", p.name, err, strings.TrimSpace(stderr.String()))
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	p.forward = cmd
+	p.ports = ports
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *KubernetesRuntime) stopForwarding(p *kubePod) {
+	r.mu.Lock()
+	cmd := p.forward
+	p.forward = nil
+	p.ports = nil
+	r.mu.Unlock()
+
+	if cmd != nil {
+		_ = cmd.Process.Kill()
+	}
+}
+
+// StopContainer deletes the pod of the container, keeping its state and logs for InspectContainer and ContainerLogs.
+func (r *KubernetesRuntime) StopContainer(ctx context.Context, id string, timeout time.Duration) error {
+	p, err := r.pod(id)
+	if err != nil {
+		return err
+	}
+	r.stopForwarding(p)
+
+	r.mu.Lock()
+	running := p.running
+	r.mu.Unlock()
+	if !running {
+		return nil
+	}
+
+	if err := r.capture(ctx, p); err != nil {
+		return err
+	}
+
+	grace := int(timeout.Round(time.Second) / time.Second)
+	if _, err := r.run(ctx, nil, "delete", "pod", p.name, "--ignore-not-found", "--wait=true", "--grace-period="+strconv.Itoa(grace)); err != nil {
+		return fmt.Errorf("delete pod %s: %w", p.name, err)
+	}
+
+	r.mu.Lock()
+	p.running = false
+	r.mu.Unlock()
+	return nil
+}
+
+// capture keeps the state and logs of the pod of p before it is deleted.
+func (r *KubernetesRuntime) capture(ctx context.Context, p *kubePod) error {
+	s, err := r.podStatus(ctx, p.name)
+	if errdefs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	logs, _ := r.run(ctx, nil, "logs", p.name, "-c", kubeMainContainer)
+
+	state := s.main()
+	if state.Terminated == nil {
+		// Deleting the pod terminates it.
+		now := time.Now().UTC().Format(time.RFC3339)
+		state.Terminated = &kubeTerminated{ExitCode: 137, FinishedAt: now}
+		if state.Running != nil {
+			state.Terminated.StartedAt = state.Running.StartedAt
+		}
+	}
+
+	r.mu.Lock()
+	p.exited = &state
+	p.lastLogs = logs
+	r.mu.Unlock()
+	return nil
+}
+
+// KillContainer deletes the pod of the container without a grace period,
+// keeping its state and logs as StopContainer does.
+func (r *KubernetesRuntime) KillContainer(ctx context.Context, id string) error {
+	if err := r.StopContainer(ctx, id, 0); err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// WaitContainer blocks until the main container of the pod terminates.
+// Containers created with AutoRemove are removed once they exit.
+func (r *KubernetesRuntime) WaitContainer(ctx context.Context, id string) (int, error) {
+	p, err := r.pod(id)
+	if err != nil {
+		return -1, err
+	}
+
+	r.mu.Lock()
+	exited := p.exited
+	r.mu.Unlock()
+	if exited != nil {
+		return exited.Terminated.ExitCode, nil
+	}
+
+	s, err := r.waitPod(ctx, p.name, func(s kubePodStatus) bool {
+		return s.main().Terminated != nil
+	})
+	if err != nil {
+		return -1, err
+	}
+	exitCode := s.main().Terminated.ExitCode
+
+	if p.hostCfg.AutoRemove {
+		if err := r.RemoveContainer(ctx, id); err != nil {
+			return exitCode, err
+		}
+	}
+	return exitCode, nil
+}
+
+func (r *KubernetesRuntime) RemoveContainer(ctx context.Context, id string) error {
+	p, err := r.pod(id)
+	if errdefs.IsNotFound(err) {
+		return nil
+	}
+	r.stopForwarding(p)
+
+	objects := []string{"pod/" + p.name}
+	if p.clusterIP != "" {
+		objects = append(objects, "service/"+p.name)
+	}
+	args := append([]string{"delete"}, objects...)
+	if _, err := r.run(ctx, nil, append(args, "--ignore-not-found", "--wait=false", "--grace-period=0", "--force")...); err != nil {
+		return fmt.Errorf("delete container %s: %w", p.name, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pods, p.name)
+	for n, ip := range r.hosts {
+		if p.clusterIP != "" && ip == p.clusterIP {
+			delete(r.hosts, n)
+		}
+	}
+	return nil
+}
+
+func (r *KubernetesRuntime) Exec(ctx context.Context, id string, cmd []string, env []string) ContainerExecResult {
+	p, err := r.pod(id)
+	if err != nil {
+		return ContainerExecResult{Err: err, ExitCode: -1}
+	}
+
+	args := []string{"exec", p.name, "-c", kubeMainContainer, "--"}
+	if len(env) > 0 {
+		args = append(append(args, kubeBusybox, "env"), env...)
+	}
+	args = append(args, cmd...)
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, r.kubectl, append([]string{"--namespace", r.namespace}, args...)...)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	err = c.Run()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return ContainerExecResult{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	case errors.As(err, &exitErr):
+		// kubectl exits with the exit code of the command.
+		return ContainerExecResult{
+			Err:      fmt.Errorf("exit code %d: %s", exitErr.ExitCode(), stderr.String()),
+			ExitCode: exitErr.ExitCode(),
+			Stdout:   stdout.Bytes(),
+			Stderr:   stderr.Bytes(),
+		}
+	default:
+		return ContainerExecResult{Err: fmt.Errorf("kubectl exec: %w", err), ExitCode: -1}
+	}
+}
+
+func (r *KubernetesRuntime) InspectContainer(ctx context.Context, id string) (types.ContainerJSON, error) {
+	p, err := r.pod(id)
+	if err != nil {
+		return types.ContainerJSON{}, err
+	}
+
+	r.mu.Lock()
+	running, exited, ports := p.running, p.exited, p.ports
+	r.mu.Unlock()
+
+	state := &types.ContainerState{Status: "created"}
+	switch {
+	case exited != nil:
+		setKubeState(state, *exited)
+	case running:
+		s, err := r.podStatus(ctx, p.name)
+		if err != nil {
+			return types.ContainerJSON{}, err
+		}
+		setKubeState(state, s.main())
+	}
+
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:    p.name,
+			Name:  "/" + p.name,
+			Args:  append(append([]string(nil), p.cfg.Entrypoint...), p.cfg.Cmd...),
+			Image: p.cfg.Image,
+			State: state,
+		},
+		Config: p.cfg,
+		NetworkSettings: &types.NetworkSettings{
+			NetworkSettingsBase: types.NetworkSettingsBase{Ports: ports},
+		},
+	}, nil
+}
+
+func setKubeState(state *types.ContainerState, s kubeContainerState) {
+	switch {
+	case s.Running != nil:
+		state.Status = "running"
+		state.Running = true
+		state.StartedAt = s.Running.StartedAt
+	case s.Terminated != nil:
+		state.Status = "exited"
+		state.ExitCode = s.Terminated.ExitCode
+		state.StartedAt = s.Terminated.StartedAt
+		state.FinishedAt = s.Terminated.FinishedAt
+	}
+}
+
+// ContainerLogs returns the logs of the main container.
+// The stderr of containers created without an entrypoint (using the image's own) is part of stdout.
+func (r *KubernetesRuntime) ContainerLogs(ctx context.Context, id string, tail int) ([]byte, []byte, error) {
+	p, err := r.pod(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.Lock()
+	running, logs := p.running, p.lastLogs
+	r.mu.Unlock()
+
+	if running {
+		args := []string{"logs", p.name, "-c", kubeMainContainer}
+		if tail > 0 {
+			args = append(args, "--tail", strconv.Itoa(tail))
+		}
+		if logs, err = r.run(ctx, nil, args...); err != nil {
+			return nil, nil, err
+		}
+	} else if tail > 0 {
+		logs = tailLines(logs, tail)
+	}
+
+	stdout, stderr := splitKubeLogs(logs)
+	return stdout, stderr, nil
+}
+
+// splitKubeLogs separates the stderr lines marked by the wrapper of the main container from its stdout.
+func splitKubeLogs(logs []byte) (stdout, stderr []byte) {
+	for len(logs) > 0 {
+		i := bytes.IndexByte(logs, kubeStderrMark)
+		if i < 0 {
+			stdout = append(stdout, logs...)
+			break
+		}
+		stdout = append(stdout, logs[:i]...)
+		logs = logs[i+1:]
+
+		j := bytes.IndexByte(logs, '\n')
+		if j < 0 {
+			stderr = append(stderr, logs...)
+			break
+		}
+		stderr = append(stderr, logs[:j+1]...)
+		logs = logs[j+1:]
+	}
+	return stdout, stderr
+}
+
+func tailLines(b []byte, n int) []byte {
+	lines := bytes.SplitAfter(b, []byte("\n"))
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return bytes.Join(lines, nil)
+}
+
+func (r *KubernetesRuntime) WriteLogs(ctx context.Context, id string, w io.Writer) error {
+	return writeSeparateLogs(ctx, r, id, w)
+}
+
+func (r *KubernetesRuntime) PauseContainer(context.Context, string) error {
+	return errKubernetesUnsupported("pausing containers")
+}
+
+func (r *KubernetesRuntime) UnpauseContainer(context.Context, string) error {
+	return errKubernetesUnsupported("unpausing containers")
+}
+
+// ListContainers returns the containers created through r, since the namespace of r belongs to a single test.
+func (r *KubernetesRuntime) ListContainers(_ context.Context, labels map[string]string) ([]types.Container, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []types.Container
+	for _, p := range r.pods {
+		if hasLabels(p.cfg.Labels, labels) {
+			out = append(out, types.Container{
+				ID:     p.name,
+				Names:  []string{"/" + p.name},
+				Image:  p.cfg.Image,
+				Labels: p.cfg.Labels,
+				Mounts: volumeMountPoints(p.hostCfg),
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// CreateVolume creates a persistent volume claim.
+func (r *KubernetesRuntime) CreateVolume(ctx context.Context, labels map[string]string) (string, error) {
+	name := kubeName(fmt.Sprintf("%s-vol-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5)))
+	if _, err := r.create(ctx, kubeVolumeManifest(name, labels, r.StorageClass, r.VolumeSize)); err != nil {
+		return "", fmt.Errorf("create persistent volume claim: %w", err)
+	}
+	return name, nil
+}
+
+func (r *KubernetesRuntime) RemoveVolume(ctx context.Context, name string) error {
+	_, err := r.run(ctx, nil, "delete", "persistentvolumeclaim", name, "--ignore-not-found", "--wait=false")
+	return err
+}
+
+// ListVolumes returns the persistent volume claims of the namespace carrying labels, as written to the cluster.
+func (r *KubernetesRuntime) ListVolumes(ctx context.Context, labels map[string]string) ([]*volume.Volume, error) {
+	var selector []string
+	for k, v := range kubeLabels(labels) {
+		selector = append(selector, k+"="+v)
+	}
+	sort.Strings(selector)
+
+	out, err := r.run(ctx, nil, "get", "persistentvolumeclaims", "-l", strings.Join(selector, ","), "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("list persistent volume claims: %w", err)
+	}
+	var list struct {
+		Items []struct {
+			Metadata kubeMeta `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("parse persistent volume claims: %w", err)
+	}
+	vs := make([]*volume.Volume, len(list.Items))
+	for i, item := range list.Items {
+		vs[i] = &volume.Volume{Name: item.Metadata.Name, Labels: item.Metadata.Labels}
+	}
+	return vs, nil
+}
+
+// CreateNetwork creates the namespace of r, unless it exists, and returns its name.
+// The name of the network is not used.
+func (r *KubernetesRuntime) CreateNetwork(ctx context.Context, _ string, labels map[string]string) (string, error) {
+	if _, err := r.run(ctx, nil, "get", "namespace", r.namespace); err == nil {
+		return r.namespace, nil
+	}
+	obj := kubeObject{
+		APIVersion: "v1",
+		Kind:       "Namespace",
+		Metadata:   kubeMeta{Name: r.namespace, Labels: kubeLabels(labels)},
+	}
+	if _, err := r.create(ctx, obj); err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	r.networkLabels = labels
+	r.mu.Unlock()
+	return r.namespace, nil
+}
+
+// RemoveNetwork does nothing; the namespace is deleted when the test completes.
+func (r *KubernetesRuntime) RemoveNetwork(context.Context, string) error { return nil }
+
+// ListNetworks returns the namespace of r if it was created through r with every label of labels.
+func (r *KubernetesRuntime) ListNetworks(_ context.Context, labels map[string]string) ([]types.NetworkResource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.networkLabels == nil || !hasLabels(r.networkLabels, labels) {
+		return nil, nil
+	}
+	return []types.NetworkResource{{ID: r.namespace, Name: r.namespace, Labels: r.networkLabels}}, nil
+}
+
+// CopyToContainer extracts content in the running container,
+// or stages it to be extracted when the container starts.
+// A container that is not running only keeps content copied to its volumes.
+func (r *KubernetesRuntime) CopyToContainer(ctx context.Context, id, dstDir string, content io.Reader) error {
+	p, err := r.pod(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	running := p.running
+	r.mu.Unlock()
+	if running {
+		_, err := r.run(ctx, content, "exec", "-i", p.name, "-c", kubeMainContainer, "--", kubeBusybox, "tar", "-x", "-C", dstDir)
+		return err
+	}
+
+	if !p.onVolume(dstDir) {
+		return errKubernetesUnsupported("copying outside of volumes into a container that is not running")
+	}
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	p.staged = append(p.staged, kubeStagedCopy{dir: dstDir, content: b})
+	r.mu.Unlock()
+	return nil
+}
+
+// CopyFromContainer returns a tar stream of srcPath in the running container.
+// For a container that is not running, the path must be on one of its volumes,
+// which are then read from a temporary pod.
+func (r *KubernetesRuntime) CopyFromContainer(ctx context.Context, id, srcPath string) (io.ReadCloser, error) {
+	p, err := r.pod(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tarArgs := []string{"tar", "-c", "-C", path.Dir(srcPath), path.Base(srcPath)}
+
+	r.mu.Lock()
+	running := p.running
+	r.mu.Unlock()
+	if running {
+		out, err := r.run(ctx, nil, append([]string{"exec", p.name, "-c", kubeMainContainer, "--", kubeBusybox}, tarArgs...)...)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(out)), nil
+	}
+
+	if !p.onVolume(srcPath) {
+		return nil, errKubernetesUnsupported("copying outside of volumes from a container that is not running")
+	}
+
+	// The volumes are mounted at the same paths in a busybox pod.
+	helper := &kubePod{
+		name: kubeName(fmt.Sprintf("%s-copy-%s", p.name[:min(len(p.name), 40)], RandLowerCaseLetterString(6))),
+		cfg: &container.Config{
+			Image:      BusyboxRef,
+			Entrypoint: []string{"sleep"},
+			Cmd:        []string{"3600"},
+			User:       GetRootUserString(),
+		},
+		hostCfg: &container.HostConfig{},
+		volumes: p.volumes,
+	}
+	if _, err := r.create(ctx, kubePodManifest(helper, "", false)); err != nil {
+		return nil, fmt.Errorf("create pod to copy from container %s: %w", p.name, err)
+	}
+	defer func() {
+		_, _ = r.run(context.Background(), nil, "delete", "pod", helper.name, "--ignore-not-found", "--wait=false", "--grace-period=0", "--force")
+	}()
+
+	if _, err := r.waitPod(ctx, helper.name, func(s kubePodStatus) bool {
+		return s.main().Running != nil
+	}); err != nil {
+		return nil, err
+	}
+	out, err := r.run(ctx, nil, append([]string{"exec", helper.name, "-c", kubeMainContainer, "--"}, tarArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(out)), nil
+}
+
+// Stats samples the CPU and memory usage of the container from the cluster's metrics server.
+// Other counters are not reported.
+func (r *KubernetesRuntime) Stats(ctx context.Context, id string) (ContainerStats, error) {
+	p, err := r.pod(id)
+	if err != nil {
+		return ContainerStats{}, err
+	}
+	out, err := r.run(ctx, nil, "top", "pod", p.name, "--containers", "--no-headers")
+	if err != nil {
+		return ContainerStats{}, fmt.Errorf("stats require a metrics server: %w", err)
+	}
+	s, err := parseKubeTop(out)
+	if err != nil {
+		return ContainerStats{}, err
+	}
+	s.Name = p.name
+	s.MemoryLimit = uint64(p.hostCfg.Memory)
+	return s, nil
+}
+
+// parseKubeTop parses the usage of the main container in the output of `kubectl top pod --containers --no-headers`.
+func parseKubeTop(out []byte) (ContainerStats, error) {
+	for _, line := range strings.Split(string(out), "\n") {
+		f := strings.Fields(line)
+		if len(f) != 4 || f[1] != kubeMainContainer {
+			continue
+		}
+		cpu, err := strconv.ParseFloat(strings.TrimSuffix(f[2], "m"), 64)
+		if err != nil {
+			return ContainerStats{}, fmt.Errorf("parse cpu %q: %w", f[2], err)
+		}
+		if !strings.HasSuffix(f[2], "m") {
+			cpu *= 1000
+		}
+		mem, err := parseKubeQuantity(f[3])
+		if err != nil {
+			return ContainerStats{}, fmt.Errorf("parse memory %q: %w", f[3], err)
+		}
+		return ContainerStats{
+			When:        time.Now(),
+			CPUPercent:  cpu / 10,
+			MemoryUsage: mem,
+		}, nil
+	}
+	return ContainerStats{}, fmt.Errorf("no usage of container %s in %q", kubeMainContainer, out)
+}
+
+func parseKubeQuantity(q string) (uint64, error) {
+	for i, suffix := range []string{"Ki", "Mi", "Gi", "Ti"} {
+		if n, ok := strings.CutSuffix(q, suffix); ok {
+			v, err := strconv.ParseUint(n, 10, 64)
+			return v << (10 * (i + 1)), err
+		}
+	}
+	return strconv.ParseUint(q, 10, 64)
+}
+
+// onVolume reports whether dst is a path on one of the volumes of p.
+func (p *kubePod) onVolume(dst string) bool {
+	dst = path.Clean(dst)
+	for _, v := range p.volumes {
+		if dst == v.MountPath || strings.HasPrefix(dst, strings.TrimSuffix(v.MountPath, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// podStatus returns the status of the pod named name.
+func (r *KubernetesRuntime) podStatus(ctx context.Context, name string) (kubePodStatus, error) {
+	out, err := r.run(ctx, nil, "get", "pod", name, "-o", "json")
+	if err != nil {
+		return kubePodStatus{}, err
+	}
+	var s kubePodStatus
+	if err := json.Unmarshal(out, &s); err != nil {
+		return kubePodStatus{}, fmt.Errorf("decode status of pod %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// waitPod polls the status of the pod named name until done reports true.
+// It fails early if an image of the pod cannot be pulled or the pod cannot be scheduled.
+func (r *KubernetesRuntime) waitPod(ctx context.Context, name string, done func(kubePodStatus) bool) (kubePodStatus, error) {
+	for {
+		s, err := r.podStatus(ctx, name)
+		if err != nil {
+			return s, err
+		}
+		if done(s) {
+			return s, nil
+		}
+		if err := s.failure(); err != nil {
+			return s, fmt.Errorf("pod %s: %w", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return s, fmt.Errorf("waiting for pod %s (%s): %w", name, s.Status.Phase, ctx.Err())
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+var invalidKubeNameRE = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// kubeName returns name as a valid name of a Kubernetes pod, service or namespace.
+// Names that are too long are shortened, keeping a hash of the full name.
+// kubeName returns valid names unchanged.
+func kubeName(name string) string {
+	n := strings.Trim(invalidKubeNameRE.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if n == "" || n[0] < 'a' || n[0] > 'z' {
+		n = "x-" + n
+	}
+	if len(n) > 63 {
+		sum := sha256.Sum256([]byte(name))
+		n = strings.TrimRight(n[:52], "-") + "-" + hex.EncodeToString(sum[:])[:10]
+	}
+	return strings.TrimRight(n, "-")
+}
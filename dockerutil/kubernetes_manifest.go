@@ -0,0 +1,410 @@
+package dockerutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+)
+
+// kubeObject is the subset of a Kubernetes object that KubernetesRuntime creates.
+type kubeObject struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Metadata   kubeMeta `json:"metadata"`
+	Spec       any      `json:"spec,omitempty"`
+}
+
+type kubeMeta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type kubePodSpec struct {
+	Hostname       string          `json:"hostname,omitempty"`
+	RestartPolicy  string          `json:"restartPolicy"`
+	InitContainers []kubeContainer `json:"initContainers,omitempty"`
+	Containers     []kubeContainer `json:"containers"`
+	Volumes        []kubeVolume    `json:"volumes,omitempty"`
+}
+
+type kubeContainer struct {
+	Name            string               `json:"name"`
+	Image           string               `json:"image"`
+	ImagePullPolicy string               `json:"imagePullPolicy,omitempty"`
+	Command         []string             `json:"command,omitempty"`
+	Args            []string             `json:"args,omitempty"`
+	WorkingDir      string               `json:"workingDir,omitempty"`
+	Env             []kubeEnvVar         `json:"env,omitempty"`
+	Ports           []kubeContainerPort  `json:"ports,omitempty"`
+	VolumeMounts    []kubeVolumeMount    `json:"volumeMounts,omitempty"`
+	Resources       *kubeResources       `json:"resources,omitempty"`
+	SecurityContext *kubeSecurityContext `json:"securityContext,omitempty"`
+}
+
+type kubeEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type kubeContainerPort struct {
+	ContainerPort int    `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+}
+
+type kubeVolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+
+	// claim is the persistent volume claim mounted, or empty for a memory-backed directory.
+	claim string
+}
+
+type kubeVolume struct {
+	Name                  string              `json:"name"`
+	PersistentVolumeClaim *kubeClaimSource    `json:"persistentVolumeClaim,omitempty"`
+	EmptyDir              *kubeEmptyDirSource `json:"emptyDir,omitempty"`
+}
+
+type kubeClaimSource struct {
+	ClaimName string `json:"claimName"`
+}
+
+type kubeEmptyDirSource struct {
+	Medium string `json:"medium,omitempty"`
+}
+
+type kubeResources struct {
+	Limits map[string]string `json:"limits,omitempty"`
+}
+
+type kubeSecurityContext struct {
+	RunAsUser  *int64 `json:"runAsUser,omitempty"`
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+}
+
+type kubeServiceSpec struct {
+	Selector map[string]string `json:"selector"`
+	Ports    []kubeServicePort `json:"ports"`
+}
+
+type kubeServicePort struct {
+	Name       string `json:"name"`
+	Port       int    `json:"port"`
+	TargetPort int    `json:"targetPort"`
+	Protocol   string `json:"protocol"`
+}
+
+type kubeClaimSpec struct {
+	AccessModes      []string      `json:"accessModes"`
+	StorageClassName *string       `json:"storageClassName,omitempty"`
+	Resources        kubeClaimSize `json:"resources"`
+}
+
+type kubeClaimSize struct {
+	Requests map[string]string `json:"requests"`
+}
+
+// kubePodStatus is the subset of a pod's status that KubernetesRuntime reads.
+type kubePodStatus struct {
+	Status struct {
+		Phase                 string                `json:"phase"`
+		Conditions            []kubePodCondition    `json:"conditions"`
+		InitContainerStatuses []kubeContainerStatus `json:"initContainerStatuses"`
+		ContainerStatuses     []kubeContainerStatus `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+type kubePodCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+type kubeContainerStatus struct {
+	Name  string             `json:"name"`
+	State kubeContainerState `json:"state"`
+}
+
+type kubeContainerState struct {
+	Waiting *struct {
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	} `json:"waiting"`
+	Running *struct {
+		StartedAt string `json:"startedAt"`
+	} `json:"running"`
+	Terminated *kubeTerminated `json:"terminated"`
+}
+
+type kubeTerminated struct {
+	ExitCode   int    `json:"exitCode"`
+	Reason     string `json:"reason"`
+	StartedAt  string `json:"startedAt"`
+	FinishedAt string `json:"finishedAt"`
+}
+
+// main returns the state of the main container.
+func (s kubePodStatus) main() kubeContainerState {
+	return findKubeState(s.Status.ContainerStatuses, kubeMainContainer)
+}
+
+// tools returns the state of the init container.
+func (s kubePodStatus) tools() kubeContainerState {
+	return findKubeState(s.Status.InitContainerStatuses, kubeToolsContainer)
+}
+
+func findKubeState(statuses []kubeContainerStatus, name string) kubeContainerState {
+	for _, c := range statuses {
+		if c.Name == name {
+			return c.State
+		}
+	}
+	return kubeContainerState{}
+}
+
+// failure returns why the pod can never run, if it cannot.
+func (s kubePodStatus) failure() error {
+	for _, c := range append(s.Status.InitContainerStatuses, s.Status.ContainerStatuses...) {
+		if w := c.State.Waiting; w != nil {
+			switch w.Reason {
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError":
+				return fmt.Errorf("container %s: %s: %s", c.Name, w.Reason, w.Message)
+			}
+		}
+		if t := c.State.Terminated; t != nil && c.Name == kubeToolsContainer && t.ExitCode != 0 {
+			return fmt.Errorf("init container exited %d: %s", t.ExitCode, t.Reason)
+		}
+	}
+	for _, c := range s.Status.Conditions {
+		if c.Type == "PodScheduled" && c.Status == "False" && c.Reason == "Unschedulable" {
+			return fmt.Errorf("unschedulable: %s", c.Message)
+		}
+	}
+	return nil
+}
+
+// kubeVolumeMounts returns the volumes of hostCfg as mounts of persistent volume claims or memory-backed directories.
+func kubeVolumeMounts(hostCfg *container.HostConfig) ([]kubeVolumeMount, error) {
+	var mounts []kubeVolumeMount
+	add := func(claim, target string, readOnly bool) {
+		mounts = append(mounts, kubeVolumeMount{
+			Name:      fmt.Sprintf("v%d", len(mounts)),
+			MountPath: target,
+			ReadOnly:  readOnly,
+			claim:     claim,
+		})
+	}
+
+	for _, b := range hostCfg.Binds {
+		parts := strings.Split(b, ":")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("invalid bind %q", b)
+		}
+		if strings.HasPrefix(parts[0], "/") || strings.HasPrefix(parts[0], ".") {
+			return nil, errKubernetesUnsupported(fmt.Sprintf("binding the host path %s", parts[0]))
+		}
+		add(parts[0], parts[1], len(parts) > 2 && strings.Contains(parts[2], "ro"))
+	}
+
+	for _, m := range hostCfg.Mounts {
+		switch m.Type {
+		case mount.TypeVolume:
+			add(m.Source, m.Target, m.ReadOnly)
+		case mount.TypeTmpfs:
+			add("", m.Target, false)
+		default:
+			return nil, errKubernetesUnsupported(fmt.Sprintf("%s mounts", m.Type))
+		}
+	}
+	return mounts, nil
+}
+
+// kubePodManifest returns the pod running the container p.
+// hosts holds the /etc/hosts entries of other containers.
+// If waitForCopies is set, the pod does not start its main container until kubeReady is created.
+func kubePodManifest(p *kubePod, hosts string, waitForCopies bool) kubeObject {
+	cfg := p.cfg
+
+	toolsMount := kubeVolumeMount{Name: "interchaintest-tools", MountPath: kubeToolsDir}
+	volumes := []kubeVolume{{Name: toolsMount.Name, EmptyDir: &kubeEmptyDirSource{}}}
+	mounts := []kubeVolumeMount{toolsMount}
+	for _, m := range p.volumes {
+		v := kubeVolume{Name: m.Name}
+		if m.claim == "" {
+			v.EmptyDir = &kubeEmptyDirSource{Medium: "Memory"}
+		} else {
+			v.PersistentVolumeClaim = &kubeClaimSource{ClaimName: m.claim}
+		}
+		volumes = append(volumes, v)
+		mounts = append(mounts, m)
+	}
+
+	wait := ""
+	if waitForCopies {
+		wait = "1"
+	}
+	root := int64(0)
+	tools := kubeContainer{
+		Name:            kubeToolsContainer,
+		Image:           BusyboxRef,
+		ImagePullPolicy: "IfNotPresent",
+		Command:         []string{"sh", "-c", kubeToolsScript},
+		Env: []kubeEnvVar{
+			{Name: "ICTEST_HOSTS", Value: hosts},
+			{Name: "ICTEST_WAIT", Value: wait},
+		},
+		VolumeMounts:    mounts,
+		SecurityContext: &kubeSecurityContext{RunAsUser: &root, RunAsGroup: &root},
+	}
+
+	app := kubeContainer{
+		Name:            kubeMainContainer,
+		Image:           cfg.Image,
+		ImagePullPolicy: "IfNotPresent",
+		WorkingDir:      cfg.WorkingDir,
+		VolumeMounts:    mounts,
+		SecurityContext: kubeUser(cfg.User),
+		Resources:       kubeLimits(p.hostCfg.Resources),
+	}
+	if cfg.Entrypoint == nil {
+		// Keep the image's entrypoint, which is only known to the node.
+		app.Args = cfg.Cmd
+	} else {
+		app.Command = []string{kubeBusybox, "sh", "-c", kubeWrapper, "_"}
+		app.Args = append(append([]string(nil), cfg.Entrypoint...), cfg.Cmd...)
+	}
+	for _, e := range cfg.Env {
+		k, v, _ := strings.Cut(e, "=")
+		app.Env = append(app.Env, kubeEnvVar{Name: k, Value: v})
+	}
+	for _, port := range sortedPorts(cfg.ExposedPorts) {
+		app.Ports = append(app.Ports, kubeContainerPort{ContainerPort: port.Int(), Protocol: strings.ToUpper(port.Proto())})
+	}
+
+	labels := kubeLabels(cfg.Labels)
+	labels[kubePodLabel] = p.name
+
+	hostname := ""
+	if cfg.Hostname != "" {
+		hostname = kubeName(cfg.Hostname)
+	}
+
+	return kubeObject{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Metadata:   kubeMeta{Name: p.name, Labels: labels},
+		Spec: kubePodSpec{
+			Hostname:       hostname,
+			RestartPolicy:  "Never",
+			InitContainers: []kubeContainer{tools},
+			Containers:     []kubeContainer{app},
+			Volumes:        volumes,
+		},
+	}
+}
+
+// kubeServiceManifest returns the service giving the pod named name a stable address for ports.
+func kubeServiceManifest(name string, ports nat.PortSet) kubeObject {
+	spec := kubeServiceSpec{Selector: map[string]string{kubePodLabel: name}}
+	for _, port := range sortedPorts(ports) {
+		spec.Ports = append(spec.Ports, kubeServicePort{
+			Name:       fmt.Sprintf("%s-%s", port.Proto(), port.Port()),
+			Port:       port.Int(),
+			TargetPort: port.Int(),
+			Protocol:   strings.ToUpper(port.Proto()),
+		})
+	}
+	return kubeObject{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   kubeMeta{Name: name, Labels: map[string]string{kubePodLabel: name}},
+		Spec:       spec,
+	}
+}
+
+// kubeVolumeManifest returns the persistent volume claim backing the volume named name.
+func kubeVolumeManifest(name string, labels map[string]string, storageClass, size string) kubeObject {
+	spec := kubeClaimSpec{
+		AccessModes: []string{"ReadWriteOnce"},
+		Resources:   kubeClaimSize{Requests: map[string]string{"storage": size}},
+	}
+	if storageClass != "" {
+		spec.StorageClassName = &storageClass
+	}
+	return kubeObject{
+		APIVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+		Metadata:   kubeMeta{Name: name, Labels: kubeLabels(labels)},
+		Spec:       spec,
+	}
+}
+
+func sortedPorts(ports nat.PortSet) []nat.Port {
+	out := make([]nat.Port, 0, len(ports))
+	for p := range ports {
+		out = append(out, p)
+	}
+	nat.Sort(out, func(a, b nat.Port) bool {
+		if a.Int() != b.Int() {
+			return a.Int() < b.Int()
+		}
+		return a.Proto() < b.Proto()
+	})
+	return out
+}
+
+var invalidKubeLabelValueRE = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// kubeLabels returns labels with their values made valid for Kubernetes.
+// Values are shortened to 63 characters, so long values may no longer be unique.
+func kubeLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		v = invalidKubeLabelValueRE.ReplaceAllString(v, "_")
+		if len(v) > 63 {
+			v = v[:63]
+		}
+		out[k] = strings.Trim(v, "_.-")
+	}
+	return out
+}
+
+// kubeUser returns the security context running as user, in the uid:gid form of container.Config.
+func kubeUser(user string) *kubeSecurityContext {
+	if user == "" {
+		return nil
+	}
+	u, g, _ := strings.Cut(user, ":")
+	var sc kubeSecurityContext
+	if uid, err := strconv.ParseInt(u, 10, 64); err == nil {
+		sc.RunAsUser = &uid
+	}
+	if gid, err := strconv.ParseInt(g, 10, 64); err == nil {
+		sc.RunAsGroup = &gid
+	}
+	if sc.RunAsUser == nil && sc.RunAsGroup == nil {
+		return nil
+	}
+	return &sc
+}
+
+func kubeLimits(r container.Resources) *kubeResources {
+	limits := make(map[string]string)
+	if r.NanoCPUs > 0 {
+		limits["cpu"] = fmt.Sprintf("%dm", r.NanoCPUs/1e6)
+	}
+	if r.Memory > 0 {
+		limits["memory"] = strconv.FormatInt(r.Memory, 10)
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+	return &kubeResources{Limits: limits}
+}
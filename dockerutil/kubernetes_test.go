@@ -0,0 +1,279 @@
+package dockerutil
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubeName(t *testing.T) {
+	require.Equal(t, "gaia-1-val-0-testfoo", kubeName("gaia-1-val-0-TestFoo"))
+	require.Equal(t, "testfoo-bar-baz", kubeName("TestFoo/bar_baz"))
+	require.Equal(t, "x-1abc", kubeName("1abc"))
+	require.Equal(t, "x", kubeName("__"))
+
+	long := kubeName(strings.Repeat("TestSomethingLong_", 10))
+	require.Len(t, long, 63)
+	require.NotEqual(t, long, kubeName(strings.Repeat("TestSomethingLong_", 11)))
+
+	for _, n := range []string{"gaia-1-val-0-testfoo", long} {
+		require.Equal(t, n, kubeName(n), "valid names must be unchanged")
+	}
+}
+
+func TestSplitKubeLogs(t *testing.T) {
+	logs := "line 1\n\x1eerr 1\nline 2\npartial\x1eerr 2\n\x1eunterminated"
+	stdout, stderr := splitKubeLogs([]byte(logs))
+	require.Equal(t, "line 1\nline 2\npartial", string(stdout))
+	require.Equal(t, "err 1\nerr 2\nunterminated", string(stderr))
+
+	require.Equal(t, "b\nc\n", string(tailLines([]byte("a\nb\nc\n"), 2)))
+	require.Equal(t, "a\nb", string(tailLines([]byte("a\nb"), 5)))
+}
+
+func TestKubeWrapper(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+
+	// Stand in for busybox with a script running the applet from PATH.
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "busybox"), []byte("#!/bin/sh\napplet=$1\nshift\nexec \"$applet\" \"$@\"\n"), 0o755))
+	wrapper := strings.ReplaceAll(kubeWrapper, kubeToolsDir, dir)
+
+	cmd := exec.Command("sh", "-c", wrapper, "_", "sh", "-c", "echo out; echo err >&2; exit 3")
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	require.Equal(t, 3, exitErr.ExitCode())
+
+	stdout, stderr := splitKubeLogs(out)
+	require.Equal(t, "out\n", string(stdout))
+	require.Equal(t, "err\n", string(stderr))
+}
+
+func TestKubeVolumeMounts(t *testing.T) {
+	mounts, err := kubeVolumeMounts(&container.HostConfig{
+		Binds: []string{"vol-a:/home/node", "vol-b:/data:ro"},
+		Mounts: []mount.Mount{
+			{Type: mount.TypeVolume, Source: "vol-c", Target: "/cache"},
+			{Type: mount.TypeTmpfs, Target: "/tmp"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []kubeVolumeMount{
+		{Name: "v0", MountPath: "/home/node", claim: "vol-a"},
+		{Name: "v1", MountPath: "/data", ReadOnly: true, claim: "vol-b"},
+		{Name: "v2", MountPath: "/cache", claim: "vol-c"},
+		{Name: "v3", MountPath: "/tmp"},
+	}, mounts)
+
+	p := &kubePod{volumes: mounts}
+	require.True(t, p.onVolume("/home/node/config/genesis.json"))
+	require.True(t, p.onVolume("/data"))
+	require.False(t, p.onVolume("/home/nodes"))
+
+	_, err = kubeVolumeMounts(&container.HostConfig{Binds: []string{"/tmp/genesis:/genesis"}})
+	require.True(t, errdefs.IsNotImplemented(err))
+}
+
+func TestKubePodManifest(t *testing.T) {
+	p := &kubePod{
+		name: "gaia-1-val-0-testfoo",
+		cfg: &container.Config{
+			Image:        "ghcr.io/strangelove-ventures/heighliner/gaia:v14.1.0",
+			Entrypoint:   []string{},
+			Cmd:          []string{"gaiad", "start"},
+			Env:          []string{"A=1", "B=x=y"},
+			User:         "1025:1025",
+			Hostname:     "gaia-1-val-0-TestFoo",
+			ExposedPorts: nat.PortSet{"26657/tcp": {}, "26656/tcp": {}},
+			Labels:       map[string]string{CleanupLabel: "TestFoo/bar"},
+		},
+		hostCfg: &container.HostConfig{Resources: HostResources(ibc.ResourceLimits{CPUs: 0.5, Memory: 256 << 20})},
+		volumes: []kubeVolumeMount{{Name: "v0", MountPath: "/var/cosmos-chain/gaia", claim: "vol-a"}},
+	}
+
+	obj := kubePodManifest(p, "10.0.0.1\tgaia-1-val-0-TestFoo\n", true)
+	b, err := json.Marshal(obj)
+	require.NoError(t, err)
+
+	var pod struct {
+		Metadata kubeMeta    `json:"metadata"`
+		Spec     kubePodSpec `json:"spec"`
+	}
+	require.NoError(t, json.Unmarshal(b, &pod))
+
+	require.Equal(t, "TestFoo_bar", pod.Metadata.Labels[CleanupLabel])
+	require.Equal(t, p.name, pod.Metadata.Labels[kubePodLabel])
+	require.Equal(t, "gaia-1-val-0-testfoo", pod.Spec.Hostname)
+	require.Equal(t, "Never", pod.Spec.RestartPolicy)
+
+	tools := pod.Spec.InitContainers[0]
+	require.Contains(t, tools.Env, kubeEnvVar{Name: "ICTEST_HOSTS", Value: "10.0.0.1\tgaia-1-val-0-TestFoo\n"})
+	require.Contains(t, tools.Env, kubeEnvVar{Name: "ICTEST_WAIT", Value: "1"})
+	require.Zero(t, *tools.SecurityContext.RunAsUser)
+
+	app := pod.Spec.Containers[0]
+	require.Equal(t, []string{kubeBusybox, "sh", "-c", kubeWrapper, "_"}, app.Command)
+	require.Equal(t, []string{"gaiad", "start"}, app.Args)
+	require.Equal(t, []kubeEnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "x=y"}}, app.Env)
+	require.Equal(t, []kubeContainerPort{{ContainerPort: 26656, Protocol: "TCP"}, {ContainerPort: 26657, Protocol: "TCP"}}, app.Ports)
+	require.EqualValues(t, 1025, *app.SecurityContext.RunAsUser)
+	require.Equal(t, map[string]string{"cpu": "500m", "memory": "268435456"}, app.Resources.Limits)
+	require.Len(t, app.VolumeMounts, 2)
+	require.Equal(t, "vol-a", pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+
+	t.Run("image entrypoint", func(t *testing.T) {
+		p.cfg.Entrypoint = nil
+		obj := kubePodManifest(p, "", false)
+		app := obj.Spec.(kubePodSpec).Containers[0]
+		require.Nil(t, app.Command)
+		require.Equal(t, []string{"gaiad", "start"}, app.Args)
+	})
+}
+
+func TestKubeServiceManifest(t *testing.T) {
+	obj := kubeServiceManifest("node", nat.PortSet{"9090/tcp": {}, "26657/tcp": {}})
+	spec := obj.Spec.(kubeServiceSpec)
+	require.Equal(t, map[string]string{kubePodLabel: "node"}, spec.Selector)
+	require.Equal(t, []kubeServicePort{
+		{Name: "tcp-9090", Port: 9090, TargetPort: 9090, Protocol: "TCP"},
+		{Name: "tcp-26657", Port: 26657, TargetPort: 26657, Protocol: "TCP"},
+	}, spec.Ports)
+}
+
+func TestKubePodStatusFailure(t *testing.T) {
+	var s kubePodStatus
+	require.NoError(t, json.Unmarshal([]byte(`{"status":{"phase":"Pending","containerStatuses":[
+		{"name":"main","state":{"waiting":{"reason":"ContainerCreating"}}}
+	]}}`), &s))
+	require.NoError(t, s.failure())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"status":{"phase":"Pending","containerStatuses":[
+		{"name":"main","state":{"waiting":{"reason":"ImagePullBackOff","message":"not found"}}}
+	]}}`), &s))
+	require.ErrorContains(t, s.failure(), "ImagePullBackOff")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"status":{"phase":"Succeeded","containerStatuses":[
+		{"name":"main","state":{"terminated":{"exitCode":2,"finishedAt":"2024-01-01T00:00:00Z"}}}
+	]}}`), &s))
+	require.Equal(t, 2, s.main().Terminated.ExitCode)
+}
+
+func TestParseKubeTop(t *testing.T) {
+	s, err := parseKubeTop([]byte("node   tools   0m    0Mi\nnode   main   250m   64Mi\n"))
+	require.NoError(t, err)
+	require.Equal(t, 25.0, s.CPUPercent)
+	require.EqualValues(t, 64<<20, s.MemoryUsage)
+
+	_, err = parseKubeTop([]byte(""))
+	require.Error(t, err)
+}
+
+// fakeKubectl installs a kubectl script that logs its arguments and answers as a cluster running every pod would.
+func fakeKubectl(t *testing.T) (logFile string) {
+	dir := t.TempDir()
+	logFile = filepath.Join(dir, "calls")
+	script := `#!/bin/sh
+echo "$@" >>` + logFile + `
+case "$*" in
+*"get persistentvolumeclaims"*) echo '{"items":[{"metadata":{"name":"vol","labels":{"ibc-test":"TestFoo"}}}]}'; exit ;;
+esac
+case "$3" in
+create) cat >/dev/null; echo 10.96.0.10 ;;
+get) echo '{"status":{"phase":"Running","initContainerStatuses":[{"name":"tools","state":{"running":{}}}],"containerStatuses":[{"name":"main","state":{"running":{"startedAt":"2024-01-01T00:00:00Z"}}}]}}' ;;
+exec) cat >/dev/null ;;
+esac
+case "$*" in
+*"-c main"*) echo out; echo boom >&2; exit 2 ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o755))
+	t.Setenv("ICTEST_KUBECTL", filepath.Join(dir, "kubectl"))
+	return logFile
+}
+
+func TestKubernetesRuntime(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not found")
+	}
+	calls := fakeKubectl(t)
+
+	rt, err := NewKubernetesRuntime("ns")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	id, err := rt.CreateContainer(ctx, "gaia-1-val-0-TestFoo", &container.Config{
+		Image:        "gaia:v1",
+		Entrypoint:   []string{},
+		Cmd:          []string{"gaiad", "start"},
+		Hostname:     "gaia-1-val-0-TestFoo_host",
+		ExposedPorts: nat.PortSet{"26657/tcp": {}},
+		Labels:       map[string]string{CleanupLabel: "TestFoo"},
+	}, &container.HostConfig{Binds: []string{"vol:/home"}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "gaia-1-val-0-testfoo", id)
+	require.Equal(t, "10.96.0.10\tgaia-1-val-0-TestFoo\n10.96.0.10\tgaia-1-val-0-TestFoo_host\n", rt.hostsFile())
+
+	_, err = rt.CreateContainer(ctx, "gaia-1-val-0-TestFoo", &container.Config{}, nil, nil)
+	require.True(t, errdefs.IsConflict(err))
+
+	require.NoError(t, rt.CopyToContainer(ctx, id, "/home/config", strings.NewReader("tar")))
+	require.True(t, errdefs.IsNotImplemented(rt.CopyToContainer(ctx, id, "/etc", strings.NewReader("tar"))))
+
+	require.NoError(t, rt.StartContainer(ctx, id))
+
+	res := rt.Exec(ctx, id, []string{"gaiad", "status"}, []string{"A=1"})
+	require.Equal(t, 2, res.ExitCode)
+	require.Equal(t, "out\n", string(res.Stdout))
+	require.ErrorContains(t, res.Err, "boom")
+
+	cj, err := rt.InspectContainer(ctx, id)
+	require.NoError(t, err)
+	require.True(t, cj.State.Running)
+
+	cs, err := rt.ListContainers(ctx, map[string]string{CleanupLabel: "TestFoo"})
+	require.NoError(t, err)
+	require.Len(t, cs, 1)
+	require.Equal(t, id, cs[0].ID)
+	cs, err = rt.ListContainers(ctx, map[string]string{CleanupLabel: "TestBar"})
+	require.NoError(t, err)
+	require.Empty(t, cs)
+
+	vs, err := rt.ListVolumes(ctx, map[string]string{CleanupLabel: "TestFoo"})
+	require.NoError(t, err)
+	require.Len(t, vs, 1)
+	require.Equal(t, "vol", vs[0].Name)
+	require.Equal(t, "TestFoo", vs[0].Labels[CleanupLabel])
+
+	require.NoError(t, rt.RemoveContainer(ctx, id))
+	require.NoError(t, rt.RemoveContainer(ctx, id), "removing a missing container is not an error")
+	require.Empty(t, rt.hostsFile())
+
+	b, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	require.Equal(t, strings.Join([]string{
+		"--namespace ns create -f - -o jsonpath={.spec.clusterIP}",
+		"--namespace ns create -f -",
+		"--namespace ns get pod gaia-1-val-0-testfoo -o json",
+		"--namespace ns exec -i gaia-1-val-0-testfoo -c tools -- tar -x -C /home/config",
+		"--namespace ns exec gaia-1-val-0-testfoo -c tools -- touch /.interchaintest/ready",
+		"--namespace ns get pod gaia-1-val-0-testfoo -o json",
+		"--namespace ns exec gaia-1-val-0-testfoo -c main -- /.interchaintest/busybox env A=1 gaiad status",
+		"--namespace ns get pod gaia-1-val-0-testfoo -o json",
+		"--namespace ns get persistentvolumeclaims -l ibc-test=TestFoo -o json",
+		"--namespace ns delete pod/gaia-1-val-0-testfoo service/gaia-1-val-0-testfoo --ignore-not-found --wait=false --grace-period=0 --force",
+	}, "\n")+"\n", string(b))
+}
@@ -0,0 +1,208 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+)
+
+// Labels identifying the objects of a persistent network and the chain nodes within it.
+const (
+	// PersistentNetworkLabel holds the name of a persistent network, on the network itself.
+	PersistentNetworkLabel = LabelPrefix + "persistent-network"
+
+	// PersistentStateLabel holds the state saved with SavePersistentState.
+	PersistentStateLabel = LabelPrefix + "persistent-state"
+
+	// ChainIDLabel, NodeTypeLabel and NodeIndexLabel identify the chain node run by a container.
+	ChainIDLabel   = LabelPrefix + "chain-id"
+	NodeTypeLabel  = LabelPrefix + "node-type"
+	NodeIndexLabel = LabelPrefix + "node-index"
+)
+
+// PersistentTestName returns the value of CleanupLabel for objects of the persistent network named name.
+// It is used in place of a test name, so no test's DockerSetup or DockerCleanup removes them.
+func PersistentTestName(name string) string {
+	return "persistent-" + name
+}
+
+// PersistentNetworkSetup returns a client and the ID of the persistent network named name,
+// creating the network unless an earlier process did; existing reports whether it did.
+// A network without saved state, left by a process that failed before saving it, cannot be attached to:
+// it is removed with its containers and volumes, and created again.
+//
+// Unlike DockerSetup, nothing is cleaned up when t completes:
+// containers, volumes and the network remain until RemovePersistentNetwork is called.
+// Objects of the persistent network must be labelled with PersistentTestName(name) in place of the test name.
+//
+// If any part of the setup fails, PersistentNetworkSetup panics because the test cannot continue.
+func PersistentNetworkSetup(t DockerSetupTestingT, name string) (cli *client.Client, networkID string, existing bool) {
+	t.Helper()
+
+	cli, rt, err := NewRuntimeClient(ContainerRuntimeName)
+	if err != nil {
+		panic(fmt.Errorf("failed to create %s client: %v", ContainerRuntimeName, err))
+	}
+	t.Cleanup(func() { forgetClient(cli) })
+
+	ctx := context.TODO()
+	networkID, stale, err := attachablePersistentNetwork(ctx, rt, name)
+	if err != nil {
+		panic(err)
+	}
+	if networkID != "" {
+		return cli, networkID, true
+	}
+	if stale {
+		t.Logf("Removing persistent network %s, which has no saved state", name)
+		RemovePersistentNetwork(t, cli, name)
+	}
+
+	networkID, err = rt.CreateNetwork(ctx, fmt.Sprintf("%s-%s", ICTDockerPrefix, SanitizeContainerName(name)), map[string]string{
+		CleanupLabel:           PersistentTestName(name),
+		PersistentNetworkLabel: name,
+	})
+	if err != nil {
+		panic(fmt.Errorf("failed to create %s network: %v", rt.Name(), err))
+	}
+	return cli, networkID, false
+}
+
+// attachablePersistentNetwork returns the ID of the persistent network named name
+// if it exists and its state was saved, or an empty ID otherwise.
+// stale reports whether the network exists without saved state.
+func attachablePersistentNetwork(ctx context.Context, rt ContainerRuntime, name string) (networkID string, stale bool, err error) {
+	ns, err := rt.ListNetworks(ctx, map[string]string{PersistentNetworkLabel: name})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list %s networks: %w", rt.Name(), err)
+	}
+	if len(ns) == 0 {
+		return "", false, nil
+	}
+
+	_, found, err := LoadPersistentState(ctx, rt, name)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to load state of persistent network %s: %w", name, err)
+	}
+	if !found {
+		return "", true, nil
+	}
+	return ns[0].ID, false, nil
+}
+
+// persistentT runs DockerCleanup against the objects of a persistent network.
+type persistentT struct {
+	DockerSetupTestingT
+	name string
+}
+
+func (t persistentT) Name() string { return PersistentTestName(t.name) }
+
+// Failed is false so that volumes are pruned and logs are not dumped regardless of the calling test.
+func (t persistentT) Failed() bool { return false }
+
+// RemovePersistentNetwork removes the containers, volumes and network of the persistent network named name.
+func RemovePersistentNetwork(t DockerSetupTestingT, cli *client.Client, name string) {
+	DockerCleanup(persistentT{DockerSetupTestingT: t, name: name}, cli)()
+}
+
+func persistentStateContainerName(name string) string {
+	return fmt.Sprintf("%s-persistent-state-%s", ICTDockerPrefix, SanitizeContainerName(name))
+}
+
+// SavePersistentState stores state in the labels of a container of the persistent network named name,
+// replacing any state saved before. The container is created but never started.
+func SavePersistentState(ctx context.Context, rt ContainerRuntime, name string, state []byte) error {
+	if err := EnsureBusybox(ctx, rt); err != nil {
+		return err
+	}
+
+	containerName := persistentStateContainerName(name)
+	if err := rt.RemoveContainer(ctx, containerName); err != nil {
+		return fmt.Errorf("remove previous state of persistent network %s: %w", name, err)
+	}
+
+	if _, err := rt.CreateContainer(ctx, containerName, &container.Config{
+		Image: BusyboxRef,
+		Cmd:   []string{"true"},
+		Labels: map[string]string{
+			CleanupLabel:         PersistentTestName(name),
+			PersistentStateLabel: string(state),
+		},
+	}, nil, nil); err != nil {
+		return fmt.Errorf("save state of persistent network %s: %w", name, err)
+	}
+	return nil
+}
+
+// LoadPersistentState returns the state last saved with SavePersistentState.
+// found is false if no state was saved.
+func LoadPersistentState(ctx context.Context, rt ContainerRuntime, name string) (state []byte, found bool, err error) {
+	cs, err := LabelledContainers(ctx, rt, PersistentTestName(name), map[string]string{
+		PersistentStateLabel: "",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(cs) == 0 {
+		return nil, false, nil
+	}
+	return []byte(cs[0].Labels[PersistentStateLabel]), true, nil
+}
+
+// LabelledContainers returns the containers, running or not, labelled for testName and with every label in labels.
+// An empty label value matches any value.
+func LabelledContainers(ctx context.Context, rt ContainerRuntime, testName string, labels map[string]string) ([]types.Container, error) {
+	want := map[string]string{CleanupLabel: testName}
+	for k, v := range labels {
+		if v != "" {
+			want[k] = v
+		}
+	}
+
+	cs, err := rt.ListContainers(ctx, want)
+	if err != nil {
+		return nil, fmt.Errorf("list containers of %s: %w", testName, err)
+	}
+
+	matched := cs[:0]
+	for _, c := range cs {
+		if hasLabelKeys(c.Labels, labels) {
+			matched = append(matched, c)
+		}
+	}
+	return matched, nil
+}
+
+// hasLabelKeys reports whether got holds a label of every key of want, whatever its value.
+func hasLabelKeys(got, want map[string]string) bool {
+	for k := range want {
+		if _, ok := got[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// OwnedVolumes returns the names of the volumes labelled for testName and owned by owner,
+// as set by NodeOwnerLabel, oldest first.
+func OwnedVolumes(ctx context.Context, rt ContainerRuntime, testName, owner string) ([]string, error) {
+	vs, err := rt.ListVolumes(ctx, map[string]string{
+		CleanupLabel:   testName,
+		NodeOwnerLabel: owner,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list volumes of %s: %w", owner, err)
+	}
+
+	sort.SliceStable(vs, func(i, j int) bool { return vs[i].CreatedAt < vs[j].CreatedAt })
+	names := make([]string, len(vs))
+	for i, v := range vs {
+		names[i] = v.Name
+	}
+	return names, nil
+}
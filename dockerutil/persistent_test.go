@@ -0,0 +1,77 @@
+package dockerutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachablePersistentNetwork(t *testing.T) {
+	ctx := context.Background()
+	rt, err := NewProcessRuntime(t.TempDir())
+	require.NoError(t, err)
+
+	id, stale, err := attachablePersistentNetwork(ctx, rt, "net")
+	require.NoError(t, err)
+	require.Empty(t, id)
+	require.False(t, stale)
+
+	networkID, err := rt.CreateNetwork(ctx, "ict-net", map[string]string{
+		CleanupLabel:           PersistentTestName("net"),
+		PersistentNetworkLabel: "net",
+	})
+	require.NoError(t, err)
+
+	// A network whose state was never saved cannot be attached to.
+	id, stale, err = attachablePersistentNetwork(ctx, rt, "net")
+	require.NoError(t, err)
+	require.Empty(t, id)
+	require.True(t, stale)
+
+	require.NoError(t, SavePersistentState(ctx, rt, "net", []byte("state")))
+	id, stale, err = attachablePersistentNetwork(ctx, rt, "net")
+	require.NoError(t, err)
+	require.Equal(t, networkID, id)
+	require.False(t, stale)
+
+	state, found, err := LoadPersistentState(ctx, rt, "net")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "state", string(state))
+}
+
+func TestLabelledContainersAndOwnedVolumes(t *testing.T) {
+	ctx := context.Background()
+	rt, err := NewProcessRuntime(t.TempDir())
+	require.NoError(t, err)
+
+	for _, c := range []struct {
+		name   string
+		labels map[string]string
+	}{
+		{"val-0", map[string]string{CleanupLabel: "test", ChainIDLabel: "gaia-1", NodeIndexLabel: "0"}},
+		{"val-1", map[string]string{CleanupLabel: "test", ChainIDLabel: "gaia-1"}},
+		{"other", map[string]string{CleanupLabel: "test", ChainIDLabel: "osmosis-1", NodeIndexLabel: "0"}},
+		{"foreign", map[string]string{CleanupLabel: "other-test", ChainIDLabel: "gaia-1", NodeIndexLabel: "0"}},
+	} {
+		_, err := rt.CreateContainer(ctx, c.name, &container.Config{Labels: c.labels}, nil, nil)
+		require.NoError(t, err)
+	}
+
+	// An empty label value matches any value.
+	cs, err := LabelledContainers(ctx, rt, "test", map[string]string{ChainIDLabel: "gaia-1", NodeIndexLabel: ""})
+	require.NoError(t, err)
+	require.Len(t, cs, 1)
+	require.Equal(t, "val-0", cs[0].ID)
+
+	vol, err := rt.CreateVolume(ctx, map[string]string{CleanupLabel: "test", NodeOwnerLabel: "val-0"})
+	require.NoError(t, err)
+	_, err = rt.CreateVolume(ctx, map[string]string{CleanupLabel: "test", NodeOwnerLabel: "val-1"})
+	require.NoError(t, err)
+
+	vs, err := OwnedVolumes(ctx, rt, "test", "val-0")
+	require.NoError(t, err)
+	require.Equal(t, []string{vol}, vs)
+}
@@ -0,0 +1,210 @@
+package dockerutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"go.uber.org/zap"
+)
+
+// PodmanRuntime is the ContainerRuntime for Podman, including rootless Podman,
+// through its Docker-compatible API socket.
+//
+// Podman's compatibility layer differs from Docker in a few ways that PodmanRuntime papers over:
+//   - Short image names are not resolved when short-name-mode is enforcing, so image references are
+//     fully qualified with docker.io as Docker would.
+//   - Auto-removed containers may disappear before a wait on them returns, so auto-removal is
+//     instead done once WaitContainer has observed the exit.
+//   - Stopping a stopped container fails with "container state improper" rather than "not modified".
+//   - An exec's exit code may not be set when its output stream ends.
+//   - It serves an older API version than the Docker client defaults to, so the version is negotiated.
+type PodmanRuntime struct {
+	*DockerRuntime
+
+	mu         sync.Mutex
+	autoRemove map[string]bool
+}
+
+// NewPodmanRuntime returns a PodmanRuntime connected to the socket found by PodmanHost.
+func NewPodmanRuntime() (*PodmanRuntime, error) {
+	host, err := PodmanHost()
+	if err != nil {
+		return nil, err
+	}
+	cli, err := client.NewClientWithOpts(client.WithHost(host), client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("create podman client for %s: %w", host, err)
+	}
+	return &PodmanRuntime{
+		DockerRuntime: NewDockerRuntime(cli),
+		autoRemove:    make(map[string]bool),
+	}, nil
+}
+
+// PodmanHost returns the address of the Podman API socket.
+//
+// CONTAINER_HOST is used if set, followed by a DOCKER_HOST pointing at a Podman socket.
+// Otherwise, the rootless socket under XDG_RUNTIME_DIR and then the rootful socket are tried.
+// The socket is typically started with `systemctl --user start podman.socket`.
+func PodmanHost() (string, error) {
+	if h := os.Getenv("CONTAINER_HOST"); h != "" {
+		return h, nil
+	}
+	if h := os.Getenv("DOCKER_HOST"); strings.Contains(h, "podman") {
+		return h, nil
+	}
+
+	candidates := podmanSocketCandidates(os.Getenv("XDG_RUNTIME_DIR"), os.Getuid())
+	for _, p := range candidates {
+		if _, err := os.Stat(p); err == nil {
+			return "unix://" + p, nil
+		}
+	}
+	return "", fmt.Errorf("no podman socket found in %s; start one with `systemctl --user start podman.socket` or set CONTAINER_HOST", strings.Join(candidates, ", "))
+}
+
+func podmanSocketCandidates(xdgRuntimeDir string, uid int) []string {
+	var out []string
+	if xdgRuntimeDir != "" {
+		out = append(out, filepath.Join(xdgRuntimeDir, "podman", "podman.sock"))
+	}
+	if uid > 0 {
+		p := filepath.Join("/run", "user", fmt.Sprint(uid), "podman", "podman.sock")
+		if len(out) == 0 || out[0] != p {
+			out = append(out, p)
+		}
+	}
+	return append(out, "/run/podman/podman.sock")
+}
+
+// qualifyImageRef expands ref the way Docker resolves short names,
+// e.g. "busybox:stable" to "docker.io/library/busybox:stable".
+func qualifyImageRef(ref string) string {
+	if ref == "" || strings.HasPrefix(ref, "sha256:") {
+		return ref
+	}
+	domain, rest, found := strings.Cut(ref, "/")
+	if !found {
+		return "docker.io/library/" + ref
+	}
+	if strings.ContainsAny(domain, ".:") || domain == "localhost" {
+		return ref
+	}
+	return "docker.io/" + domain + "/" + rest
+}
+
+func (r *PodmanRuntime) Name() string { return RuntimePodman }
+
+func (r *PodmanRuntime) ImageExists(ctx context.Context, ref string) (bool, error) {
+	return r.DockerRuntime.ImageExists(ctx, qualifyImageRef(ref))
+}
+
+func (r *PodmanRuntime) PullImage(ctx context.Context, ref string) error {
+	return r.DockerRuntime.PullImage(ctx, qualifyImageRef(ref))
+}
+
+func (r *PodmanRuntime) SaveImages(ctx context.Context, refs []string) (io.ReadCloser, error) {
+	qualified := make([]string, len(refs))
+	for i, ref := range refs {
+		qualified[i] = qualifyImageRef(ref)
+	}
+	return r.DockerRuntime.SaveImages(ctx, qualified)
+}
+
+// BuildChain builds the image of opts through BuildImage, qualifying its tags.
+func (r *PodmanRuntime) BuildChain(ctx context.Context, log *zap.Logger, opts BuildOptions) error {
+	return buildChainImage(ctx, log, r, opts)
+}
+
+// BuildImage builds with Buildah, which supports cache mounts regardless of opts.Version.
+// Tags are qualified so that CreateContainer finds the built image.
+func (r *PodmanRuntime) BuildImage(ctx context.Context, buildContext io.Reader, opts types.ImageBuildOptions) (io.ReadCloser, error) {
+	tags := make([]string, len(opts.Tags))
+	for i, tag := range opts.Tags {
+		tags[i] = qualifyImageRef(tag)
+	}
+	opts.Tags = tags
+	return r.DockerRuntime.BuildImage(ctx, buildContext, opts)
+}
+
+func (r *PodmanRuntime) CreateContainer(ctx context.Context, name string, cfg *container.Config, hostCfg *container.HostConfig, netCfg *network.NetworkingConfig) (string, error) {
+	c := *cfg
+	c.Image = qualifyImageRef(c.Image)
+
+	var autoRemove bool
+	if hostCfg != nil && hostCfg.AutoRemove {
+		h := *hostCfg
+		h.AutoRemove = false
+		hostCfg, autoRemove = &h, true
+	}
+
+	id, err := r.DockerRuntime.CreateContainer(ctx, name, &c, hostCfg, netCfg)
+	if err != nil {
+		return "", err
+	}
+	if autoRemove {
+		r.mu.Lock()
+		r.autoRemove[id] = true
+		r.mu.Unlock()
+	}
+	return id, nil
+}
+
+func (r *PodmanRuntime) StopContainer(ctx context.Context, id string, timeout time.Duration) error {
+	err := r.DockerRuntime.StopContainer(ctx, id, timeout)
+	if err != nil && strings.Contains(err.Error(), "container state improper") {
+		return nil
+	}
+	return err
+}
+
+func (r *PodmanRuntime) KillContainer(ctx context.Context, id string) error {
+	err := r.DockerRuntime.KillContainer(ctx, id)
+	if err != nil && (isPodmanNotFound(err) || strings.Contains(err.Error(), "container state improper")) {
+		return nil
+	}
+	return err
+}
+
+func (r *PodmanRuntime) WaitContainer(ctx context.Context, id string) (int, error) {
+	code, err := r.DockerRuntime.WaitContainer(ctx, id)
+
+	r.mu.Lock()
+	autoRemove := r.autoRemove[id]
+	delete(r.autoRemove, id)
+	r.mu.Unlock()
+
+	if autoRemove {
+		err = errors.Join(err, r.RemoveContainer(context.WithoutCancel(ctx), id))
+	}
+	return code, err
+}
+
+func (r *PodmanRuntime) RemoveContainer(ctx context.Context, id string) error {
+	if err := r.DockerRuntime.RemoveContainer(ctx, id); err != nil && !isPodmanNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *PodmanRuntime) Exec(ctx context.Context, id string, cmd []string, env []string) ContainerExecResult {
+	return r.exec(ctx, id, cmd, env, true)
+}
+
+// isPodmanNotFound reports whether err means the object is missing,
+// which older Podman versions report as a server error instead of a 404.
+func isPodmanNotFound(err error) bool {
+	return errdefs.IsNotFound(err) || (err != nil && strings.Contains(err.Error(), "no such"))
+}
@@ -0,0 +1,50 @@
+package dockerutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualifyImageRef(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"busybox:stable", "docker.io/library/busybox:stable"},
+		{"ghcr.io/strangelove-ventures/heighliner/gaia:v15.0.0", "ghcr.io/strangelove-ventures/heighliner/gaia:v15.0.0"},
+		{"interchainio/simapp:latest", "docker.io/interchainio/simapp:latest"},
+		{"localhost/mychain:local", "localhost/mychain:local"},
+		{"registry:5000/app:v1", "registry:5000/app:v1"},
+		{"sha256:abcdef", "sha256:abcdef"},
+	} {
+		require.Equal(t, tt.want, qualifyImageRef(tt.in), tt.in)
+	}
+}
+
+func TestPodmanHost(t *testing.T) {
+	t.Setenv("CONTAINER_HOST", "unix:///tmp/podman.sock")
+	host, err := PodmanHost()
+	require.NoError(t, err)
+	require.Equal(t, "unix:///tmp/podman.sock", host)
+
+	t.Setenv("CONTAINER_HOST", "")
+	t.Setenv("DOCKER_HOST", "unix:///run/user/1000/podman/podman.sock")
+	host, err = PodmanHost()
+	require.NoError(t, err)
+	require.Equal(t, "unix:///run/user/1000/podman/podman.sock", host)
+}
+
+func TestPodmanSocketCandidates(t *testing.T) {
+	require.Equal(t, []string{
+		"/run/user/1000/podman/podman.sock",
+		"/run/podman/podman.sock",
+	}, podmanSocketCandidates("/run/user/1000", 1000))
+
+	require.Equal(t, []string{
+		"/tmp/xdg/podman/podman.sock",
+		"/run/user/1000/podman/podman.sock",
+		"/run/podman/podman.sock",
+	}, podmanSocketCandidates("/tmp/xdg", 1000))
+
+	require.Equal(t, []string{"/run/podman/podman.sock"}, podmanSocketCandidates("", 0))
+}
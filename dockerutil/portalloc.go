@@ -0,0 +1,209 @@
+package dockerutil
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Defaults of the host port range handed out by DefaultPortAllocator.
+// The range sits below the Linux ephemeral range (32768 and up),
+// so ports picked by the kernel for outgoing connections rarely collide with it.
+const (
+	DefaultPortRangeStart = 20000
+	DefaultPortRangeEnd   = 32000
+
+	// portBlockSize is the number of ports reserved at once by a process.
+	portBlockSize = 100
+)
+
+// DefaultPortAllocator allocates the host ports of containers.
+//
+// Its range can be initialized by setting the environment variable ICTEST_PORT_RANGE, e.g. "40000-50000",
+// and its lock directory by setting ICTEST_PORT_LOCK_DIR.
+// Every process sharing a host must use the same range and lock directory to coordinate.
+var DefaultPortAllocator = defaultPortAllocator()
+
+func defaultPortAllocator() *PortAllocator {
+	start, end := DefaultPortRangeStart, DefaultPortRangeEnd
+	if r := os.Getenv("ICTEST_PORT_RANGE"); r != "" {
+		s, e, err := ParsePortRange(r)
+		if err != nil {
+			panic(fmt.Errorf("invalid ICTEST_PORT_RANGE: %w", err))
+		}
+		start, end = s, e
+	}
+
+	dir := os.Getenv("ICTEST_PORT_LOCK_DIR")
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "interchaintest-ports")
+	}
+	return NewPortAllocator(dir, start, end)
+}
+
+// ParsePortRange parses a range of the form "start-end", where end is exclusive.
+func ParsePortRange(s string) (start, end int, err error) {
+	a, b, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("port range %q is not of the form start-end", s)
+	}
+	if start, err = strconv.Atoi(strings.TrimSpace(a)); err != nil {
+		return 0, 0, fmt.Errorf("port range %q: %w", s, err)
+	}
+	if end, err = strconv.Atoi(strings.TrimSpace(b)); err != nil {
+		return 0, 0, fmt.Errorf("port range %q: %w", s, err)
+	}
+	if start <= 0 || end > 65536 || start >= end {
+		return 0, 0, fmt.Errorf("port range %q is empty or out of bounds", s)
+	}
+	return start, end, nil
+}
+
+// PortAllocator hands out host ports so that parallel tests, in one process or many, never pick the same port.
+//
+// The range is split in blocks of 100 ports. A process reserves a block by locking a file named after it
+// in the lock directory, and holds the lock until it exits, so a crashed process never leaks its blocks.
+// Within a process, ports of the reserved blocks are handed out once until released,
+// skipping any port that another program on the host is listening on.
+type PortAllocator struct {
+	dir        string
+	start, end int
+
+	mu     sync.Mutex
+	blocks map[int]*os.File // Keyed by block number, holding the lock file.
+	inUse  map[int]bool
+}
+
+// NewPortAllocator returns an allocator of ports in [start, end), coordinating through lock files in dir.
+func NewPortAllocator(dir string, start, end int) *PortAllocator {
+	return &PortAllocator{
+		dir:    dir,
+		start:  start,
+		end:    end,
+		blocks: make(map[int]*os.File),
+		inUse:  make(map[int]bool),
+	}
+}
+
+// ErrPortsExhausted is returned when every block of the range is reserved by other processes
+// or has no free port left.
+var ErrPortsExhausted = errors.New("no free host ports left in range")
+
+// Allocate returns n free ports.
+func (a *PortAllocator) Allocate(n int) ([]int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ports := make([]int, 0, n)
+	for len(ports) < n {
+		p, ok := a.nextFreeLocked()
+		if !ok {
+			if err := a.reserveBlockLocked(); err != nil {
+				for _, p := range ports {
+					delete(a.inUse, p)
+				}
+				return nil, err
+			}
+			continue
+		}
+		a.inUse[p] = true
+		ports = append(ports, p)
+	}
+	return ports, nil
+}
+
+// Release returns ports to the allocator. Ports it did not allocate are ignored.
+func (a *PortAllocator) Release(ports ...int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, p := range ports {
+		delete(a.inUse, p)
+	}
+}
+
+// nextFreeLocked returns a port of a reserved block that is neither handed out nor bound on the host.
+func (a *PortAllocator) nextFreeLocked() (int, bool) {
+	for b := range a.blocks {
+		first, last := a.blockRange(b)
+		for p := first; p < last; p++ {
+			if a.inUse[p] {
+				continue
+			}
+			if !hostPortFree(p) {
+				// Bound by another program; never hand it out.
+				a.inUse[p] = true
+				continue
+			}
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+func (a *PortAllocator) numBlocks() int {
+	return (a.end - a.start + portBlockSize - 1) / portBlockSize
+}
+
+func (a *PortAllocator) blockRange(b int) (first, last int) {
+	first = a.start + b*portBlockSize
+	last = first + portBlockSize
+	if last > a.end {
+		last = a.end
+	}
+	return first, last
+}
+
+// reserveBlockLocked locks a block not reserved by any process.
+// The search starts at a random block, so that processes starting together rarely contend for the same lock.
+func (a *PortAllocator) reserveBlockLocked() error {
+	if err := os.MkdirAll(a.dir, 0o777); err != nil {
+		return fmt.Errorf("create port lock directory: %w", err)
+	}
+
+	n := a.numBlocks()
+	offset := rand.Intn(n)
+	for i := 0; i < n; i++ {
+		b := (offset + i) % n
+		if _, ok := a.blocks[b]; ok {
+			continue
+		}
+
+		first, last := a.blockRange(b)
+		f, err := tryLockFile(filepath.Join(a.dir, fmt.Sprintf("%d-%d.lock", first, last)))
+		if err != nil {
+			return fmt.Errorf("lock port block %d-%d: %w", first, last, err)
+		}
+		if f != nil {
+			a.blocks[b] = f
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %d-%d", ErrPortsExhausted, a.start, a.end)
+}
+
+// hostPortFree reports whether nothing listens on port on any interface.
+func hostPortFree(port int) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
+
+// IsPortConflict reports whether err is the failure of a container start
+// because a published host port was already bound.
+func IsPortConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "port is already allocated") ||
+		strings.Contains(msg, "address already in use")
+}
@@ -0,0 +1,106 @@
+package dockerutil
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortRange(t *testing.T) {
+	start, end, err := ParsePortRange("40000-50000")
+	require.NoError(t, err)
+	require.Equal(t, 40000, start)
+	require.Equal(t, 50000, end)
+
+	for _, s := range []string{"", "40000", "a-b", "50000-40000", "0-100", "60000-70000"} {
+		_, _, err := ParsePortRange(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestPortAllocator(t *testing.T) {
+	dir := t.TempDir()
+	a := NewPortAllocator(dir, 41000, 41250)
+
+	ports, err := a.Allocate(150)
+	require.NoError(t, err)
+	require.Len(t, ports, 150)
+
+	seen := make(map[int]bool)
+	for _, p := range ports {
+		require.False(t, seen[p], "port %d allocated twice", p)
+		seen[p] = true
+		require.GreaterOrEqual(t, p, 41000)
+		require.Less(t, p, 41250)
+	}
+
+	t.Run("release", func(t *testing.T) {
+		a.Release(ports[0])
+		more, err := a.Allocate(1)
+		require.NoError(t, err)
+		require.True(t, more[0] == ports[0] || !seen[more[0]], "port %d allocated twice", more[0])
+		a.Release(more...)
+	})
+
+	t.Run("lock files coordinate allocators", func(t *testing.T) {
+		// Locks are held per open file, so a second allocator in the same process
+		// behaves like one in another process.
+		b := NewPortAllocator(dir, 41000, 41250)
+		other, err := b.Allocate(50)
+		require.NoError(t, err)
+		for _, p := range other {
+			require.False(t, seen[p], "port %d allocated by both allocators", p)
+		}
+
+		_, err = b.Allocate(100)
+		require.ErrorIs(t, err, ErrPortsExhausted)
+	})
+}
+
+func TestPortAllocatorSkipsBoundPorts(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer l.Close()
+	bound := l.Addr().(*net.TCPAddr).Port
+
+	a := NewPortAllocator(t.TempDir(), bound, bound+1)
+	_, err = a.Allocate(1)
+	require.ErrorIs(t, err, ErrPortsExhausted)
+}
+
+func TestIsPortConflict(t *testing.T) {
+	require.False(t, IsPortConflict(nil))
+	require.False(t, IsPortConflict(errors.New("no such image")))
+	require.True(t, IsPortConflict(fmt.Errorf("start: %w", errors.New(
+		"driver failed programming external connectivity: Bind for 0.0.0.0:26657 failed: port is already allocated"))))
+	require.True(t, IsPortConflict(errors.New("listen tcp4 0.0.0.0:9090: bind: address already in use")))
+}
+
+func TestAllocatedPorts(t *testing.T) {
+	requested := nat.PortMap{
+		"26657/tcp": {},
+		"9090/tcp":  {{HostIP: "0.0.0.0", HostPort: "30000"}},
+	}
+	bindings := nat.PortMap{
+		"26657/tcp": {{HostIP: "0.0.0.0", HostPort: "21234"}},
+		"9090/tcp":  {{HostIP: "0.0.0.0", HostPort: "30000"}},
+	}
+	require.Equal(t, []int{21234}, allocatedPorts(requested, bindings))
+}
+
+func TestHostPortOverrideBindings(t *testing.T) {
+	cfg := ibc.ChainConfig{HostPortOverride: map[int]int{26657: 36657}}
+
+	require.Equal(t, nat.PortMap{"26657/tcp": {{HostPort: "36657"}}}, HostPortOverrideBindings(cfg, 0))
+	require.Nil(t, HostPortOverrideBindings(cfg, 1))
+
+	cfg.HostPortOverrideStride = 10
+	require.Equal(t, nat.PortMap{"26657/tcp": {{HostPort: "36677"}}}, HostPortOverrideBindings(cfg, 2))
+
+	require.Nil(t, HostPortOverrideBindings(ibc.ChainConfig{HostPortOverrideStride: 10}, 0))
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package dockerutil
+
+import "os"
+
+// tryLockFile opens the file at path without locking it,
+// so ports are only coordinated within the process on platforms without flock.
+// Conflicts with other processes are handled by retrying container starts.
+func tryLockFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o666)
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 )
 
 var mu sync.RWMutex
@@ -54,38 +55,69 @@ func GetPort(port int) (nat.PortBinding, *net.TCPListener, error) {
 	}, l, nil
 }
 
-// GeneratePortBindings will find open ports on the local
-// machine and create a PortBinding for every port in the portSet.
-// If a port is already bound, it will use that port as an override.
+// GeneratePortBindings creates a PortBinding for every port in pairs.
+// Ports without a binding get a host port from DefaultPortAllocator,
+// and ports with a binding use its host port as an override.
+//
+// No listeners are returned: allocated ports are reserved by the allocator rather than by holding them open,
+// so there is no window between closing a listener and Docker binding the port in which another test can take it.
+// Return the allocated ports with DefaultPortAllocator.Release once the container is removed.
 func GeneratePortBindings(pairs nat.PortMap) (nat.PortMap, Listeners, error) {
-	m := make(nat.PortMap)
-	listeners := make(Listeners, 0, len(pairs))
-
-	var pb nat.PortBinding
-	var l *net.TCPListener
-	var err error
+	m := make(nat.PortMap, len(pairs))
 
+	var random []nat.Port
 	for p, bind := range pairs {
 		if len(bind) == 0 {
-			// random port
-			pb, l, err = GetPort(0)
-		} else {
-			var pNum int
-			if pNum, err = strconv.Atoi(bind[0].HostPort); err != nil {
-				return nat.PortMap{}, nil, err
-			}
-
-			pb, l, err = GetPort(pNum)
+			random = append(random, p)
+			continue
 		}
-
-		if err != nil {
-			listeners.CloseAll()
+		if _, err := strconv.Atoi(bind[0].HostPort); err != nil {
 			return nat.PortMap{}, nil, err
 		}
+		m[p] = []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: bind[0].HostPort}}
+	}
 
-		listeners = append(listeners, l)
-		m[p] = []nat.PortBinding{pb}
+	ports, err := DefaultPortAllocator.Allocate(len(random))
+	if err != nil {
+		return nat.PortMap{}, nil, err
+	}
+	for i, p := range random {
+		m[p] = []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: strconv.Itoa(ports[i])}}
 	}
 
-	return m, listeners, nil
+	return m, nil, nil
+}
+
+// allocatedPorts returns the host ports of bindings that GeneratePortBindings(requested) allocated,
+// leaving out overrides.
+func allocatedPorts(requested, bindings nat.PortMap) []int {
+	var ports []int
+	for p, bs := range bindings {
+		if len(requested[p]) > 0 {
+			continue
+		}
+		for _, b := range bs {
+			if port, err := strconv.Atoi(b.HostPort); err == nil {
+				ports = append(ports, port)
+			}
+		}
+	}
+	return ports
+}
+
+// HostPortOverrideBindings returns the bindings of cfg.HostPortOverride for the node at position
+// among the nodes of a chain, ordered validators first.
+// Unless cfg.HostPortOverrideStride is set, only the node at position 0 has bindings.
+func HostPortOverrideBindings(cfg ibc.ChainConfig, position int) nat.PortMap {
+	if len(cfg.HostPortOverride) == 0 || (position > 0 && cfg.HostPortOverrideStride <= 0) {
+		return nil
+	}
+
+	m := make(nat.PortMap, len(cfg.HostPortOverride))
+	for intP, extP := range cfg.HostPortOverride {
+		m[nat.Port(fmt.Sprintf("%d/tcp", intP))] = []nat.PortBinding{
+			{HostPort: strconv.Itoa(extP + position*cfg.HostPortOverrideStride)},
+		}
+	}
+	return m
 }
@@ -0,0 +1,1080 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"go.uber.org/zap"
+)
+
+// ProcessRuntime is the ContainerRuntime running containers as processes of the host,
+// so that simple tests skip pulling images and starting containers.
+// The directories of a test live under a temporary directory of its own, created by ProcessSetup.
+//
+// Docker concepts map to the host as follows:
+//   - A container runs its entrypoint and command with binaries from BinDir or PATH.
+//     The image is only a name, so an image's own entrypoint is not known.
+//   - A volume is a directory. Container paths on volumes, in arguments and environment variables,
+//     are rewritten to the directories of the volumes. HOME is the first volume of the container,
+//     where images put the home of their user.
+//   - Addresses of containers and their container ports, in arguments, environment variables and files
+//     copied into containers, are rewritten to 127.0.0.1 and the published host ports.
+//     A container's own ports on 0.0.0.0 are rewritten to its host ports,
+//     so the process listens on the host ports.
+//   - Networks do not exist; every process shares the host's network.
+//
+// Processes run as the user running the test, whatever the user of the container.
+// Copying outside of volumes, resource limits, stats, and building, saving or loading images are not supported.
+type ProcessRuntime struct {
+	cli *client.Client
+	dir string
+
+	// BinDir holds the binaries built by BuildBinary.
+	// It is searched before PATH, and put first in the PATH of processes.
+	BinDir string
+
+	mu       sync.Mutex
+	procs    map[string]*hostProcess
+	volumes  map[string]map[string]string // Labels by volume name.
+	networks map[string]map[string]string // Labels by network name.
+}
+
+// hostProcess is a container of a ProcessRuntime.
+type hostProcess struct {
+	name    string
+	dir     string // Holds the logs, and is the home of containers without volumes.
+	home    string
+	cfg     *container.Config
+	hostCfg *container.HostConfig
+	mounts  []processMount // Longest container paths first.
+
+	cmd   *exec.Cmd
+	done  chan struct{} // Closed once cmd exits.
+	state types.ContainerState
+}
+
+// processMount is a directory of the host mounted at a path of a container.
+type processMount struct {
+	dst, src string
+}
+
+// NewProcessRuntime returns a ProcessRuntime keeping its directories under dir.
+func NewProcessRuntime(dir string) (*ProcessRuntime, error) {
+	// Only a handle to find the runtime with RuntimeFor; there is no Docker daemon behind it.
+	cli, err := client.NewClientWithOpts(client.WithHost("unix:///interchaintest/process.sock"))
+	if err != nil {
+		return nil, fmt.Errorf("create process client handle: %w", err)
+	}
+
+	r := &ProcessRuntime{
+		cli:      cli,
+		dir:      dir,
+		BinDir:   filepath.Join(dir, "bin"),
+		procs:    make(map[string]*hostProcess),
+		volumes:  make(map[string]map[string]string),
+		networks: make(map[string]map[string]string),
+	}
+	for _, d := range []string{r.BinDir, r.volumesDir(), r.containersDir()} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// ProcessSetup returns a client handle and network ID associated with t, running containers as host processes,
+// to be used in place of the client and network ID returned by DockerSetup.
+// DockerSetup returns them itself when ContainerRuntimeName is "process".
+//
+// The directories of the test are removed when t completes,
+// unless KEEP_CONTAINERS is set or volumes are kept on failure.
+//
+// If any part of the setup fails, ProcessSetup panics because the test cannot continue.
+func ProcessSetup(t DockerSetupTestingT) (*client.Client, string) {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", fmt.Sprintf("%s-%s-", ICTDockerPrefix, SanitizeContainerName(t.Name())))
+	if err != nil {
+		panic(fmt.Errorf("failed to create process runtime directory: %v", err))
+	}
+	rt, err := NewProcessRuntime(dir)
+	if err != nil {
+		panic(err)
+	}
+	cli := rt.Client()
+	runtimes.Store(cli, rt)
+	// Registered first so that it runs after the processes are stopped.
+	t.Cleanup(func() { forgetClient(cli) })
+	t.Cleanup(rt.cleanup(t))
+
+	networkID, err := rt.CreateNetwork(context.TODO(), fmt.Sprintf("%s-%s", ICTDockerPrefix, RandLowerCaseLetterString(8)), nil)
+	if err != nil {
+		panic(err)
+	}
+	return cli, networkID
+}
+
+// cleanup collects artifacts and shows the logs of the containers of t as DockerCleanup does,
+// then stops them and removes their directories.
+func (r *ProcessRuntime) cleanup(t DockerSetupTestingT) func() {
+	return func() {
+		showContainerLogs := os.Getenv("SHOW_CONTAINER_LOGS")
+		keepContainers := os.Getenv("KEEP_CONTAINERS") != ""
+		logTail := 50
+		if n, err := strconv.Atoi(os.Getenv("CONTAINER_LOG_TAIL")); err == nil {
+			logTail = n
+		}
+
+		ctx := context.TODO()
+
+		// Collect artifacts before any containers or volumes are removed.
+		artifactFiles, artifactReporters := takeArtifactRegistrations(t.Name())
+		if t.Failed() && CollectArtifactsOnFailure {
+			collectArtifacts(ctx, t, r, artifactFiles, artifactReporters)
+		}
+
+		r.mu.Lock()
+		procs := make([]*hostProcess, 0, len(r.procs))
+		for _, p := range r.procs {
+			procs = append(procs, p)
+		}
+		r.mu.Unlock()
+		sort.Slice(procs, func(i, j int) bool { return procs[i].name < procs[j].name })
+
+		for _, p := range procs {
+			if (t.Failed() && showContainerLogs == "") || showContainerLogs == "always" {
+				stdout, stderr, err := r.ContainerLogs(ctx, p.name, logTail)
+				if err == nil {
+					t.Logf("\n\nContainer logs - {%s}\n%s%s", p.name, stdout, stderr)
+				}
+			}
+			if err := r.StopContainer(ctx, p.name, 10*time.Second); err != nil {
+				t.Logf("Failed to stop process of container %s: %v", p.name, err)
+			}
+			DefaultPortAllocator.Release(allocatedPorts(nil, p.hostCfg.PortBindings)...)
+		}
+
+		if keepContainers || (KeepVolumesOnFailure && t.Failed()) {
+			t.Logf("Keeping process runtime directory %s", r.dir)
+			return
+		}
+		if err := os.RemoveAll(r.dir); err != nil {
+			t.Logf("Failed to remove process runtime directory %s: %v", r.dir, err)
+		}
+	}
+}
+
+// Client returns the handle passed in place of a Docker client to functions taking one.
+func (r *ProcessRuntime) Client() *client.Client { return r.cli }
+
+// Dir returns the directory holding the volumes, logs and binaries of the runtime.
+func (r *ProcessRuntime) Dir() string { return r.dir }
+
+func (r *ProcessRuntime) Name() string { return RuntimeProcess }
+
+func (r *ProcessRuntime) RunsOnHost() bool { return true }
+
+func (r *ProcessRuntime) volumesDir() string    { return filepath.Join(r.dir, "volumes") }
+func (r *ProcessRuntime) containersDir() string { return filepath.Join(r.dir, "containers") }
+
+// BuildBinary builds the binary of opts with `go build` into BinDir, where processes find it before PATH.
+// Only the fields of opts applying to the generated Dockerfile are used, except GoVersion, BaseImage and Packages;
+// the go toolchain and libraries of the host are used instead.
+func (r *ProcessRuntime) BuildBinary(ctx context.Context, log *zap.Logger, opts BuildOptions) (string, error) {
+	if opts.ContextDir == "" || opts.Binary == "" {
+		return "", errors.New("ContextDir and Binary must be set to build a binary")
+	}
+	if opts.Dockerfile != "" {
+		return "", errProcessUnsupported("building a Dockerfile")
+	}
+
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = "./cmd/" + opts.Binary
+	}
+	out := filepath.Join(r.BinDir, opts.Binary)
+	args := []string{"build", "-o", out}
+	if len(opts.BuildTags) > 0 {
+		args = append(args, "-tags", strings.Join(opts.BuildTags, ","))
+	}
+	if opts.LDFlags != "" {
+		args = append(args, "-ldflags", opts.LDFlags)
+	}
+	args = append(args, pkg)
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = opts.ContextDir
+	cmd.Env = os.Environ()
+	if opts.CgoDisabled {
+		cmd.Env = append(cmd.Env, "CGO_ENABLED=0")
+	}
+	log.Info("Building binary", zap.String("binary", out), zap.String("context", opts.ContextDir))
+	if b, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("build %s: %w\n%s", opts.Binary, err, tailLines(b, buildOutputTail))
+	}
+	return out, nil
+}
+
+// BuildChain builds the binary of opts with BuildBinary, as containers run binaries of the host.
+func (r *ProcessRuntime) BuildChain(ctx context.Context, log *zap.Logger, opts BuildOptions) error {
+	_, err := r.BuildBinary(ctx, log, opts)
+	return err
+}
+
+// ImageExists reports true, because containers run binaries of the host.
+func (r *ProcessRuntime) ImageExists(context.Context, string) (bool, error) { return true, nil }
+
+// PullImage does nothing, because containers run binaries of the host.
+func (r *ProcessRuntime) PullImage(context.Context, string) error { return nil }
+
+func (r *ProcessRuntime) SaveImages(context.Context, []string) (io.ReadCloser, error) {
+	return nil, errProcessUnsupported("saving images")
+}
+
+func (r *ProcessRuntime) LoadImages(context.Context, io.Reader) error {
+	return errProcessUnsupported("loading images")
+}
+
+func (r *ProcessRuntime) BuildImage(context.Context, io.Reader, types.ImageBuildOptions) (io.ReadCloser, error) {
+	return nil, errProcessUnsupported("building images")
+}
+
+func errProcessUnsupported(what string) error {
+	return errdefs.NotImplemented(fmt.Errorf("%s is not supported by the process runtime", what))
+}
+
+// CreateContainer records the container and creates its directory and volumes.
+func (r *ProcessRuntime) CreateContainer(_ context.Context, name string, cfg *container.Config, hostCfg *container.HostConfig, _ *network.NetworkingConfig) (string, error) {
+	if hostCfg == nil {
+		hostCfg = &container.HostConfig{}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.procs[name]; ok {
+		return "", errdefs.Conflict(fmt.Errorf("container %s already exists", name))
+	}
+
+	p := &hostProcess{
+		name:    name,
+		dir:     filepath.Join(r.containersDir(), name),
+		cfg:     cfg,
+		hostCfg: hostCfg,
+		state:   types.ContainerState{Status: "created"},
+	}
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return "", err
+	}
+	mounts, err := r.processMounts(p)
+	if err != nil {
+		_ = os.RemoveAll(p.dir)
+		return "", err
+	}
+	p.mounts = mounts
+	p.home = p.dir
+	if len(mounts) > 0 {
+		p.home = mounts[0].src
+	}
+	sort.SliceStable(p.mounts, func(i, j int) bool { return len(p.mounts[i].dst) > len(p.mounts[j].dst) })
+
+	r.procs[name] = p
+	return name, nil
+}
+
+// processMounts returns the directories of the binds and mounts of p in order, creating the missing ones.
+func (r *ProcessRuntime) processMounts(p *hostProcess) ([]processMount, error) {
+	var mounts []processMount
+	for _, b := range p.hostCfg.Binds {
+		parts := strings.Split(b, ":")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("invalid bind %q", b)
+		}
+		src := parts[0]
+		if !filepath.IsAbs(src) {
+			src = filepath.Join(r.volumesDir(), src)
+		}
+		mounts = append(mounts, processMount{dst: path.Clean(parts[1]), src: src})
+	}
+	for i, m := range p.hostCfg.Mounts {
+		var src string
+		switch m.Type {
+		case mount.TypeBind:
+			src = m.Source
+		case mount.TypeVolume:
+			src = filepath.Join(r.volumesDir(), m.Source)
+		case mount.TypeTmpfs:
+			src = filepath.Join(p.dir, "tmpfs"+strconv.Itoa(i))
+		default:
+			return nil, errProcessUnsupported(fmt.Sprintf("mounts of type %s", m.Type))
+		}
+		mounts = append(mounts, processMount{dst: path.Clean(m.Target), src: src})
+	}
+
+	for _, m := range mounts {
+		if err := os.MkdirAll(m.src, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return mounts, nil
+}
+
+func (r *ProcessRuntime) process(id string) (*hostProcess, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.procs[strings.TrimPrefix(id, "/")]
+	if !ok {
+		return nil, errdefs.NotFound(fmt.Errorf("no such container: %s", id))
+	}
+	return p, nil
+}
+
+// StartContainer starts the process of the container, appending its output to the logs of the container.
+// Starting a running container is not an error.
+func (r *ProcessRuntime) StartContainer(_ context.Context, id string) error {
+	p, err := r.process(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	running := p.state.Running
+	r.mu.Unlock()
+	if running {
+		return nil
+	}
+
+	argv := append(append([]string(nil), p.cfg.Entrypoint...), p.cfg.Cmd...)
+	if len(argv) == 0 {
+		return fmt.Errorf("container %s has no command to run", p.name)
+	}
+	cmd, err := r.command(p, argv, nil)
+	if err != nil {
+		return err
+	}
+
+	stdout, err := os.OpenFile(filepath.Join(p.dir, "stdout"), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	stderr, err := os.OpenFile(filepath.Join(p.dir, "stderr"), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		_ = stdout.Close()
+		return err
+	}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		_, _ = stdout.Close(), stderr.Close()
+		return fmt.Errorf("start process of container %s: %w", p.name, err)
+	}
+
+	done := make(chan struct{})
+	r.mu.Lock()
+	p.cmd, p.done = cmd, done
+	p.state = types.ContainerState{
+		Status:    "running",
+		Running:   true,
+		Pid:       cmd.Process.Pid,
+		StartedAt: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	r.mu.Unlock()
+
+	go func() {
+		err := cmd.Wait()
+		_, _ = stdout.Close(), stderr.Close()
+
+		exitCode := 0
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+			if exitCode < 0 {
+				// Killed by a signal, reported as by a shell.
+				exitCode = 128 + exitSignal(exitErr)
+			}
+		}
+
+		r.mu.Lock()
+		p.state.Status = "exited"
+		p.state.Running = false
+		p.state.Paused = false
+		p.state.Pid = 0
+		p.state.ExitCode = exitCode
+		p.state.FinishedAt = time.Now().UTC().Format(time.RFC3339Nano)
+		r.mu.Unlock()
+		close(done)
+	}()
+	return nil
+}
+
+// command returns the command running argv in the container of p, with the additional environment variables env.
+func (r *ProcessRuntime) command(p *hostProcess, argv, env []string) (*exec.Cmd, error) {
+	rewrite := r.rewriter(p)
+	args := make([]string, len(argv))
+	for i, a := range argv {
+		args[i] = rewrite(a)
+	}
+
+	bin, err := r.lookPath(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("container %s: %w", p.name, err)
+	}
+
+	environ := append(os.Environ(),
+		"PATH="+r.BinDir+string(os.PathListSeparator)+os.Getenv("PATH"),
+		"HOME="+p.home,
+	)
+	for _, e := range append(append([]string(nil), p.cfg.Env...), env...) {
+		environ = append(environ, rewrite(e))
+	}
+
+	cmd := exec.Command(bin, args[1:]...)
+	cmd.Env = environ
+	cmd.Dir = p.home
+	if p.cfg.WorkingDir != "" {
+		cmd.Dir = p.hostPathOrSelf(p.cfg.WorkingDir)
+	}
+	return cmd, nil
+}
+
+// lookPath finds the binary name in BinDir, or else in PATH.
+func (r *ProcessRuntime) lookPath(name string) (string, error) {
+	if strings.Contains(name, "/") {
+		return name, nil
+	}
+	p := filepath.Join(r.BinDir, name)
+	if fi, err := os.Stat(p); err == nil && !fi.IsDir() && fi.Mode()&0o111 != 0 {
+		return p, nil
+	}
+	p, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("binary %s not found in %s or PATH: %w", name, r.BinDir, err)
+	}
+	return p, nil
+}
+
+// StopContainer terminates the process of the container, killing it if it is still running after timeout.
+func (r *ProcessRuntime) StopContainer(ctx context.Context, id string, timeout time.Duration) error {
+	p, err := r.process(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	cmd, done, running, paused := p.cmd, p.done, p.state.Running, p.state.Paused
+	r.mu.Unlock()
+	if !running {
+		return nil
+	}
+
+	if paused {
+		_ = pauseProcess(cmd, false)
+	}
+	_ = terminateProcess(cmd, false)
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+	case <-ctx.Done():
+	}
+
+	_ = terminateProcess(cmd, true)
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// KillContainer kills the process group of the container with SIGKILL and waits for it to exit.
+func (r *ProcessRuntime) KillContainer(ctx context.Context, id string) error {
+	p, err := r.process(id)
+	if errdefs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	cmd, done, running := p.cmd, p.done, p.state.Running
+	r.mu.Unlock()
+	if !running {
+		return nil
+	}
+
+	_ = terminateProcess(cmd, true)
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitContainer blocks until the process of the container exits.
+// Containers created with AutoRemove are removed once they exit.
+func (r *ProcessRuntime) WaitContainer(ctx context.Context, id string) (int, error) {
+	p, err := r.process(id)
+	if err != nil {
+		return -1, err
+	}
+
+	r.mu.Lock()
+	done := p.done
+	r.mu.Unlock()
+	if done != nil {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		}
+	}
+
+	r.mu.Lock()
+	exitCode := p.state.ExitCode
+	r.mu.Unlock()
+
+	if p.hostCfg.AutoRemove {
+		if err := r.RemoveContainer(ctx, id); err != nil {
+			return exitCode, err
+		}
+	}
+	return exitCode, nil
+}
+
+// RemoveContainer kills the process of the container, and removes its logs and anonymous volumes.
+func (r *ProcessRuntime) RemoveContainer(ctx context.Context, id string) error {
+	p, err := r.process(id)
+	if errdefs.IsNotFound(err) {
+		return nil
+	}
+	if err := r.StopContainer(ctx, id, 0); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	delete(r.procs, p.name)
+	r.mu.Unlock()
+	return os.RemoveAll(p.dir)
+}
+
+// Exec runs cmd as another process of the running container.
+func (r *ProcessRuntime) Exec(ctx context.Context, id string, cmd []string, env []string) ContainerExecResult {
+	p, err := r.process(id)
+	if err != nil {
+		return ContainerExecResult{Err: err, ExitCode: -1}
+	}
+
+	r.mu.Lock()
+	running := p.state.Running
+	r.mu.Unlock()
+	if !running {
+		return ContainerExecResult{Err: errdefs.Conflict(fmt.Errorf("container %s is not running", p.name)), ExitCode: -1}
+	}
+
+	c, err := r.command(p, cmd, env)
+	if err != nil {
+		return ContainerExecResult{Err: err, ExitCode: -1}
+	}
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	if err := c.Start(); err != nil {
+		return ContainerExecResult{Err: fmt.Errorf("exec in container %s: %w", p.name, err), ExitCode: -1}
+	}
+	waited := make(chan error, 1)
+	go func() { waited <- c.Wait() }()
+
+	select {
+	case err = <-waited:
+	case <-ctx.Done():
+		_ = c.Process.Kill()
+		<-waited
+		return ContainerExecResult{Err: ctx.Err(), ExitCode: -1}
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return ContainerExecResult{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	case errors.As(err, &exitErr):
+		return ContainerExecResult{
+			Err:      fmt.Errorf("exit code %d: %s", exitErr.ExitCode(), stderr.String()),
+			ExitCode: exitErr.ExitCode(),
+			Stdout:   stdout.Bytes(),
+			Stderr:   stderr.Bytes(),
+		}
+	default:
+		return ContainerExecResult{Err: fmt.Errorf("exec in container %s: %w", p.name, err), ExitCode: -1}
+	}
+}
+
+func (r *ProcessRuntime) InspectContainer(_ context.Context, id string) (types.ContainerJSON, error) {
+	p, err := r.process(id)
+	if err != nil {
+		return types.ContainerJSON{}, err
+	}
+
+	r.mu.Lock()
+	state := p.state
+	r.mu.Unlock()
+
+	var ports nat.PortMap
+	if state.Running {
+		ports = p.hostCfg.PortBindings
+	}
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:         p.name,
+			Name:       "/" + p.name,
+			Args:       append(append([]string(nil), p.cfg.Entrypoint...), p.cfg.Cmd...),
+			Image:      p.cfg.Image,
+			State:      &state,
+			HostConfig: p.hostCfg,
+		},
+		Config: p.cfg,
+		NetworkSettings: &types.NetworkSettings{
+			NetworkSettingsBase: types.NetworkSettingsBase{Ports: ports},
+		},
+	}, nil
+}
+
+// ContainerLogs returns the output of every start of the container.
+func (r *ProcessRuntime) ContainerLogs(_ context.Context, id string, tail int) ([]byte, []byte, error) {
+	p, err := r.process(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var logs [2][]byte
+	for i, name := range []string{"stdout", "stderr"} {
+		b, err := os.ReadFile(filepath.Join(p.dir, name))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, err
+		}
+		if tail > 0 {
+			b = tailLines(b, tail)
+		}
+		logs[i] = b
+	}
+	return logs[0], logs[1], nil
+}
+
+// PauseContainer stops the process group of the container until it is unpaused.
+func (r *ProcessRuntime) WriteLogs(ctx context.Context, id string, w io.Writer) error {
+	return writeSeparateLogs(ctx, r, id, w)
+}
+
+func (r *ProcessRuntime) PauseContainer(_ context.Context, id string) error {
+	return r.setPaused(id, true)
+}
+
+func (r *ProcessRuntime) UnpauseContainer(_ context.Context, id string) error {
+	return r.setPaused(id, false)
+}
+
+func (r *ProcessRuntime) setPaused(id string, paused bool) error {
+	p, err := r.process(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !p.state.Running {
+		return errdefs.Conflict(fmt.Errorf("container %s is not running", p.name))
+	}
+	if err := pauseProcess(p.cmd, paused); err != nil {
+		return err
+	}
+	p.state.Paused = paused
+	if paused {
+		p.state.Status = "paused"
+	} else {
+		p.state.Status = "running"
+	}
+	return nil
+}
+
+// ListContainers returns the containers created through r, since the directory of r belongs to a single test.
+func (r *ProcessRuntime) ListContainers(_ context.Context, labels map[string]string) ([]types.Container, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []types.Container
+	for _, p := range r.procs {
+		if hasLabels(p.cfg.Labels, labels) {
+			out = append(out, types.Container{
+				ID:     p.name,
+				Names:  []string{"/" + p.name},
+				Image:  p.cfg.Image,
+				Labels: p.cfg.Labels,
+				Mounts: volumeMountPoints(p.hostCfg),
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// CreateVolume creates a directory for the volume.
+func (r *ProcessRuntime) CreateVolume(_ context.Context, labels map[string]string) (string, error) {
+	name := fmt.Sprintf("%s-vol-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+	if err := os.Mkdir(filepath.Join(r.volumesDir(), name), 0o755); err != nil {
+		return "", fmt.Errorf("create volume: %w", err)
+	}
+	r.mu.Lock()
+	r.volumes[name] = labels
+	r.mu.Unlock()
+	return name, nil
+}
+
+func (r *ProcessRuntime) RemoveVolume(_ context.Context, name string) error {
+	r.mu.Lock()
+	delete(r.volumes, name)
+	r.mu.Unlock()
+	return os.RemoveAll(filepath.Join(r.volumesDir(), name))
+}
+
+// ListVolumes returns the volumes created through r carrying labels.
+func (r *ProcessRuntime) ListVolumes(_ context.Context, labels map[string]string) ([]*volume.Volume, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*volume.Volume
+	for name, l := range r.volumes {
+		if hasLabels(l, labels) {
+			out = append(out, &volume.Volume{Name: name, Labels: l, Mountpoint: r.VolumePath(name)})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// VolumePath returns the directory of the volume named name.
+func (r *ProcessRuntime) VolumePath(name string) string {
+	return filepath.Join(r.volumesDir(), name)
+}
+
+// CreateNetwork records the labels of the network and returns name, since processes share the host's network.
+func (r *ProcessRuntime) CreateNetwork(_ context.Context, name string, labels map[string]string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	r.networks[name] = labels
+	return name, nil
+}
+
+// RemoveNetwork forgets the network, since processes share the host's network.
+func (r *ProcessRuntime) RemoveNetwork(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.networks, id)
+	return nil
+}
+
+// ListNetworks returns the networks created through r.
+func (r *ProcessRuntime) ListNetworks(_ context.Context, labels map[string]string) ([]types.NetworkResource, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []types.NetworkResource
+	for name, l := range r.networks {
+		if hasLabels(l, labels) {
+			out = append(out, types.NetworkResource{ID: name, Name: name, Labels: l})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// CopyToContainer extracts content to the directory of the volume holding dstDir.
+// Addresses of containers in node configuration files are rewritten as in the arguments of processes.
+func (r *ProcessRuntime) CopyToContainer(_ context.Context, id, dstDir string, content io.Reader) error {
+	p, err := r.process(id)
+	if err != nil {
+		return err
+	}
+	dir, ok := p.hostPath(dstDir)
+	if !ok {
+		return errProcessUnsupported("copying outside of volumes into a container")
+	}
+	return extractTar(p.volumeDir(dstDir), dir, content, r.rewriter(nil))
+}
+
+// CopyFromContainer returns a tar stream of srcPath, which must be on a volume of the container.
+func (r *ProcessRuntime) CopyFromContainer(_ context.Context, id, srcPath string) (io.ReadCloser, error) {
+	p, err := r.process(id)
+	if err != nil {
+		return nil, err
+	}
+	src, ok := p.hostPath(srcPath)
+	if !ok {
+		return nil, errProcessUnsupported("copying outside of volumes from a container")
+	}
+	if _, err := os.Lstat(src); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errdefs.NotFound(fmt.Errorf("could not find the file %s in container %s", srcPath, p.name))
+		}
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() { pw.CloseWithError(archivePath(pw, src)) }()
+	return pr, nil
+}
+
+// Stats is not supported by the process runtime.
+func (r *ProcessRuntime) Stats(context.Context, string) (ContainerStats, error) {
+	return ContainerStats{}, errProcessUnsupported("stats")
+}
+
+// hostPath returns the path on the host of the path ctrPath of the container,
+// and whether ctrPath is on a volume.
+func (p *hostProcess) hostPath(ctrPath string) (string, bool) {
+	ctrPath = path.Clean(ctrPath)
+	for _, m := range p.mounts {
+		if ctrPath == m.dst {
+			return m.src, true
+		}
+		if rel, ok := strings.CutPrefix(ctrPath, strings.TrimSuffix(m.dst, "/")+"/"); ok {
+			return filepath.Join(m.src, filepath.FromSlash(rel)), true
+		}
+	}
+	return ctrPath, false
+}
+
+// volumeDir returns the directory of the volume holding ctrPath, which must be on a volume of p.
+func (p *hostProcess) volumeDir(ctrPath string) string {
+	ctrPath = path.Clean(ctrPath)
+	for _, m := range p.mounts {
+		if ctrPath == m.dst || strings.HasPrefix(ctrPath, strings.TrimSuffix(m.dst, "/")+"/") {
+			return m.src
+		}
+	}
+	return ""
+}
+
+// hostPathOrSelf returns the path on the host of ctrPath, which is ctrPath itself outside of volumes.
+func (p *hostProcess) hostPathOrSelf(ctrPath string) string {
+	hp, _ := p.hostPath(ctrPath)
+	return hp
+}
+
+// rewritePaths rewrites the container paths on the volumes of p, anywhere in s, to paths of the host.
+func (p *hostProcess) rewritePaths(s string) string {
+	if len(p.mounts) == 0 {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if i == 0 || !isPathChar(s[i-1]) {
+			matched := false
+			for _, m := range p.mounts {
+				rest, ok := strings.CutPrefix(s[i:], m.dst)
+				if !ok || (rest != "" && rest[0] != '/' && isPathChar(rest[0])) {
+					continue
+				}
+				b.WriteString(m.src)
+				i += len(m.dst)
+				matched = true
+				break
+			}
+			if matched {
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
+
+func isPathChar(c byte) bool {
+	return c == '/' || c == '.' || c == '_' || c == '-' ||
+		('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+var processAddrRE = regexp.MustCompile(`[A-Za-z0-9_.-]+:[0-9]+`)
+
+// rewriter returns a function rewriting the strings a process of the container self sees.
+// Addresses of containers and container ports become 127.0.0.1 and the published host ports,
+// and self's own ports on 0.0.0.0 become its host ports on 0.0.0.0.
+// Paths on the volumes of self become the directories of the volumes. self may be nil.
+func (r *ProcessRuntime) rewriter(self *hostProcess) func(string) string {
+	r.mu.Lock()
+	hosts := make(map[string]nat.PortMap)
+	for _, p := range r.procs {
+		hosts[p.name] = p.hostCfg.PortBindings
+		if p.cfg.Hostname != "" {
+			hosts[p.cfg.Hostname] = p.hostCfg.PortBindings
+		}
+	}
+	r.mu.Unlock()
+
+	return func(s string) string {
+		s = processAddrRE.ReplaceAllStringFunc(s, func(addr string) string {
+			host, port, _ := strings.Cut(addr, ":")
+			ip, bindings := "127.0.0.1", hosts[host]
+			if host == "0.0.0.0" && self != nil {
+				ip, bindings = host, self.hostCfg.PortBindings
+			}
+			b := bindings[nat.Port(port+"/tcp")]
+			if len(b) == 0 || b[0].HostPort == "" {
+				return addr
+			}
+			return ip + ":" + b[0].HostPort
+		})
+		if self != nil {
+			s = self.rewritePaths(s)
+		}
+		return s
+	}
+}
+
+// rewrittenFiles are the names of the node configuration files whose addresses are rewritten
+// when copied into a container. Other files are copied unchanged.
+var rewrittenFiles = map[string]bool{
+	"config.toml": true,
+	"app.toml":    true,
+	"client.toml": true,
+}
+
+// extractTar extracts the tar stream r into dir, within the volume directory root,
+// applying rewrite to the content of the node configuration files.
+// Entries and symlink targets outside of root are rejected.
+func extractTar(root, dir string, r io.Reader, rewrite func(string) string) error {
+	root = filepath.Clean(root)
+	within := func(p string) bool {
+		return p == root || strings.HasPrefix(p, root+string(filepath.Separator))
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar: %w", err)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if !within(target) {
+			return fmt.Errorf("tar entry %s is outside of %s", hdr.Name, root)
+		}
+		mode := fs.FileMode(hdr.Mode).Perm()
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode|0o700); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			// Absolute targets resolve on the host, not within the volume.
+			if filepath.IsAbs(hdr.Linkname) || !within(filepath.Join(filepath.Dir(target), filepath.FromSlash(hdr.Linkname))) {
+				return fmt.Errorf("tar entry %s links to %s, outside of %s", hdr.Name, hdr.Linkname, root)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			_ = os.Remove(target)
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if rewrittenFiles[path.Base(hdr.Name)] {
+				b, err := io.ReadAll(tr)
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(target, []byte(rewrite(string(b))), mode|0o600); err != nil {
+					return err
+				}
+			} else if err := writeTarFile(tr, target, mode|0o600); err != nil {
+				return err
+			}
+			if err := os.Chmod(target, mode|0o600); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// archivePath writes a tar stream of src to w, naming entries relative to the parent of src as Docker does.
+func archivePath(w io.Writer, src string) error {
+	tw := tar.NewWriter(w)
+	parent := filepath.Dir(src)
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(parent, p)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
@@ -0,0 +1,21 @@
+//go:build !unix
+
+package dockerutil
+
+import "os/exec"
+
+// setProcessGroup does nothing on platforms without process groups.
+func setProcessGroup(*exec.Cmd) {}
+
+// terminateProcess kills the process of cmd, without its children.
+func terminateProcess(cmd *exec.Cmd, _ bool) error {
+	return cmd.Process.Kill()
+}
+
+// pauseProcess is not supported on platforms without job control signals.
+func pauseProcess(*exec.Cmd, bool) error {
+	return errProcessUnsupported("pausing containers on this platform")
+}
+
+// exitSignal returns 0, since signals are not reported on this platform.
+func exitSignal(*exec.ExitError) int { return 0 }
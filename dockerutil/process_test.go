@@ -0,0 +1,274 @@
+//go:build unix
+
+package dockerutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"github.com/strangelove-ventures/interchaintest/v8/mocktesting"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessRewritePaths(t *testing.T) {
+	p := &hostProcess{mounts: []processMount{
+		{dst: "/var/cosmos-chain/gaia-1", src: "/tmp/vol-1"},
+		{dst: "/home", src: "/tmp/vol-2"},
+	}}
+	for in, want := range map[string]string{
+		"/var/cosmos-chain/gaia-1":                   "/tmp/vol-1",
+		"--home=/var/cosmos-chain/gaia-1/config":     "--home=/tmp/vol-1/config",
+		"cat /home/a /home/b":                        "cat /tmp/vol-2/a /tmp/vol-2/b",
+		"/var/cosmos-chain/gaia-10":                  "/var/cosmos-chain/gaia-10",
+		"/homes /x/home":                             "/homes /x/home",
+		"HOME=/home":                                 "HOME=/tmp/vol-2",
+		"tmp:/var/cosmos-chain/gaia-1:/home/relayer": "tmp:/tmp/vol-1:/tmp/vol-2/relayer",
+	} {
+		require.Equal(t, want, p.rewritePaths(in), in)
+	}
+}
+
+func TestProcessExtractTar(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "home")
+	rewrite := func(s string) string { return strings.ReplaceAll(s, "node-1:26657", "127.0.0.1:20001") }
+
+	type entry struct {
+		hdr  tar.Header
+		body string
+	}
+	archive := func(entries ...entry) io.Reader {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, e := range entries {
+			e.hdr.Mode, e.hdr.Size = 0o644, int64(len(e.body))
+			require.NoError(t, tw.WriteHeader(&e.hdr))
+			_, err := tw.Write([]byte(e.body))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return &buf
+	}
+	file := func(name, body string) entry {
+		return entry{hdr: tar.Header{Name: name, Typeflag: tar.TypeReg}, body: body}
+	}
+	link := func(name, target string) entry {
+		return entry{hdr: tar.Header{Name: name, Typeflag: tar.TypeSymlink, Linkname: target}}
+	}
+
+	const cfg = `laddr = "tcp://node-1:26657"`
+	r := archive(file("config/config.toml", cfg), file("config/genesis.json", cfg), link("data/cfg", "../config/config.toml"), link("up", ".."))
+	require.NoError(t, extractTar(root, dir, r, rewrite))
+
+	b, err := os.ReadFile(filepath.Join(dir, "config", "config.toml"))
+	require.NoError(t, err)
+	require.Equal(t, `laddr = "tcp://127.0.0.1:20001"`, string(b))
+	// Only the node configuration files are rewritten.
+	b, err = os.ReadFile(filepath.Join(dir, "config", "genesis.json"))
+	require.NoError(t, err)
+	require.Equal(t, cfg, string(b))
+	b, err = os.ReadFile(filepath.Join(dir, "data", "cfg"))
+	require.NoError(t, err)
+	require.Equal(t, `laddr = "tcp://127.0.0.1:20001"`, string(b))
+
+	for _, e := range []entry{
+		file("../../escape", ""),
+		link("escape", "../.."),
+		link("data/escape", "../../../etc/passwd"),
+		link("abs", "/etc/passwd"),
+	} {
+		require.ErrorContains(t, extractTar(root, dir, archive(e), rewrite), "outside of "+root, e.hdr.Name)
+	}
+	_, err = os.Lstat(filepath.Join(dir, "escape"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestProcessRuntime(t *testing.T) {
+	ctx := context.Background()
+	rt, err := NewProcessRuntime(t.TempDir())
+	require.NoError(t, err)
+
+	vol, err := rt.CreateVolume(ctx, map[string]string{CleanupLabel: t.Name()})
+	require.NoError(t, err)
+	vs, err := rt.ListVolumes(ctx, map[string]string{CleanupLabel: t.Name()})
+	require.NoError(t, err)
+	require.Len(t, vs, 1)
+	require.Equal(t, vol, vs[0].Name)
+
+	id, err := rt.CreateContainer(ctx, "node-1", &container.Config{
+		Hostname: "node-1-host",
+		Cmd: []string{"sh", "-c", `echo "$HOME" "$1" "$ADDR"; cat /home/node/config/config.toml; echo err >&2; exit 3`,
+			"_", "tcp://0.0.0.0:26657"},
+		Env:          []string{"ADDR=node-1-host:26657"},
+		ExposedPorts: nat.PortSet{"26657/tcp": {}},
+		Labels:       map[string]string{CleanupLabel: t.Name()},
+	}, &container.HostConfig{
+		Binds:        []string{vol + ":/home/node"},
+		PortBindings: nat.PortMap{"26657/tcp": {{HostIP: "0.0.0.0", HostPort: "20001"}}},
+	}, nil)
+	require.NoError(t, err)
+
+	_, err = rt.CreateContainer(ctx, "node-1", &container.Config{}, nil, nil)
+	require.True(t, errdefs.IsConflict(err))
+
+	cs, err := rt.ListContainers(ctx, map[string]string{CleanupLabel: t.Name()})
+	require.NoError(t, err)
+	require.Len(t, cs, 1)
+	require.Equal(t, []string{"/node-1"}, cs[0].Names)
+	require.Equal(t, []types.MountPoint{{Type: mount.TypeVolume, Name: vol, Destination: "/home/node"}}, cs[0].Mounts)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	cfg := `rpc = "http://node-1-host:26657"` + "\n"
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "config/config.toml", Mode: 0o600, Size: int64(len(cfg))}))
+	_, err = tw.Write([]byte(cfg))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, rt.CopyToContainer(ctx, id, "/home/node", &buf))
+	require.True(t, errdefs.IsNotImplemented(rt.CopyToContainer(ctx, id, "/etc", strings.NewReader(""))))
+
+	require.NoError(t, rt.StartContainer(ctx, id))
+	exitCode, err := rt.WaitContainer(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 3, exitCode)
+
+	stdout, stderr, err := rt.ContainerLogs(ctx, id, 0)
+	require.NoError(t, err)
+	require.Equal(t, rt.VolumePath(vol)+" tcp://0.0.0.0:20001 127.0.0.1:20001\n"+`rpc = "http://127.0.0.1:20001"`+"\n", string(stdout))
+	require.Equal(t, "err\n", string(stderr))
+
+	cj, err := rt.InspectContainer(ctx, id)
+	require.NoError(t, err)
+	require.False(t, cj.State.Running)
+	require.Equal(t, 3, cj.State.ExitCode)
+
+	rc, err := rt.CopyFromContainer(ctx, id, "/home/node/config/config.toml")
+	require.NoError(t, err)
+	tr := tar.NewReader(rc)
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "config.toml", hdr.Name)
+	b, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	require.Equal(t, `rpc = "http://127.0.0.1:20001"`+"\n", string(b))
+	require.NoError(t, rc.Close())
+
+	require.NoError(t, rt.RemoveContainer(ctx, id))
+	require.NoError(t, rt.RemoveContainer(ctx, id), "removing a missing container is not an error")
+	_, err = os.Stat(filepath.Join(rt.VolumePath(vol), "config", "config.toml"))
+	require.NoError(t, err, "volumes must outlive containers")
+}
+
+func TestProcessRuntimeExecAndStop(t *testing.T) {
+	ctx := context.Background()
+	rt, err := NewProcessRuntime(t.TempDir())
+	require.NoError(t, err)
+
+	id, err := rt.CreateContainer(ctx, "sleeper", &container.Config{
+		Entrypoint: []string{"sh", "-c"},
+		Cmd:        []string{"sleep 30"},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	res := rt.Exec(ctx, id, []string{"true"}, nil)
+	require.True(t, errdefs.IsConflict(res.Err), "exec requires a running container")
+
+	require.NoError(t, rt.StartContainer(ctx, id))
+	t.Cleanup(func() { _ = rt.RemoveContainer(ctx, id) })
+
+	res = rt.Exec(ctx, id, []string{"sh", "-c", `echo "$A"; exit 2`}, []string{"A=1"})
+	require.Equal(t, 2, res.ExitCode)
+	require.Equal(t, "1\n", string(res.Stdout))
+	require.Error(t, res.Err)
+
+	require.NoError(t, rt.PauseContainer(ctx, id))
+	cj, err := rt.InspectContainer(ctx, id)
+	require.NoError(t, err)
+	require.True(t, cj.State.Paused)
+	require.NoError(t, rt.UnpauseContainer(ctx, id))
+
+	start := time.Now()
+	require.NoError(t, rt.StopContainer(ctx, id, 10*time.Second))
+	require.Less(t, time.Since(start), 5*time.Second, "the process group must be terminated")
+
+	exitCode, err := rt.WaitContainer(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 143, exitCode)
+}
+
+func TestProcessRuntimeKill(t *testing.T) {
+	ctx := context.Background()
+	rt, err := NewProcessRuntime(t.TempDir())
+	require.NoError(t, err)
+
+	id, err := rt.CreateContainer(ctx, "sleeper", &container.Config{
+		Entrypoint: []string{"sh", "-c"},
+		// The process ignores SIGTERM, so only SIGKILL stops it.
+		Cmd: []string{"trap '' TERM; sleep 30"},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, rt.KillContainer(ctx, id), "killing a container that is not running is not an error")
+
+	require.NoError(t, rt.StartContainer(ctx, id))
+	t.Cleanup(func() { _ = rt.RemoveContainer(ctx, id) })
+
+	start := time.Now()
+	require.NoError(t, rt.KillContainer(ctx, id))
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	exitCode, err := rt.WaitContainer(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 137, exitCode)
+
+	require.NoError(t, rt.KillContainer(ctx, "missing"))
+}
+
+func TestProcessRuntimeArtifacts(t *testing.T) {
+	rt, err := NewProcessRuntime(t.TempDir())
+	require.NoError(t, err)
+	defer func(dir string) { ArtifactsDir = dir }(ArtifactsDir)
+	ArtifactsDir = t.TempDir()
+
+	ctx := context.Background()
+	const testName = "TestFoo"
+
+	vol, err := rt.CreateVolume(ctx, map[string]string{CleanupLabel: testName, NodeOwnerLabel: "gaia-val-0"})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(rt.VolumePath(vol), "config"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(rt.VolumePath(vol), "config", "genesis.json"), []byte("{}"), 0o644))
+
+	id, err := rt.CreateContainer(ctx, "gaia-val-0", &container.Config{
+		Cmd:    []string{"sh", "-c", "echo started"},
+		Labels: map[string]string{CleanupLabel: testName},
+	}, &container.HostConfig{Binds: []string{vol + ":/home/gaia"}}, nil)
+	require.NoError(t, err)
+	require.NoError(t, rt.StartContainer(ctx, id))
+	_, err = rt.WaitContainer(ctx, id)
+	require.NoError(t, err)
+
+	collectArtifacts(ctx, mocktesting.NewT(testName), rt, nil, nil)
+
+	dirs, err := os.ReadDir(ArtifactsDir)
+	require.NoError(t, err)
+	require.Len(t, dirs, 1)
+	dir := filepath.Join(ArtifactsDir, dirs[0].Name())
+
+	b, err := os.ReadFile(filepath.Join(dir, "volumes", "gaia-val-0", "config", "genesis.json"))
+	require.NoError(t, err)
+	require.Equal(t, "{}", string(b))
+	b, err = os.ReadFile(filepath.Join(dir, "logs", "gaia-val-0.log"))
+	require.NoError(t, err)
+	require.Equal(t, "started\n", string(b))
+}
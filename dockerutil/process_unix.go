@@ -0,0 +1,44 @@
+//go:build unix
+
+package dockerutil
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts cmd in a process group of its own,
+// so that signals reach the children of shell commands too.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// terminateProcess sends SIGTERM, or SIGKILL if force is set, to the process group of cmd.
+func terminateProcess(cmd *exec.Cmd, force bool) error {
+	sig := syscall.SIGTERM
+	if force {
+		sig = syscall.SIGKILL
+	}
+	return syscall.Kill(-cmd.Process.Pid, sig)
+}
+
+// pauseProcess stops or continues the process group of cmd.
+func pauseProcess(cmd *exec.Cmd, pause bool) error {
+	sig := syscall.SIGCONT
+	if pause {
+		sig = syscall.SIGSTOP
+	}
+	return syscall.Kill(-cmd.Process.Pid, sig)
+}
+
+// exitSignal returns the number of the signal that killed the process of err.
+func exitSignal(err *exec.ExitError) int {
+	var ws syscall.WaitStatus
+	if s, ok := err.Sys().(syscall.WaitStatus); ok {
+		ws = s
+	}
+	if !ws.Signaled() {
+		return 0
+	}
+	return int(ws.Signal())
+}
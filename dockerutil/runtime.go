@@ -0,0 +1,519 @@
+package dockerutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
+	"go.uber.org/zap"
+)
+
+// ContainerRuntime is the set of container engine operations interchaintest relies on.
+//
+// The Docker implementation is the default.
+// Set ICTEST_CONTAINER_RUNTIME=podman to run against Podman's Docker-compatible API instead,
+// ICTEST_CONTAINER_RUNTIME=kubernetes to run pods in a Kubernetes cluster,
+// or ICTEST_CONTAINER_RUNTIME=process to run processes of the host.
+type ContainerRuntime interface {
+	// Name identifies the runtime, e.g. "docker" or "podman".
+	Name() string
+	// RunsOnHost reports whether containers run as processes of the host:
+	// they share its network, listening on host ports, and run as the user owning the volumes.
+	RunsOnHost() bool
+
+	// ImageExists reports whether ref is present locally.
+	ImageExists(ctx context.Context, ref string) (bool, error)
+	// PullImage pulls the public image ref, blocking until the pull completes.
+	PullImage(ctx context.Context, ref string) error
+	// SaveImages returns a tar stream of the given images, suitable for LoadImages.
+	SaveImages(ctx context.Context, refs []string) (io.ReadCloser, error)
+	// LoadImages loads images from a tar stream, optionally gzip-compressed.
+	LoadImages(ctx context.Context, r io.Reader) error
+	// BuildImage builds an image from the tar stream of a build context.
+	// It returns the JSON message stream of the build, which reports build failures.
+	BuildImage(ctx context.Context, buildContext io.Reader, opts types.ImageBuildOptions) (io.ReadCloser, error)
+	// BuildChain builds the chain of opts from source, so that containers of opts.Image() run it.
+	BuildChain(ctx context.Context, log *zap.Logger, opts BuildOptions) error
+
+	CreateContainer(ctx context.Context, name string, cfg *container.Config, hostCfg *container.HostConfig, netCfg *network.NetworkingConfig) (id string, err error)
+	StartContainer(ctx context.Context, id string) error
+	// StopContainer stops the container, giving it up to timeout to exit.
+	// Stopping a container that is not running is not an error.
+	StopContainer(ctx context.Context, id string, timeout time.Duration) error
+	// KillContainer terminates the container with SIGKILL, without giving it a chance to shut down.
+	// Killing a container that is not running or no longer exists is not an error.
+	KillContainer(ctx context.Context, id string) error
+	// WaitContainer blocks until the container is no longer running and returns its exit code.
+	WaitContainer(ctx context.Context, id string) (exitCode int, err error)
+	// RemoveContainer force-removes the container and its anonymous volumes.
+	// Removing a missing container is not an error.
+	RemoveContainer(ctx context.Context, id string) error
+	// Exec runs cmd in the running container.
+	Exec(ctx context.Context, id string, cmd []string, env []string) ContainerExecResult
+	// InspectContainer returns the low-level details of the container, running or not.
+	InspectContainer(ctx context.Context, id string) (types.ContainerJSON, error)
+	// ContainerLogs returns the stdout and stderr of the container.
+	// If tail is positive, only the last tail lines are returned.
+	ContainerLogs(ctx context.Context, id string, tail int) (stdout, stderr []byte, err error)
+	// WriteLogs writes the stdout and stderr of the container to w.
+	// Runtimes keeping the order of the two streams interleave them, with timestamps;
+	// the others write stdout before stderr.
+	WriteLogs(ctx context.Context, id string, w io.Writer) error
+	PauseContainer(ctx context.Context, id string) error
+	UnpauseContainer(ctx context.Context, id string) error
+
+	// ListContainers returns the containers, running or not, carrying every label of labels.
+	// Only the ID, Names, Labels and the Mounts of named volumes of the returned containers are set by every runtime.
+	ListContainers(ctx context.Context, labels map[string]string) ([]types.Container, error)
+
+	// CreateVolume creates a volume with the given labels and returns its name.
+	CreateVolume(ctx context.Context, labels map[string]string) (name string, err error)
+	RemoveVolume(ctx context.Context, name string) error
+	// ListVolumes returns the volumes carrying every label of labels.
+	// Only the Name and Labels of the returned volumes are set by every runtime.
+	ListVolumes(ctx context.Context, labels map[string]string) ([]*volume.Volume, error)
+
+	// CreateNetwork creates a bridge network and returns its ID.
+	CreateNetwork(ctx context.Context, name string, labels map[string]string) (id string, err error)
+	RemoveNetwork(ctx context.Context, id string) error
+	// ListNetworks returns the networks carrying every label of labels.
+	// Only the ID, Name and Labels of the returned networks are set by every runtime.
+	ListNetworks(ctx context.Context, labels map[string]string) ([]types.NetworkResource, error)
+
+	// CopyToContainer extracts the tar stream content into dstDir within the container.
+	CopyToContainer(ctx context.Context, id, dstDir string, content io.Reader) error
+	// CopyFromContainer returns a tar stream of srcPath within the container.
+	CopyFromContainer(ctx context.Context, id, srcPath string) (io.ReadCloser, error)
+
+	// Stats returns a single sample of the container's resource usage.
+	Stats(ctx context.Context, id string) (ContainerStats, error)
+}
+
+// Supported values of ContainerRuntimeName.
+const (
+	RuntimeDocker     = "docker"
+	RuntimePodman     = "podman"
+	RuntimeKubernetes = "kubernetes"
+	RuntimeProcess    = "process"
+)
+
+// ContainerRuntimeName selects the runtime used by DockerSetup.
+//
+// The value is "docker" by default, and can be initialized by setting the
+// environment variable ICTEST_CONTAINER_RUNTIME.
+var ContainerRuntimeName = os.Getenv("ICTEST_CONTAINER_RUNTIME")
+
+// runtimes holds the runtimes of clients created by NewRuntimeClient,
+// so that functions taking a *client.Client use the matching implementation.
+var runtimes sync.Map // map[*client.Client]ContainerRuntime
+
+// forgetClient drops what the package holds for cli, once the test using it is done.
+func forgetClient(cli *client.Client) {
+	if rt, ok := runtimes.LoadAndDelete(cli); ok {
+		liveContainers.Delete(rt)
+	}
+}
+
+// NewRuntimeClient returns a client for the runtime named name, along with the runtime itself.
+// An empty name selects Docker.
+//
+// Podman serves a Docker-compatible API, so the returned client may be passed
+// anywhere a Docker client is accepted, and RuntimeFor returns the Podman runtime for it.
+func NewRuntimeClient(name string) (*client.Client, ContainerRuntime, error) {
+	var rt ContainerRuntime
+	var cli *client.Client
+	switch name {
+	case "", RuntimeDocker:
+		var err error
+		cli, err = client.NewClientWithOpts(client.FromEnv)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create docker client: %w", err)
+		}
+		rt = NewDockerRuntime(cli)
+	case RuntimePodman:
+		p, err := NewPodmanRuntime()
+		if err != nil {
+			return nil, nil, err
+		}
+		cli, rt = p.Client(), p
+	case RuntimeKubernetes:
+		return nil, nil, fmt.Errorf("the %s runtime is set up per test namespace by KubernetesSetup", name)
+	case RuntimeProcess:
+		return nil, nil, fmt.Errorf("the %s runtime is set up per test directory by ProcessSetup", name)
+	default:
+		return nil, nil, fmt.Errorf("unknown container runtime %q (want %q, %q, %q or %q)", name, RuntimeDocker, RuntimePodman, RuntimeKubernetes, RuntimeProcess)
+	}
+
+	runtimes.Store(cli, rt)
+	return cli, rt, nil
+}
+
+// CloseRuntimeClient closes cli, returned by NewRuntimeClient outside of DockerSetup, and forgets its runtime.
+func CloseRuntimeClient(cli *client.Client) error {
+	forgetClient(cli)
+	return cli.Close()
+}
+
+// RuntimeFor returns the runtime backing cli, for callers holding only the client returned by DockerSetup.
+// Clients not created by NewRuntimeClient are assumed to talk to Docker.
+func RuntimeFor(cli *client.Client) ContainerRuntime {
+	if rt, ok := runtimes.Load(cli); ok {
+		return rt.(ContainerRuntime)
+	}
+	rt, _ := runtimes.LoadOrStore(cli, NewDockerRuntime(cli))
+	return rt.(ContainerRuntime)
+}
+
+// DockerRuntime is the ContainerRuntime for the Docker engine.
+type DockerRuntime struct {
+	cli *client.Client
+}
+
+// NewDockerRuntime returns a DockerRuntime using cli.
+func NewDockerRuntime(cli *client.Client) *DockerRuntime {
+	return &DockerRuntime{cli: cli}
+}
+
+// Client returns the underlying Docker client.
+func (r *DockerRuntime) Client() *client.Client { return r.cli }
+
+func (r *DockerRuntime) Name() string { return RuntimeDocker }
+
+func (r *DockerRuntime) RunsOnHost() bool { return false }
+
+func (r *DockerRuntime) ImageExists(ctx context.Context, ref string) (bool, error) {
+	_, _, err := r.cli.ImageInspectWithRaw(ctx, ref)
+	if err == nil {
+		return true, nil
+	}
+	if errdefs.IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (r *DockerRuntime) PullImage(ctx context.Context, ref string) error {
+	rc, err := r.cli.ImagePull(ctx, ref, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+	return nil
+}
+
+func (r *DockerRuntime) SaveImages(ctx context.Context, refs []string) (io.ReadCloser, error) {
+	return r.cli.ImageSave(ctx, refs)
+}
+
+func (r *DockerRuntime) LoadImages(ctx context.Context, rd io.Reader) error {
+	res, err := r.cli.ImageLoad(ctx, rd, true)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, err = io.Copy(io.Discard, res.Body)
+	return err
+}
+
+func (r *DockerRuntime) BuildImage(ctx context.Context, buildContext io.Reader, opts types.ImageBuildOptions) (io.ReadCloser, error) {
+	res, err := r.cli.ImageBuild(ctx, buildContext, opts)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+func (r *DockerRuntime) BuildChain(ctx context.Context, log *zap.Logger, opts BuildOptions) error {
+	return buildChainImage(ctx, log, r, opts)
+}
+
+func (r *DockerRuntime) CreateContainer(ctx context.Context, name string, cfg *container.Config, hostCfg *container.HostConfig, netCfg *network.NetworkingConfig) (string, error) {
+	cc, err := r.cli.ContainerCreate(ctx, cfg, hostCfg, netCfg, nil, name)
+	if err != nil {
+		return "", err
+	}
+	return cc.ID, nil
+}
+
+func (r *DockerRuntime) StartContainer(ctx context.Context, id string) error {
+	return r.cli.ContainerStart(ctx, id, types.ContainerStartOptions{})
+}
+
+func (r *DockerRuntime) StopContainer(ctx context.Context, id string, timeout time.Duration) error {
+	timeoutSec := int(timeout.Round(time.Second) / time.Second)
+	err := r.cli.ContainerStop(ctx, id, container.StopOptions{Timeout: &timeoutSec})
+	if err != nil && !errdefs.IsNotModified(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *DockerRuntime) KillContainer(ctx context.Context, id string) error {
+	err := r.cli.ContainerKill(ctx, id, "SIGKILL")
+	// A conflict means the container is not running.
+	if err != nil && !errdefs.IsNotFound(err) && !errdefs.IsConflict(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *DockerRuntime) WaitContainer(ctx context.Context, id string) (int, error) {
+	waitCh, errCh := r.cli.ContainerWait(ctx, id, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return -1, ctx.Err()
+	case err := <-errCh:
+		return -1, err
+	case res := <-waitCh:
+		if res.Error != nil {
+			return int(res.StatusCode), fmt.Errorf("waiting for container %s: %s", id, res.Error.Message)
+		}
+		return int(res.StatusCode), nil
+	}
+}
+
+func (r *DockerRuntime) RemoveContainer(ctx context.Context, id string) error {
+	err := r.cli.ContainerRemove(ctx, id, types.ContainerRemoveOptions{
+		Force:         true,
+		RemoveVolumes: true,
+	})
+	if err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *DockerRuntime) Exec(ctx context.Context, id string, cmd []string, env []string) ContainerExecResult {
+	return r.exec(ctx, id, cmd, env, false)
+}
+
+// exec runs cmd in the container.
+// If waitExit is set, the exec is polled until it is no longer running before its exit code is read,
+// for runtimes that may close the output stream first.
+func (r *DockerRuntime) exec(ctx context.Context, id string, cmd []string, env []string, waitExit bool) ContainerExecResult {
+	exec, err := r.cli.ContainerExecCreate(ctx, id, types.ExecConfig{
+		Cmd:          cmd,
+		Env:          env,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return ContainerExecResult{Err: fmt.Errorf("create exec: %w", err), ExitCode: -1}
+	}
+
+	resp, err := r.cli.ContainerExecAttach(ctx, exec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return ContainerExecResult{Err: fmt.Errorf("attach exec: %w", err), ExitCode: -1}
+	}
+	defer resp.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, resp.Reader); err != nil {
+		return ContainerExecResult{Err: fmt.Errorf("read exec output: %w", err), ExitCode: -1}
+	}
+
+	var res types.ContainerExecInspect
+	for {
+		res, err = r.cli.ContainerExecInspect(ctx, exec.ID)
+		if err != nil {
+			return ContainerExecResult{Err: fmt.Errorf("inspect exec: %w", err), ExitCode: -1}
+		}
+		if !waitExit || !res.Running {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ContainerExecResult{Err: ctx.Err(), ExitCode: -1}
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	result := ContainerExecResult{
+		ExitCode: res.ExitCode,
+		Stdout:   stdout.Bytes(),
+		Stderr:   stderr.Bytes(),
+	}
+	if res.ExitCode != 0 {
+		result.Err = fmt.Errorf("exit code %d: %s", res.ExitCode, stderr.String())
+	}
+	return result
+}
+
+func (r *DockerRuntime) InspectContainer(ctx context.Context, id string) (types.ContainerJSON, error) {
+	return r.cli.ContainerInspect(ctx, id)
+}
+
+func (r *DockerRuntime) ContainerLogs(ctx context.Context, id string, tail int) ([]byte, []byte, error) {
+	opts := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	}
+	if tail > 0 {
+		opts.Tail = strconv.Itoa(tail)
+	}
+
+	rc, err := r.cli.ContainerLogs(ctx, id, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rc.Close()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, rc); err != nil {
+		return nil, nil, fmt.Errorf("demux logs: %w", err)
+	}
+	return stdout.Bytes(), stderr.Bytes(), nil
+}
+
+func (r *DockerRuntime) WriteLogs(ctx context.Context, id string, w io.Writer) error {
+	rc, err := r.cli.ContainerLogs(ctx, id, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if _, err := stdcopy.StdCopy(w, w, rc); err != nil {
+		return fmt.Errorf("demux logs: %w", err)
+	}
+	return nil
+}
+
+// writeSeparateLogs writes the stdout of the container to w, followed by its stderr,
+// for runtimes that do not keep the order of the two streams.
+func writeSeparateLogs(ctx context.Context, rt ContainerRuntime, id string, w io.Writer) error {
+	stdout, stderr, err := rt.ContainerLogs(ctx, id, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(stdout); err != nil {
+		return err
+	}
+	_, err = w.Write(stderr)
+	return err
+}
+
+func (r *DockerRuntime) PauseContainer(ctx context.Context, id string) error {
+	return r.cli.ContainerPause(ctx, id)
+}
+
+func (r *DockerRuntime) UnpauseContainer(ctx context.Context, id string) error {
+	return r.cli.ContainerUnpause(ctx, id)
+}
+
+func (r *DockerRuntime) ListContainers(ctx context.Context, labels map[string]string) ([]types.Container, error) {
+	return r.cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: labelFilters(labels)})
+}
+
+func (r *DockerRuntime) CreateVolume(ctx context.Context, labels map[string]string) (string, error) {
+	v, err := r.cli.VolumeCreate(ctx, volume.CreateOptions{Labels: labels})
+	if err != nil {
+		return "", err
+	}
+	return v.Name, nil
+}
+
+func (r *DockerRuntime) RemoveVolume(ctx context.Context, name string) error {
+	err := r.cli.VolumeRemove(ctx, name, true)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *DockerRuntime) ListVolumes(ctx context.Context, labels map[string]string) ([]*volume.Volume, error) {
+	res, err := r.cli.VolumeList(ctx, volume.ListOptions{Filters: labelFilters(labels)})
+	if err != nil {
+		return nil, err
+	}
+	return res.Volumes, nil
+}
+
+// labelFilters returns the filters selecting the objects carrying every label of labels.
+func labelFilters(labels map[string]string) filters.Args {
+	args := filters.NewArgs()
+	for k, v := range labels {
+		args.Add("label", k+"="+v)
+	}
+	return args
+}
+
+// hasLabels reports whether got holds every label of want.
+func hasLabels(got, want map[string]string) bool {
+	for k, v := range want {
+		if gv, ok := got[k]; !ok || gv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// volumeMountPoints returns the named volumes mounted by hostCfg, as Docker lists them with a container.
+func volumeMountPoints(hostCfg *container.HostConfig) []types.MountPoint {
+	var out []types.MountPoint
+	for _, b := range hostCfg.Binds {
+		parts := strings.Split(b, ":")
+		if len(parts) < 2 || strings.HasPrefix(parts[0], "/") || strings.HasPrefix(parts[0], ".") {
+			continue
+		}
+		out = append(out, types.MountPoint{Type: mount.TypeVolume, Name: parts[0], Destination: parts[1]})
+	}
+	for _, m := range hostCfg.Mounts {
+		if m.Type == mount.TypeVolume {
+			out = append(out, types.MountPoint{Type: mount.TypeVolume, Name: m.Source, Destination: m.Target})
+		}
+	}
+	return out
+}
+
+func (r *DockerRuntime) CreateNetwork(ctx context.Context, name string, labels map[string]string) (string, error) {
+	n, err := r.cli.NetworkCreate(ctx, name, types.NetworkCreate{
+		CheckDuplicate: true,
+
+		Labels: labels,
+	})
+	if err != nil {
+		return "", err
+	}
+	return n.ID, nil
+}
+
+func (r *DockerRuntime) RemoveNetwork(ctx context.Context, id string) error {
+	err := r.cli.NetworkRemove(ctx, id)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *DockerRuntime) ListNetworks(ctx context.Context, labels map[string]string) ([]types.NetworkResource, error) {
+	return r.cli.NetworkList(ctx, types.NetworkListOptions{Filters: labelFilters(labels)})
+}
+
+func (r *DockerRuntime) CopyToContainer(ctx context.Context, id, dstDir string, content io.Reader) error {
+	return r.cli.CopyToContainer(ctx, id, dstDir, content, types.CopyToContainerOptions{})
+}
+
+func (r *DockerRuntime) CopyFromContainer(ctx context.Context, id, srcPath string) (io.ReadCloser, error) {
+	rc, _, err := r.cli.CopyFromContainer(ctx, id, srcPath)
+	return rc, err
+}
@@ -0,0 +1,32 @@
+package dockerutil
+
+import (
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeFor(t *testing.T) {
+	cli, err := client.NewClientWithOpts()
+	require.NoError(t, err)
+	t.Cleanup(func() { forgetClient(cli) })
+	rt := RuntimeFor(cli)
+	require.Equal(t, RuntimeDocker, rt.Name())
+	require.Same(t, rt, RuntimeFor(cli))
+
+	p := &PodmanRuntime{DockerRuntime: NewDockerRuntime(cli)}
+	runtimes.Store(cli, p)
+	require.Same(t, p, RuntimeFor(cli))
+}
+
+func TestNewRuntimeClient(t *testing.T) {
+	cli, rt, err := NewRuntimeClient("")
+	require.NoError(t, err)
+	t.Cleanup(func() { runtimes.Delete(cli) })
+	require.Equal(t, RuntimeDocker, rt.Name())
+	require.Same(t, rt, RuntimeFor(cli))
+
+	_, _, err = NewRuntimeClient("containerd")
+	require.ErrorContains(t, err, `unknown container runtime "containerd"`)
+}
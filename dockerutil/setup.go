@@ -1,16 +1,14 @@
 package dockerutil
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
-	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
@@ -58,15 +56,27 @@ const (
 var KeepVolumesOnFailure = os.Getenv("ICTEST_SKIP_FAILURE_CLEANUP") != ""
 
 // DockerSetup returns a new Docker Client and the ID of a configured network, associated with t.
+// The client talks to the runtime selected by ContainerRuntimeName.
+// For the kubernetes runtime, it returns the handle and namespace of KubernetesSetup,
+// and for the process runtime, those of ProcessSetup.
 //
 // If any part of the setup fails, DockerSetup panics because the test cannot continue.
 func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 	t.Helper()
 
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if ContainerRuntimeName == RuntimeKubernetes {
+		return KubernetesSetup(t)
+	}
+	if ContainerRuntimeName == RuntimeProcess {
+		return ProcessSetup(t)
+	}
+
+	cli, rt, err := NewRuntimeClient(ContainerRuntimeName)
 	if err != nil {
-		panic(fmt.Errorf("failed to create docker client: %v", err))
+		panic(fmt.Errorf("failed to create %s client: %v", ContainerRuntimeName, err))
 	}
+	// Registered first so that it runs after DockerCleanup.
+	t.Cleanup(func() { forgetClient(cli) })
 
 	// Clean up docker resources at end of test.
 	t.Cleanup(DockerCleanup(t, cli))
@@ -76,16 +86,12 @@ func DockerSetup(t DockerSetupTestingT) (*client.Client, string) {
 	DockerCleanup(t, cli)()
 
 	name := fmt.Sprintf("%s-%s", ICTDockerPrefix, RandLowerCaseLetterString(8))
-	network, err := cli.NetworkCreate(context.TODO(), name, types.NetworkCreate{
-		CheckDuplicate: true,
-
-		Labels: map[string]string{CleanupLabel: t.Name()},
-	})
+	networkID, err := rt.CreateNetwork(context.TODO(), name, map[string]string{CleanupLabel: t.Name()})
 	if err != nil {
-		panic(fmt.Errorf("failed to create docker network: %v", err))
+		panic(fmt.Errorf("failed to create %s network: %v", rt.Name(), err))
 	}
 
-	return cli, network.ID
+	return cli, networkID
 }
 
 // DockerCleanup will clean up Docker containers, networks, and the other various config files generated in testing
@@ -97,12 +103,15 @@ func DockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 
 		ctx := context.TODO()
 		cli.NegotiateAPIVersion(ctx)
-		cs, err := cli.ContainerList(ctx, types.ContainerListOptions{
-			All: true,
-			Filters: filters.NewArgs(
-				filters.Arg("label", CleanupLabel+"="+t.Name()),
-			),
-		})
+		rt := RuntimeFor(cli)
+
+		// Collect artifacts before any containers or volumes are removed.
+		artifactFiles, artifactReporters := takeArtifactRegistrations(t.Name())
+		if t.Failed() && CollectArtifactsOnFailure {
+			collectArtifacts(ctx, t, rt, artifactFiles, artifactReporters)
+		}
+
+		cs, err := rt.ListContainers(ctx, map[string]string{CleanupLabel: t.Name()})
 		if err != nil {
 			t.Logf("Failed to list containers during docker cleanup: %v", err)
 			return
@@ -110,53 +119,40 @@ func DockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 
 		for _, c := range cs {
 			if (t.Failed() && showContainerLogs == "") || showContainerLogs == "always" {
-				logTail := "50"
-				if containerLogTail != "" {
-					logTail = containerLogTail
+				logTail := 50
+				if n, err := strconv.Atoi(containerLogTail); err == nil {
+					logTail = n
 				}
-				rc, err := cli.ContainerLogs(ctx, c.ID, types.ContainerLogsOptions{
-					ShowStdout: true,
-					ShowStderr: true,
-					Tail:       logTail,
-				})
+				stdout, stderr, err := rt.ContainerLogs(ctx, c.ID, logTail)
 				if err == nil {
-					b := new(bytes.Buffer)
-					_, err := b.ReadFrom(rc)
-					if err == nil {
-						t.Logf("\n\nContainer logs - {%s}\n%s", strings.Join(c.Names, " "), b.String())
-					}
+					t.Logf("\n\nContainer logs - {%s}\n%s%s", strings.Join(c.Names, " "), stdout, stderr)
 				}
 			}
 			if !keepContainers {
-				var stopTimeout container.StopOptions
-				timeout := 10
-				timeoutDur := time.Duration(timeout * int(time.Second))
-				deadline := time.Now().Add(timeoutDur)
-				stopTimeout.Timeout = &timeout
-				if err := cli.ContainerStop(ctx, c.ID, stopTimeout); IsLoggableStopError(err) {
+				timeout := 10 * time.Second
+				deadline := time.Now().Add(timeout)
+				if err := rt.StopContainer(ctx, c.ID, timeout); IsLoggableStopError(err) {
 					t.Logf("Failed to stop container %s during docker cleanup: %v", c.ID, err)
 				}
 
 				waitCtx, cancel := context.WithDeadline(ctx, deadline.Add(500*time.Millisecond))
-				waitCh, errCh := cli.ContainerWait(waitCtx, c.ID, container.WaitConditionNotRunning)
-				select {
-				case <-waitCtx.Done():
-					t.Logf("Timed out waiting for container %s", c.ID)
-				case err := <-errCh:
-					t.Logf("Failed to wait for container %s during docker cleanup: %v", c.ID, err)
-				case res := <-waitCh:
-					if res.Error != nil {
-						t.Logf("Error while waiting for container %s during docker cleanup: %s", c.ID, res.Error.Message)
+				// Ignoring the exit code for now.
+				if _, err := rt.WaitContainer(waitCtx, c.ID); err != nil {
+					if waitCtx.Err() != nil {
+						t.Logf("Timed out waiting for container %s", c.ID)
+					} else {
+						t.Logf("Failed to wait for container %s during docker cleanup: %v", c.ID, err)
 					}
-					// Ignoring statuscode for now.
 				}
 				cancel()
 
-				if err := cli.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{
-					// Not removing volumes with the container, because we separately handle them conditionally.
-					Force: true,
-				}); err != nil {
+				// Named volumes are not removed with the container, because we separately handle them conditionally.
+				if err := rt.RemoveContainer(ctx, c.ID); err != nil {
 					t.Logf("Failed to remove container %s during docker cleanup: %v", c.ID, err)
+				} else {
+					for _, p := range c.Ports {
+						DefaultPortAllocator.Release(int(p.PublicPort))
+					}
 				}
 			}
 		}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"time"
 
-	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
 )
 
-// StartContainer attempts to start the container with the given ID.
-func StartContainer(ctx context.Context, cli *client.Client, id string) error {
+// StartContainer attempts to start the container with the given ID through rt.
+func StartContainer(ctx context.Context, rt ContainerRuntime, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "docker.container.start", tracing.AttrContainer.String(id))
+	defer func() { tracing.End(span, err) }()
+
 	// add a deadline for the request if the calling context does not provide one
 	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
 		var cancel func()
@@ -17,7 +19,7 @@ func StartContainer(ctx context.Context, cli *client.Client, id string) error {
 		defer cancel()
 	}
 
-	err := cli.ContainerStart(ctx, id, types.ContainerStartOptions{})
+	err = rt.StartContainer(ctx, id)
 	if err != nil {
 		return err
 	}
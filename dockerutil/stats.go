@@ -0,0 +1,246 @@
+package dockerutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// HostResources converts limits to the resources of a container's host config.
+func HostResources(limits ibc.ResourceLimits) container.Resources {
+	var r container.Resources
+	if limits.CPUs > 0 {
+		r.NanoCPUs = int64(limits.CPUs * 1e9)
+	}
+	if limits.Memory > 0 {
+		r.Memory = limits.Memory
+		// Equal to Memory, so no swap is available.
+		r.MemorySwap = limits.Memory
+	}
+	return r
+}
+
+// ContainerStats is a sample of a container's resource usage.
+// I/O counters are cumulative since the container started.
+type ContainerStats struct {
+	Name string
+	When time.Time
+
+	// CPUPercent is relative to a single CPU, so a container using two CPUs fully reports 200.
+	CPUPercent float64
+
+	// MemoryUsage excludes the page cache, matching `docker stats`.
+	MemoryUsage uint64
+	MemoryLimit uint64
+
+	BlockRead, BlockWrite uint64
+	NetworkRx, NetworkTx  uint64
+}
+
+func (r *DockerRuntime) Stats(ctx context.Context, id string) (ContainerStats, error) {
+	res, err := r.cli.ContainerStats(ctx, id, false)
+	if err != nil {
+		return ContainerStats{}, err
+	}
+	defer res.Body.Close()
+
+	var v types.StatsJSON
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		return ContainerStats{}, fmt.Errorf("decode stats: %w", err)
+	}
+	return statsFromJSON(v), nil
+}
+
+func statsFromJSON(v types.StatsJSON) ContainerStats {
+	s := ContainerStats{
+		Name:        strings.TrimPrefix(v.Name, "/"),
+		When:        v.Read,
+		MemoryLimit: v.MemoryStats.Limit,
+	}
+
+	cpuDelta := float64(v.CPUStats.CPUUsage.TotalUsage) - float64(v.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(v.CPUStats.SystemUsage) - float64(v.PreCPUStats.SystemUsage)
+	cpus := float64(v.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(v.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		s.CPUPercent = cpuDelta / systemDelta * cpus * 100
+	}
+
+	// The page cache is reported as inactive_file on cgroup v2 and total_inactive_file on cgroup v1.
+	s.MemoryUsage = v.MemoryStats.Usage
+	for _, k := range []string{"inactive_file", "total_inactive_file"} {
+		if cache, ok := v.MemoryStats.Stats[k]; ok && cache < s.MemoryUsage {
+			s.MemoryUsage -= cache
+			break
+		}
+	}
+
+	for _, e := range v.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			s.BlockRead += e.Value
+		case "write":
+			s.BlockWrite += e.Value
+		}
+	}
+
+	for _, n := range v.Networks {
+		s.NetworkRx += n.RxBytes
+		s.NetworkTx += n.TxBytes
+	}
+
+	return s
+}
+
+// StatsSampler periodically samples the resource usage of every running container of a test.
+type StatsSampler struct {
+	rt       ContainerRuntime
+	testName string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	samples []ContainerStats
+}
+
+// SampleStats starts sampling, every interval, the resource usage of the running containers
+// labelled for testName, until Stop is called or ctx is done.
+// testName should match the t.Name() passed to DockerSetup.
+func SampleStats(ctx context.Context, rt ContainerRuntime, testName string, interval time.Duration) *StatsSampler {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &StatsSampler{
+		rt:       rt,
+		testName: testName,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go s.run(ctx, interval)
+	return s
+}
+
+func (s *StatsSampler) run(ctx context.Context, interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.sample(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *StatsSampler) sample(ctx context.Context) {
+	cs, err := s.rt.ListContainers(ctx, map[string]string{CleanupLabel: s.testName})
+	if err != nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range cs {
+		c := c
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Stopped containers have no usage, and short-lived containers may exit before they are sampled.
+			stats, err := s.rt.Stats(ctx, c.ID)
+			if err != nil || stats.When.IsZero() {
+				return
+			}
+			if len(c.Names) > 0 {
+				stats.Name = strings.TrimPrefix(c.Names[0], "/")
+			}
+
+			s.mu.Lock()
+			s.samples = append(s.samples, stats)
+			s.mu.Unlock()
+		}()
+	}
+	wg.Wait()
+}
+
+// Samples returns the samples taken so far, ordered by time.
+func (s *StatsSampler) Samples() []ContainerStats {
+	s.mu.Lock()
+	out := append([]ContainerStats(nil), s.samples...)
+	s.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].When.Before(out[j].When) })
+	return out
+}
+
+// Stop stops sampling and returns all samples taken.
+func (s *StatsSampler) Stop() []ContainerStats {
+	s.cancel()
+	<-s.done
+	return s.Samples()
+}
+
+// StatsSummary aggregates the samples of a single container.
+type StatsSummary struct {
+	Name    string
+	Samples int
+
+	MaxCPUPercent float64
+	AvgCPUPercent float64
+
+	MaxMemory   uint64
+	LastMemory  uint64
+	MemoryLimit uint64
+
+	// Cumulative I/O as of the last sample.
+	BlockRead, BlockWrite uint64
+	NetworkRx, NetworkTx  uint64
+}
+
+// SummarizeStats aggregates samples by container name, ordered by name.
+func SummarizeStats(samples []ContainerStats) []StatsSummary {
+	byName := make(map[string]*StatsSummary)
+	last := make(map[string]time.Time)
+	for _, s := range samples {
+		sum, ok := byName[s.Name]
+		if !ok {
+			sum = &StatsSummary{Name: s.Name}
+			byName[s.Name] = sum
+		}
+
+		sum.Samples++
+		sum.AvgCPUPercent += s.CPUPercent
+		if s.CPUPercent > sum.MaxCPUPercent {
+			sum.MaxCPUPercent = s.CPUPercent
+		}
+		if s.MemoryUsage > sum.MaxMemory {
+			sum.MaxMemory = s.MemoryUsage
+		}
+
+		if !s.When.Before(last[s.Name]) {
+			last[s.Name] = s.When
+			sum.LastMemory = s.MemoryUsage
+			sum.MemoryLimit = s.MemoryLimit
+			sum.BlockRead, sum.BlockWrite = s.BlockRead, s.BlockWrite
+			sum.NetworkRx, sum.NetworkTx = s.NetworkRx, s.NetworkTx
+		}
+	}
+
+	out := make([]StatsSummary, 0, len(byName))
+	for _, sum := range byName {
+		sum.AvgCPUPercent /= float64(sum.Samples)
+		out = append(out, *sum)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
@@ -0,0 +1,79 @@
+package dockerutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostResources(t *testing.T) {
+	require.Zero(t, HostResources(ibc.ResourceLimits{}))
+
+	r := HostResources(ibc.ResourceLimits{CPUs: 1.5, Memory: 256 << 20})
+	require.EqualValues(t, 1_500_000_000, r.NanoCPUs)
+	require.EqualValues(t, 256<<20, r.Memory)
+	require.EqualValues(t, 256<<20, r.MemorySwap)
+}
+
+func TestStatsFromJSON(t *testing.T) {
+	var v types.StatsJSON
+	v.Name = "/gaia-val-0"
+	v.Read = time.Unix(100, 0)
+	v.PreCPUStats.CPUUsage.TotalUsage = 1_000
+	v.PreCPUStats.SystemUsage = 10_000
+	v.CPUStats.CPUUsage.TotalUsage = 2_000
+	v.CPUStats.SystemUsage = 14_000
+	v.CPUStats.OnlineCPUs = 4
+	v.MemoryStats.Usage = 300
+	v.MemoryStats.Limit = 1000
+	v.MemoryStats.Stats = map[string]uint64{"inactive_file": 100}
+	v.BlkioStats.IoServiceBytesRecursive = []types.BlkioStatEntry{
+		{Op: "read", Value: 10},
+		{Op: "Write", Value: 20},
+		{Op: "Read", Value: 5},
+	}
+	v.Networks = map[string]types.NetworkStats{
+		"eth0": {RxBytes: 7, TxBytes: 3},
+		"eth1": {RxBytes: 1, TxBytes: 2},
+	}
+
+	require.Equal(t, ContainerStats{
+		Name:        "gaia-val-0",
+		When:        time.Unix(100, 0),
+		CPUPercent:  100,
+		MemoryUsage: 200,
+		MemoryLimit: 1000,
+		BlockRead:   15,
+		BlockWrite:  20,
+		NetworkRx:   8,
+		NetworkTx:   5,
+	}, statsFromJSON(v))
+}
+
+func TestSummarizeStats(t *testing.T) {
+	at := func(sec int64) time.Time { return time.Unix(sec, 0) }
+	got := SummarizeStats([]ContainerStats{
+		{Name: "val-1", When: at(2), CPUPercent: 30, MemoryUsage: 50, BlockRead: 9},
+		{Name: "val-0", When: at(1), CPUPercent: 10, MemoryUsage: 100, MemoryLimit: 1000, NetworkRx: 1},
+		{Name: "val-0", When: at(3), CPUPercent: 50, MemoryUsage: 80, MemoryLimit: 1000, NetworkRx: 4},
+		{Name: "val-0", When: at(2), CPUPercent: 30, MemoryUsage: 120, MemoryLimit: 1000, NetworkRx: 2},
+	})
+
+	require.Equal(t, []StatsSummary{
+		{
+			Name: "val-0", Samples: 3,
+			MaxCPUPercent: 50, AvgCPUPercent: 30,
+			MaxMemory: 120, LastMemory: 80, MemoryLimit: 1000,
+			NetworkRx: 4,
+		},
+		{
+			Name: "val-1", Samples: 1,
+			MaxCPUPercent: 30, AvgCPUPercent: 30,
+			MaxMemory: 50, LastMemory: 50,
+			BlockRead: 9,
+		},
+	}, got)
+}
@@ -0,0 +1,163 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"go.uber.org/zap"
+)
+
+// supervisorInterval is how often a supervisor inspects the containers it watches.
+var supervisorInterval = 500 * time.Millisecond
+
+// supervisorLogTail is the number of log lines kept in a ContainerExitError.
+const supervisorLogTail = 30
+
+// ContainerExitError is the cause of the cancellation of a context returned by Supervise,
+// describing the container that exited unexpectedly.
+type ContainerExitError struct {
+	Container string
+	// ExitCode is -1 if the container was removed.
+	ExitCode int
+	// Panic is the panic line of the logs, if any, as found by ParseSDKPanicFromText.
+	Panic error
+	// Logs holds the last lines of the container's stdout and stderr.
+	Logs string
+}
+
+func (e *ContainerExitError) Error() string {
+	msg := fmt.Sprintf("container %s exited unexpectedly with code %d", e.Container, e.ExitCode)
+	if e.ExitCode < 0 {
+		msg = fmt.Sprintf("container %s was removed unexpectedly", e.Container)
+	}
+	if e.Panic != nil {
+		msg += ": " + e.Panic.Error()
+	}
+	if e.Logs != "" {
+		msg += fmt.Sprintf("\nlast %d log lines:\n%s", supervisorLogTail, e.Logs)
+	}
+	return msg
+}
+
+// liveContainers holds, by runtime, the containers started through a ContainerLifecycle
+// and not stopped or removed through it since, by ID to their names.
+var liveContainers sync.Map // map[ContainerRuntime]*containerSet
+
+type containerSet struct {
+	mu    sync.Mutex
+	names map[string]string
+}
+
+func containersOf(rt ContainerRuntime) *containerSet {
+	s, _ := liveContainers.LoadOrStore(rt, &containerSet{names: make(map[string]string)})
+	return s.(*containerSet)
+}
+
+func (s *containerSet) add(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.names[id] = name
+}
+
+func (s *containerSet) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.names, id)
+}
+
+func (s *containerSet) has(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.names[id]
+	return ok
+}
+
+func (s *containerSet) snapshot() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := make(map[string]string, len(s.names))
+	for id, name := range s.names {
+		m[id] = name
+	}
+	return m
+}
+
+// Supervise returns a copy of ctx that is canceled as soon as a container of rt,
+// started through a ContainerLifecycle, exits or is removed without being stopped or removed through it.
+// This covers chain nodes, sidecars and relayers, whether started before or after Supervise is called,
+// but not the one-off containers running commands.
+// context.Cause then returns a *ContainerExitError with the exit code and last log lines of the container.
+//
+// Supervision works with every runtime.
+// Supervision stops when the returned context is done; cancel stops it and waits for it to return,
+// so that log is no longer used afterwards.
+func Supervise(ctx context.Context, log *zap.Logger, rt ContainerRuntime) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		supervise(ctx, cancel, log, rt)
+	}()
+	return ctx, func() {
+		cancel(context.Canceled)
+		<-done
+	}
+}
+
+func supervise(ctx context.Context, cancel context.CancelCauseFunc, log *zap.Logger, rt ContainerRuntime) {
+	set := containersOf(rt)
+
+	ticker := time.NewTicker(supervisorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for id, name := range set.snapshot() {
+			exitErr := checkContainer(ctx, rt, id, name)
+			if ctx.Err() != nil {
+				return
+			}
+			// The container may have been stopped through its lifecycle while it was inspected.
+			if exitErr == nil || !set.has(id) {
+				continue
+			}
+			log.Error(
+				"Container exited unexpectedly",
+				zap.String("container", name),
+				zap.Int("exit_code", exitErr.ExitCode),
+				zap.NamedError("panic", exitErr.Panic),
+			)
+			set.remove(id)
+			cancel(exitErr)
+			return
+		}
+	}
+}
+
+// checkContainer returns a *ContainerExitError if the container id is no longer running.
+func checkContainer(ctx context.Context, rt ContainerRuntime, id, name string) *ContainerExitError {
+	cj, err := rt.InspectContainer(ctx, id)
+	if errdefs.IsNotFound(err) {
+		return &ContainerExitError{Container: name, ExitCode: -1}
+	}
+	if err != nil || cj.State == nil || cj.State.Running {
+		// Inspection errors are retried on the next tick.
+		return nil
+	}
+
+	exitErr := &ContainerExitError{Container: name, ExitCode: cj.State.ExitCode}
+	stdout, stderr, err := rt.ContainerLogs(ctx, id, supervisorLogTail)
+	if err == nil {
+		logs := string(stdout) + string(stderr)
+		exitErr.Panic = ParseSDKPanicFromText(logs)
+		exitErr.Logs = logs
+	}
+	return exitErr
+}
@@ -0,0 +1,75 @@
+//go:build unix
+
+package dockerutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSupervise(t *testing.T) {
+	rt, err := NewProcessRuntime(t.TempDir())
+	require.NoError(t, err)
+
+	defer func(d time.Duration) { supervisorInterval = d }(supervisorInterval)
+	supervisorInterval = 50 * time.Millisecond
+
+	ctx := context.Background()
+	log := zaptest.NewLogger(t)
+	start := func(name, script string) *ContainerLifecycle {
+		c := NewContainerLifecycle(log, rt, name)
+		require.NoError(t, c.CreateContainer(
+			ctx, t.Name(), "", ibc.DockerImage{Repository: "busybox", Version: "stable"},
+			nil, nil, nil, name, []string{script}, nil, []string{"sh", "-c"},
+		))
+		require.NoError(t, c.StartContainer(ctx))
+		t.Cleanup(func() { _ = c.RemoveContainer(ctx) })
+		return c
+	}
+
+	sctx, cancel := Supervise(ctx, log, rt)
+	defer cancel()
+
+	stopped := start("stopped", "sleep 30")
+	require.NoError(t, stopped.StopContainer(ctx))
+	killed := start("killed", "sleep 30")
+	killed.ExpectExit()
+	require.NoError(t, rt.StopContainer(ctx, killed.ContainerID(), 0))
+
+	// The crash comes after the panic check of StartContainer.
+	start("crashing", `sleep 1.5; echo "panic: boom"; exit 2`)
+
+	select {
+	case <-sctx.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("context not canceled after the container exited")
+	}
+	var exitErr *ContainerExitError
+	require.True(t, errors.As(context.Cause(sctx), &exitErr), context.Cause(sctx))
+	require.Equal(t, "crashing", exitErr.Container)
+	require.Equal(t, 2, exitErr.ExitCode)
+	require.EqualError(t, exitErr.Panic, "panic: boom")
+	require.Contains(t, exitErr.Error(), "container crashing exited unexpectedly with code 2: panic: boom")
+}
+
+func TestSetupForgetsContainers(t *testing.T) {
+	var cli *client.Client
+	var rt ContainerRuntime
+	t.Run("setup", func(t *testing.T) {
+		cli, _ = ProcessSetup(t)
+		rt = RuntimeFor(cli)
+		containersOf(rt).add("id", "name")
+	})
+
+	_, ok := liveContainers.Load(rt)
+	require.False(t, ok)
+	_, ok = runtimes.Load(cli)
+	require.False(t, ok)
+}
@@ -0,0 +1,407 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"go.uber.org/zap"
+)
+
+// DirFilter selects the files copied by CopyDirToVolume and CopyDirFromVolume.
+//
+// Patterns are those of path.Match. A pattern without a slash matches the base name of files and directories,
+// as "*.wasm" does; other patterns match their slash-separated path relative to the copied directory,
+// as "data/*.db" does.
+type DirFilter struct {
+	// Include lists the patterns of the files to copy; all files are copied if it is empty.
+	// The directories holding included files are created as needed.
+	Include []string
+
+	// Exclude lists the patterns of the files and directories not to copy, with everything below them.
+	Exclude []string
+}
+
+func (f DirFilter) validate() error {
+	for _, p := range append(append([]string(nil), f.Include...), f.Exclude...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// excluded reports whether rel or one of its parent directories matches an exclude pattern.
+func (f DirFilter) excluded(rel string) bool {
+	for p := rel; p != "." && p != "/"; p = path.Dir(p) {
+		if matchAny(f.Exclude, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// included reports whether the file rel is to be copied.
+func (f DirFilter) included(rel string) bool {
+	return (len(f.Include) == 0 || matchAny(f.Include, rel)) && !f.excluded(rel)
+}
+
+func matchAny(patterns []string, rel string) bool {
+	for _, p := range patterns {
+		name := rel
+		if !strings.Contains(p, "/") {
+			name = path.Base(rel)
+		}
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// VolumeDirOptions contain the configuration for the CopyDirToVolume and CopyDirFromVolume functions.
+type VolumeDirOptions struct {
+	Log *zap.Logger
+
+	Runtime ContainerRuntime
+
+	VolumeName string
+	TestName   string
+
+	// UidGid is set as the owner of the volume and everything in it, through SetVolumeOwner,
+	// after copying to the volume. It defaults to root, so set it to the user of the image mounting the volume.
+	UidGid string
+
+	Filter DirFilter
+}
+
+// CopyDirToVolume copies the directory srcDir of the host to relDir within the volume,
+// streaming it as a tar archive through a one-off container.
+// Files already in relDir are overwritten but not removed.
+func CopyDirToVolume(ctx context.Context, opts VolumeDirOptions, srcDir, relDir string) error {
+	relDir, err := cleanVolumeDir(relDir)
+	if err != nil {
+		return err
+	}
+	if err := opts.Filter.validate(); err != nil {
+		return err
+	}
+	if fi, err := os.Stat(srcDir); err != nil {
+		return err
+	} else if !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", srcDir)
+	}
+
+	err = withVolumeContainer(ctx, opts, "copydir", func(rt ContainerRuntime, id, mountPath string) error {
+		pr, pw := io.Pipe()
+		go func() { pw.CloseWithError(writeDirTar(pw, srcDir, relDir, opts.Filter)) }()
+		defer pr.Close()
+
+		if err := rt.CopyToContainer(ctx, id, mountPath, pr); err != nil {
+			return fmt.Errorf("copying %s to container: %w", srcDir, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Extracted files are owned by root.
+	return SetVolumeOwner(ctx, VolumeOwnerOptions{
+		Log: opts.Log,
+
+		Runtime: opts.Runtime,
+
+		VolumeName: opts.VolumeName,
+		TestName:   opts.TestName,
+		UidGid:     opts.UidGid,
+		Recursive:  true,
+	})
+}
+
+// CopyDirFromVolume copies relDir within the volume to the directory dstDir of the host, creating it if needed,
+// streaming it as a tar archive through a one-off container.
+// Symlinks pointing outside of dstDir are rejected, and files are never written through a symlink.
+func CopyDirFromVolume(ctx context.Context, opts VolumeDirOptions, relDir, dstDir string) error {
+	relDir, err := cleanVolumeDir(relDir)
+	if err != nil {
+		return err
+	}
+	if err := opts.Filter.validate(); err != nil {
+		return err
+	}
+
+	return withVolumeContainer(ctx, opts, "copydir", func(rt ContainerRuntime, id, mountPath string) error {
+		rc, err := rt.CopyFromContainer(ctx, id, path.Join(mountPath, relDir))
+		if err != nil {
+			return fmt.Errorf("copying %s from container: %w", relDir, err)
+		}
+		defer func() {
+			_ = rc.Close()
+		}()
+
+		if err := extractDirTar(rc, dstDir, opts.Filter); err != nil {
+			return fmt.Errorf("extracting %s: %w", relDir, err)
+		}
+		return nil
+	})
+}
+
+// cleanVolumeDir returns relDir cleaned, or an error if it is not within the volume.
+func cleanVolumeDir(relDir string) (string, error) {
+	if relDir == "" {
+		return ".", nil
+	}
+	relDir = path.Clean(relDir)
+	if !filepath.IsLocal(filepath.FromSlash(relDir)) {
+		return "", fmt.Errorf("directory %q is not relative to the volume", relDir)
+	}
+	return relDir, nil
+}
+
+// withVolumeContainer calls fn with a busybox container mounting the volume of opts, which is never started.
+func withVolumeContainer(
+	ctx context.Context,
+	opts VolumeDirOptions,
+	purpose string,
+	fn func(rt ContainerRuntime, id, mountPath string) error,
+) error {
+	const mountPath = "/mnt/dockervolume"
+
+	if err := EnsureBusybox(ctx, opts.Runtime); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("%s-%s-%d-%s", ICTDockerPrefix, purpose, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	rt := opts.Runtime
+	id, err := rt.CreateContainer(
+		ctx,
+		containerName,
+		&container.Config{
+			Image: BusyboxRef,
+
+			// Use root user to avoid permission issues when reading files from the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: opts.TestName},
+		},
+		&container.HostConfig{
+			Binds: []string{opts.VolumeName + ":" + mountPath},
+		},
+		nil, // No networking necessary.
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	defer func() {
+		if err := rt.RemoveContainer(ctx, id); err != nil {
+			opts.Log.Warn("Failed to remove copy-dir container", zap.String("container_id", id), zap.Error(err))
+		}
+	}()
+
+	return fn(rt, id, mountPath)
+}
+
+// writeDirTar writes a tar stream of the files of srcDir selected by filter to w,
+// naming entries after their path relative to srcDir, within prefix.
+func writeDirTar(w io.Writer, srcDir, prefix string, filter DirFilter) error {
+	tw := tar.NewWriter(w)
+	err := filepath.WalkDir(srcDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if filter.excluded(rel) {
+				return filepath.SkipDir
+			}
+			if len(filter.Include) > 0 {
+				// Only the directories holding included files are created.
+				return nil
+			}
+		} else if !filter.included(rel) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		case !info.Mode().IsRegular() && !info.IsDir():
+			// Sockets, devices and the like are not copied.
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(prefix, rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		// The volume owner is set once the archive is extracted.
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+		hdr.Format = tar.FormatPAX
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// extractDirTar extracts the files of the tar stream r selected by filter to dstDir.
+// Entries are named as in archives returned by ContainerRuntime.CopyFromContainer,
+// so the first element of their names, the name of the copied directory, is dropped.
+func extractDirTar(r io.Reader, dstDir string, filter DirFilter) error {
+	if err := os.MkdirAll(dstDir, 0o755); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar: %w", err)
+		}
+
+		rel := "."
+		if _, after, ok := strings.Cut(strings.TrimSuffix(hdr.Name, "/"), "/"); ok {
+			rel = path.Clean(after)
+		}
+		if rel == "." {
+			if hdr.Typeflag != tar.TypeDir {
+				return fmt.Errorf("%s is not a directory", hdr.Name)
+			}
+			continue
+		}
+		if !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return fmt.Errorf("tar entry %s is outside of the copied directory", hdr.Name)
+		}
+		target := filepath.Join(dstDir, filepath.FromSlash(rel))
+		mode := fs.FileMode(hdr.Mode).Perm()
+		if link, err := symlinkedParent(dstDir, rel); err != nil {
+			return err
+		} else if link != "" {
+			return fmt.Errorf("tar entry %s would be written through symlink %s", hdr.Name, link)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if len(filter.Include) > 0 || filter.excluded(rel) {
+				continue
+			}
+			if err := os.MkdirAll(target, mode|0o700); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if !filter.included(rel) {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if filepath.IsAbs(hdr.Linkname) || !isWithin(filepath.Clean(dstDir), filepath.Join(filepath.Dir(target), filepath.FromSlash(hdr.Linkname))) {
+				return fmt.Errorf("tar entry %s links to %s, outside of %s", hdr.Name, hdr.Linkname, dstDir)
+			}
+			_ = os.Remove(target)
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if !filter.included(rel) {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			// Replace rather than follow a symlink left at target by an earlier copy.
+			if fi, err := os.Lstat(target); err == nil && fi.Mode()&fs.ModeSymlink != 0 {
+				if err := os.Remove(target); err != nil {
+					return err
+				}
+			}
+			if err := writeTarFile(tr, target, mode|0o600); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// symlinkedParent returns the first parent directory of the slash-separated
+// path rel below root that is a symlink, or "" if there is none.
+// Writing through such a directory could escape root.
+func symlinkedParent(root, rel string) (string, error) {
+	dir := root
+	parents := strings.Split(rel, "/")
+	for _, elem := range parents[:len(parents)-1] {
+		dir = filepath.Join(dir, elem)
+		fi, err := os.Lstat(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			return dir, nil
+		}
+	}
+	return "", nil
+}
+
+// isWithin reports whether the clean path p is root or below it.
+func isWithin(root, p string) bool {
+	return p == root || strings.HasPrefix(p, root+string(filepath.Separator))
+}
+
+func writeTarFile(r io.Reader, target string, mode fs.FileMode) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
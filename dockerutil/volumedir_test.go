@@ -0,0 +1,137 @@
+//go:build unix
+
+package dockerutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestDirFilter(t *testing.T) {
+	f := DirFilter{Include: []string{"*.wasm", "keyring-test/*"}, Exclude: []string{"old"}}
+	for rel, want := range map[string]bool{
+		"a.wasm":               true,
+		"contracts/b.wasm":     true,
+		"contracts/b.json":     false,
+		"keyring-test/a.info":  true,
+		"x/keyring-test/a":     false,
+		"old/c.wasm":           false,
+		"contracts/old/c.wasm": false,
+	} {
+		require.Equal(t, want, f.included(rel), rel)
+	}
+	require.True(t, DirFilter{}.included("any/file"))
+	require.Error(t, DirFilter{Exclude: []string{"["}}.validate())
+}
+
+func TestCopyDir(t *testing.T) {
+	rt, err := NewProcessRuntime(t.TempDir())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	vol, err := rt.CreateVolume(ctx, nil)
+	require.NoError(t, err)
+
+	src := t.TempDir()
+	for name, content := range map[string]string{
+		"keyring-test/val.info": "key",
+		"wasm/a.wasm":           "\x00asm",
+		"wasm/old/b.wasm":       "\x00asm-old",
+		"notes.txt":             "notes",
+	} {
+		p := filepath.Join(src, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+	require.NoError(t, os.Symlink("a.wasm", filepath.Join(src, "wasm", "latest.wasm")))
+
+	opts := VolumeDirOptions{
+		Log:        zaptest.NewLogger(t),
+		Runtime:    rt,
+		VolumeName: vol,
+		TestName:   t.Name(),
+		Filter:     DirFilter{Exclude: []string{"old", "*.txt"}},
+	}
+	require.NoError(t, CopyDirToVolume(ctx, opts, src, "data"))
+
+	volDir := filepath.Join(rt.VolumePath(vol), "data")
+	b, err := os.ReadFile(filepath.Join(volDir, "keyring-test", "val.info"))
+	require.NoError(t, err)
+	require.Equal(t, "key", string(b))
+	link, err := os.Readlink(filepath.Join(volDir, "wasm", "latest.wasm"))
+	require.NoError(t, err)
+	require.Equal(t, "a.wasm", link)
+	require.NoFileExists(t, filepath.Join(volDir, "notes.txt"))
+	require.NoDirExists(t, filepath.Join(volDir, "wasm", "old"))
+
+	dst := filepath.Join(t.TempDir(), "out")
+	opts.Filter = DirFilter{Include: []string{"*.wasm"}}
+	require.NoError(t, CopyDirFromVolume(ctx, opts, "data", dst))
+
+	b, err = os.ReadFile(filepath.Join(dst, "wasm", "a.wasm"))
+	require.NoError(t, err)
+	require.Equal(t, "\x00asm", string(b))
+	require.FileExists(t, filepath.Join(dst, "wasm", "latest.wasm"))
+	require.NoDirExists(t, filepath.Join(dst, "keyring-test"))
+
+	require.Error(t, CopyDirToVolume(ctx, opts, src, "../escape"))
+	require.Error(t, CopyDirFromVolume(ctx, opts, "data/keyring-test/val.info", dst), "only directories are copied")
+}
+
+func TestExtractDirTarSymlinks(t *testing.T) {
+	type entry struct {
+		name, link, content string
+	}
+	archive := func(entries ...entry) *bytes.Buffer {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "data/", Typeflag: tar.TypeDir, Mode: 0o755}))
+		for _, e := range entries {
+			hdr := &tar.Header{Name: e.name, Mode: 0o644}
+			if e.link != "" {
+				hdr.Typeflag, hdr.Linkname = tar.TypeSymlink, e.link
+			} else {
+				hdr.Typeflag, hdr.Size = tar.TypeReg, int64(len(e.content))
+			}
+			require.NoError(t, tw.WriteHeader(hdr))
+			_, err := tw.Write([]byte(e.content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return &buf
+	}
+
+	outside := t.TempDir()
+	for name, entries := range map[string][]entry{
+		"absolute link":  {{name: "data/a", link: outside}, {name: "data/a/x", content: "pwned"}},
+		"relative link":  {{name: "data/a", link: "../../" + filepath.Base(outside)}, {name: "data/a/x", content: "pwned"}},
+		"nested link":    {{name: "data/sub/a", link: "../.."}, {name: "data/sub/a/x", content: "pwned"}},
+		"through parent": {{name: "data/dir/keep", content: "ok"}, {name: "data/a", link: "dir"}, {name: "data/a/x", content: "x"}},
+	} {
+		dst := filepath.Join(t.TempDir(), "out")
+		require.Error(t, extractDirTar(archive(entries...), dst, DirFilter{}), name)
+		require.NoFileExists(t, filepath.Join(outside, "x"), name)
+		require.NoFileExists(t, filepath.Join(dst, "dir", "x"), name)
+	}
+
+	// A symlink left in dstDir by an earlier copy is replaced, not followed.
+	dst := filepath.Join(t.TempDir(), "out")
+	require.NoError(t, os.MkdirAll(dst, 0o755))
+	target := filepath.Join(outside, "target")
+	require.NoError(t, os.WriteFile(target, []byte("keep"), 0o600))
+	require.NoError(t, os.Symlink(target, filepath.Join(dst, "f")))
+	require.NoError(t, extractDirTar(archive(entry{name: "data/f", content: "new"}, entry{name: "data/l", link: "f"}), dst, DirFilter{}))
+	b, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "keep", string(b))
+	b, err = os.ReadFile(filepath.Join(dst, "l"))
+	require.NoError(t, err)
+	require.Equal(t, "new", string(b))
+}
@@ -5,9 +5,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/client"
 	"go.uber.org/zap"
 )
 
@@ -15,16 +13,25 @@ import (
 type VolumeOwnerOptions struct {
 	Log *zap.Logger
 
-	Client *client.Client
+	Runtime ContainerRuntime
 
 	VolumeName string
 	ImageRef   string
 	TestName   string
 	UidGid     string
+
+	// Recursive also sets the owner of everything in the volume, not only of its root.
+	Recursive bool
 }
 
 // SetVolumeOwner configures the owner of a volume to match the default user in the supplied image reference.
 func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
+	rt := opts.Runtime
+	if rt.RunsOnHost() {
+		// Processes run as the user owning the volumes.
+		return nil
+	}
+
 	owner := opts.UidGid
 	if owner == "" {
 		owner = GetRootUserString()
@@ -34,19 +41,25 @@ func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
 
 	containerName := fmt.Sprintf("%s-volumeowner-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
 
-	if err := EnsureBusybox(ctx, opts.Client); err != nil {
+	if err := EnsureBusybox(ctx, rt); err != nil {
 		return err
 	}
 
+	chown := `chown "$2" "$1"`
+	if opts.Recursive {
+		chown = `chown -R "$2" "$1"`
+	}
+
 	const mountPath = "/mnt/dockervolume"
-	cc, err := opts.Client.ContainerCreate(
+	id, err := rt.CreateContainer(
 		ctx,
+		containerName,
 		&container.Config{
-			Image: busyboxRef, // Using busybox image which has chown and chmod.
+			Image: BusyboxRef, // Using busybox image which has chown and chmod.
 
 			Entrypoint: []string{"sh", "-c"},
 			Cmd: []string{
-				`chown "$2" "$1" && chmod 0700 "$1"`,
+				chown + ` && chmod 0700 "$1"`,
 				"_", // Meaningless arg0 for sh -c with positional args.
 				mountPath,
 				owner,
@@ -62,8 +75,6 @@ func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
 			AutoRemove: true,
 		},
 		nil, // No networking necessary.
-		nil,
-		containerName,
 	)
 	if err != nil {
 		return fmt.Errorf("creating container: %w", err)
@@ -76,33 +87,24 @@ func SetVolumeOwner(ctx context.Context, opts VolumeOwnerOptions) error {
 			return
 		}
 
-		if err := opts.Client.ContainerRemove(ctx, cc.ID, types.ContainerRemoveOptions{
-			Force: true,
-		}); err != nil {
-			opts.Log.Warn("Failed to remove volume-owner container", zap.String("container_id", cc.ID), zap.Error(err))
+		if err := rt.RemoveContainer(ctx, id); err != nil {
+			opts.Log.Warn("Failed to remove volume-owner container", zap.String("container_id", id), zap.Error(err))
 		}
 	}()
 
-	if err := opts.Client.ContainerStart(ctx, cc.ID, types.ContainerStartOptions{}); err != nil {
+	if err := rt.StartContainer(ctx, id); err != nil {
 		return fmt.Errorf("starting volume-owner container: %w", err)
 	}
 
-	waitCh, errCh := opts.Client.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case err := <-errCh:
-		return err
-	case res := <-waitCh:
+	exitCode, err := rt.WaitContainer(ctx, id)
+	if exitCode >= 0 {
 		autoRemoved = true
-
-		if res.Error != nil {
-			return fmt.Errorf("waiting for volume-owner container: %s", res.Error.Message)
-		}
-
-		if res.StatusCode != 0 {
-			return fmt.Errorf("configuring volume exited %d", res.StatusCode)
-		}
+	}
+	if err != nil {
+		return fmt.Errorf("waiting for volume-owner container: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("configuring volume exited %d", exitCode)
 	}
 
 	return nil
@@ -51,7 +51,7 @@ func HaltChainAndExportGenesis(ctx context.Context, t *testing.T, chain *cosmos.
 		err := testutil.ModifyTomlConfigFile(
 			ctx,
 			zap.NewExample(),
-			node.DockerClient,
+			node.Runtime,
 			node.TestName,
 			node.VolumeName,
 			"config/app.toml",
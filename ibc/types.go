@@ -71,8 +71,13 @@ type ChainConfig struct {
 	// CoinDecimals for the chains base micro/nano/atto token configuration.
 	CoinDecimals *int64
 	// HostPortOverride exposes ports to the host.
-	// To avoid port binding conflicts, ports are only exposed on the 0th validator.
+	// To avoid port binding conflicts, ports are only exposed on the 0th validator,
+	// unless HostPortOverrideStride is set.
 	HostPortOverride map[int]int `yaml:"host-port-override"`
+	// HostPortOverrideStride, if positive, exposes HostPortOverride on every node of the chain.
+	// Host ports are shifted by the stride for each node, ordered validators first,
+	// e.g. with a stride of 10 and 2 validators, host port 26657 is 26657, 26667 and 26677 on validators 0, 1 and full node 0.
+	HostPortOverrideStride int `yaml:"host-port-override-stride"`
 	// ExposeAdditionalPorts exposes each port id to the host on a random port. ex: "8080/tcp"
 	// Access the address with ChainNode.GetHostAddress
 	ExposeAdditionalPorts []string
@@ -80,6 +85,8 @@ type ChainConfig struct {
 	AdditionalStartArgs []string
 	// Environment variables for chain nodes
 	Env []string
+	// CPU and memory limits applied to each chain node container.
+	Resources ResourceLimits `yaml:"resources"`
 	// Genesis file contents for the chain
 	// Used if starting from an already populated genesis.json, e.g for hard fork upgrades.
 	// When nil, the chain will generate the number of validators specified in the ChainSpec.
@@ -236,6 +243,10 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.ExposeAdditionalPorts = append(c.ExposeAdditionalPorts, other.ExposeAdditionalPorts...)
 	}
 
+	if other.Resources != (ResourceLimits{}) {
+		c.Resources = other.Resources
+	}
+
 	if !cmp.Equal(other.InterchainSecurityConfig, ICSConfig{}) {
 		c.InterchainSecurityConfig = other.InterchainSecurityConfig
 	}
@@ -286,6 +297,18 @@ type SidecarConfig struct {
 	Env              []string
 	PreStart         bool
 	ValidatorProcess bool
+	// CPU and memory limits applied to each sidecar container.
+	Resources ResourceLimits
+}
+
+// ResourceLimits constrains the resources available to a container.
+// Zero values mean unlimited.
+type ResourceLimits struct {
+	// CPUs is the number of CPUs the container may use, e.g. 0.5 for half of one CPU.
+	CPUs float64 `json:"cpus" yaml:"cpus"`
+	// Memory is the maximum memory in bytes. Swap is disabled when set,
+	// so a container exceeding it is killed rather than slowed down.
+	Memory int64 `json:"memory" yaml:"memory"`
 }
 
 type DockerImage struct {
@@ -343,17 +366,26 @@ func (i DockerImage) Ref() string {
 	return i.Repository + ":" + i.Version
 }
 
+// EnsureImage pulls an image if it is not present locally.
+// The dockerutil package, which imports this package, sets it to go through the container runtime of the client.
+var EnsureImage func(ctx context.Context, client *client.Client, ref string) error
+
+// PullImage pulls the image if it is not present locally, through EnsureImage when it is set.
 func (i DockerImage) PullImage(ctx context.Context, client *client.Client) error {
 	ref := i.Ref()
-	_, _, err := client.ImageInspectWithRaw(ctx, ref)
+	if EnsureImage != nil {
+		return EnsureImage(ctx, client, ref)
+	}
+
+	if _, _, err := client.ImageInspectWithRaw(ctx, ref); err == nil {
+		return nil
+	}
+	rc, err := client.ImagePull(ctx, ref, types.ImagePullOptions{})
 	if err != nil {
-		rc, err := client.ImagePull(ctx, ref, types.ImagePullOptions{})
-		if err != nil {
-			return fmt.Errorf("pull image %s: %w", ref, err)
-		}
-		_, _ = io.Copy(io.Discard, rc)
-		_ = rc.Close()
+		return fmt.Errorf("pull image %s: %w", ref, err)
 	}
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
 	return nil
 }
 
@@ -419,6 +451,8 @@ const (
 	CosmosRly RelayerImplementation = iota
 	Hermes
 	Hyperspace
+	// CosmosRlyInProcess is the cosmos relayer linked into the test binary, running without Docker.
+	CosmosRlyInProcess
 )
 
 // ChannelFilter provides the means for either creating an allowlist or a denylist of channels on the src chain
@@ -436,6 +470,38 @@ type PathUpdateOptions struct {
 	DstClientID   *string
 	DstConnID     *string
 	DstChainID    *string
+
+	// Packet clearing behaviour of the relayer.
+	// Not every relayer implementation supports these settings; currently only hermes honours them.
+	// They are not specific to the path: hermes applies them to every path it relays,
+	// and the last value set through any path wins.
+	ClearInterval  *uint64 // Number of blocks between clearing pending packets, 0 disables periodic clearing.
+	ClearOnStart   *bool
+	TxConfirmation *bool
+
+	// Settings for the src and dst chains of the path.
+	// Not every relayer implementation supports these settings; currently only hermes honours them.
+	SrcChainSettings *PathChainSettings
+	DstChainSettings *PathChainSettings
+}
+
+// PathEnds are the clients and connections of a linked path on its src and dst chains.
+type PathEnds struct {
+	SrcClientID string
+	SrcConnID   string
+	DstClientID string
+	DstConnID   string
+}
+
+// PathChainSettings overrides the relayer's configuration for one chain of a path.
+// A nil field leaves the relayer's default in place.
+type PathChainSettings struct {
+	MemoPrefix    *string
+	DefaultGas    *uint64
+	MaxGas        *uint64
+	GasMultiplier *float64
+	// Gas price in the chain's configured denom.
+	GasPrice *float64
 }
 
 type ICSConfig struct {
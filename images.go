@@ -0,0 +1,79 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"go.uber.org/zap"
+)
+
+// RelayerImager is implemented by relayer factories that can report the images
+// of the relayers they build without building one.
+// The factories returned by NewBuiltinRelayerFactory implement it.
+type RelayerImager interface {
+	Images() []ibc.DockerImage
+}
+
+// RequiredImages returns the references of every image needed to run the chains described by specs
+// and the relayers built by factories, along with the busybox image used to prepare volumes.
+// Factories that do not implement RelayerImager are skipped.
+func RequiredImages(log *zap.Logger, specs []*ChainSpec, factories ...RelayerFactory) ([]string, error) {
+	refs := []string{dockerutil.BusyboxRef}
+
+	for _, s := range specs {
+		cfg, err := s.Config(log)
+		if err != nil {
+			return nil, fmt.Errorf("config of chain spec %s: %w", s.Name, err)
+		}
+		for _, img := range cfg.Images {
+			refs = append(refs, img.Ref())
+		}
+		if cfg.UsesCometMock() {
+			refs = append(refs, cfg.CometMock.Image.Ref())
+		}
+		for _, sc := range cfg.SidecarConfigs {
+			refs = append(refs, sc.Image.Ref())
+		}
+	}
+
+	for _, f := range factories {
+		ri, ok := f.(RelayerImager)
+		if !ok {
+			continue
+		}
+		for _, img := range ri.Images() {
+			refs = append(refs, img.Ref())
+		}
+	}
+
+	return dockerutil.UniqueImageRefs(refs), nil
+}
+
+// PrepareImages makes every image returned by RequiredImages available locally before any test starts,
+// pulling missing images in parallel.
+// If dockerutil.OfflineImages is set, nothing is pulled, and a *dockerutil.MissingImagesError lists every missing image.
+func PrepareImages(ctx context.Context, log *zap.Logger, cli *client.Client, specs []*ChainSpec, factories ...RelayerFactory) error {
+	refs, err := RequiredImages(log, specs, factories...)
+	if err != nil {
+		return err
+	}
+	return dockerutil.NewImageManager(log, dockerutil.RuntimeFor(cli)).Prepare(ctx, refs)
+}
+
+// BuildChainImage builds the chain of spec from local source with dockerutil.BuildImage,
+// and sets the built image as the spec's only image, so a test runs against the working tree of the chain.
+// spec.Bin defaults to opts.Binary.
+func BuildChainImage(ctx context.Context, log *zap.Logger, cli *client.Client, spec *ChainSpec, opts dockerutil.BuildOptions) error {
+	img, err := dockerutil.BuildImage(ctx, log, dockerutil.RuntimeFor(cli), opts)
+	if err != nil {
+		return err
+	}
+	spec.UseImage(img)
+	if spec.Bin == "" {
+		spec.Bin = opts.Binary
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package interchaintest_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRequiredImages(t *testing.T) {
+	log := zaptest.NewLogger(t)
+
+	specs := []*interchaintest.ChainSpec{
+		{Name: "gaia", Version: "v15.0.0"},
+		{Name: "gaia", Version: "v15.0.0", ChainName: "gaia2"},
+		{
+			Name: "osmosis", Version: "v22.0.0",
+			ChainConfig: ibc.ChainConfig{
+				SidecarConfigs: []ibc.SidecarConfig{
+					{ProcessName: "oracle", Image: ibc.DockerImage{Repository: "oracle", Version: "v1"}},
+				},
+			},
+		},
+	}
+
+	refs, err := interchaintest.RequiredImages(log, specs,
+		interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, log, relayer.CustomDockerImage("my/relayer", "dev", "100:1000")),
+		interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, log),
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"busybox:stable",
+		"ghcr.io/informalsystems/hermes:1.8.2",
+		"ghcr.io/strangelove-ventures/heighliner/gaia:v15.0.0",
+		"ghcr.io/strangelove-ventures/heighliner/osmosis:v22.0.0",
+		"my/relayer:dev",
+		"oracle:v1",
+	}, refs)
+}
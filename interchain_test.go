@@ -16,6 +16,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v8"
 	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/hermes"
 	"github.com/strangelove-ventures/interchaintest/v8/relayer/rly"
 	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
 	"github.com/strangelove-ventures/interchaintest/v8/testutil"
@@ -25,6 +26,7 @@ import (
 
 	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
 	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types" // nolint:staticcheck
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 )
 
 func TestInterchain_DuplicateChain_CosmosRly(t *testing.T) {
@@ -533,6 +535,72 @@ func TestInterchain_ConflictRejection(t *testing.T) {
 	})
 }
 
+var (
+	_ interchaintest.PathEndsGetter = (*rly.CosmosRelayer)(nil)
+	_ interchaintest.PathEndsGetter = (*hermes.Relayer)(nil)
+)
+
+func TestInterchain_RedundantRelayerRejection(t *testing.T) {
+	cf := interchaintest.NewBuiltinChainFactory(zap.NewNop(), []*interchaintest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	newInterchain := func(r1, r2 ibc.Relayer) *interchaintest.Interchain {
+		return interchaintest.NewInterchain().
+			AddChain(chains[0]).
+			AddChain(chains[1]).
+			AddRelayer(r1, "r1").
+			AddRelayer(r2, "r2").
+			AddLink(interchaintest.InterchainLink{
+				Chain1:  chains[0],
+				Chain2:  chains[1],
+				Relayer: r1,
+				Path:    "p",
+			})
+	}
+
+	t.Run("unknown relayer", func(t *testing.T) {
+		var r1, r2, r3 rly.CosmosRelayer
+
+		exp := fmt.Sprintf("relayer %v was never added to Interchain", &r3)
+		require.PanicsWithError(t, exp, func() {
+			_ = newInterchain(&r1, &r2).AddRedundantRelayer(&r1, "p", &r3)
+		})
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		var r1, r2 rly.CosmosRelayer
+
+		exp := fmt.Sprintf("relayer %v has no path named %q", &r1, "other")
+		require.PanicsWithError(t, exp, func() {
+			_ = newInterchain(&r1, &r2).AddRedundantRelayer(&r1, "other", &r2)
+		})
+	})
+
+	t.Run("primary without path ends", func(t *testing.T) {
+		var r1 struct{ ibc.Relayer }
+		var r2 rly.CosmosRelayer
+
+		exp := fmt.Sprintf("relayer %v does not report the clients and connections of its paths", &r1)
+		require.PanicsWithError(t, exp, func() {
+			_ = newInterchain(&r1, &r2).AddRedundantRelayer(&r1, "p", &r2)
+		})
+	})
+
+	t.Run("duplicate path", func(t *testing.T) {
+		var r1, r2 rly.CosmosRelayer
+
+		exp := fmt.Sprintf("relayer %v already has a path named %q", &r2, "p")
+		require.PanicsWithError(t, exp, func() {
+			_ = newInterchain(&r1, &r2).AddRedundantRelayer(&r1, "p", &r2).AddRedundantRelayer(&r1, "p", &r2)
+		})
+	})
+}
+
 func TestInterchain_AddNil(t *testing.T) {
 	require.PanicsWithError(t, "cannot add nil chain", func() {
 		_ = interchaintest.NewInterchain().AddChain(nil)
@@ -552,3 +620,60 @@ func assertTransactionIsValid(t *testing.T, resp sdk.TxResponse) {
 	require.NotEmpty(t, resp.TxHash)
 	require.NotEmpty(t, resp.Events)
 }
+
+func TestInterchain_PersistentNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+	name := dockerutil.SanitizeContainerName(t.Name()) + "-" + dockerutil.RandLowerCaseLetterString(5)
+
+	n := interchaintest.PersistentNetworkSetup(t, name)
+	require.False(t, n.Existing)
+	t.Cleanup(func() { n.Remove(t) })
+
+	newInterchain := func() (*interchaintest.Interchain, ibc.Chain) {
+		cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+			{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-persistent"}},
+		})
+		chains, err := cf.Chains(n.Name())
+		require.NoError(t, err)
+		return interchaintest.NewInterchain().AddChain(chains[0]), chains[0]
+	}
+
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+	// The first Build starts the chain and saves a funded user.
+	ic, gaia := newInterchain()
+	require.NoError(t, ic.Build(ctx, eRep, n.BuildOptions()))
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "user", math.NewInt(10_000), gaia)
+	require.NoError(t, ic.SaveWallets(ctx, gaia, users...))
+	height, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	require.NoError(t, ic.Close())
+
+	// A later Build of the same network attaches to the running chain.
+	attached := interchaintest.PersistentNetworkSetup(t, name)
+	require.True(t, attached.Existing)
+	require.Equal(t, n.NetworkID, attached.NetworkID)
+
+	ic, gaia = newInterchain()
+	defer ic.Close()
+	require.NoError(t, ic.Build(ctx, eRep, attached.BuildOptions()))
+
+	require.NoError(t, testutil.WaitForBlocks(ctx, 1, gaia))
+	attachedHeight, err := gaia.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, attachedHeight, height)
+
+	wallets := ic.Wallets(gaia)
+	require.Len(t, wallets, 1)
+	require.Equal(t, users[0].FormattedAddress(), wallets[0].FormattedAddress())
+
+	bal, err := gaia.GetBalance(ctx, wallets[0].FormattedAddress(), gaia.Config().Denom)
+	require.NoError(t, err)
+	require.True(t, bal.Equal(math.NewInt(10_000)))
+}
@@ -6,6 +6,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/strangelove-ventures/interchaintest/local-interchain/interchain"
@@ -17,11 +18,14 @@ const (
 	FlagAPIAddressOverride = "api-address"
 	FlagAPIPortOverride    = "api-port"
 
-	FlagRelayerImage        = "relayer-image"
-	FlagRelayerVersion      = "relayer-version"
-	FlagRelayerUidGid       = "relayer-uidgid"
-	FlagRelayerStartupFlags = "relayer-startup-flags"
-	FlagAuthKey             = "auth-key"
+	FlagRelayerImage           = "relayer-image"
+	FlagRelayerVersion         = "relayer-version"
+	FlagRelayerUidGid          = "relayer-uidgid"
+	FlagRelayerStartupFlags    = "relayer-startup-flags"
+	FlagRelayerRefillThreshold = "relayer-refill-threshold"
+	FlagRelayerRefillAmount    = "relayer-refill-amount"
+	FlagRelayerRefillInterval  = "relayer-refill-interval"
+	FlagAuthKey                = "auth-key"
 )
 
 var startCmd = &cobra.Command{
@@ -78,6 +82,9 @@ local-ic start https://pastebin.com/raw/Ummk4DTM
 		relayerVer := cmd.Flag(FlagRelayerVersion).Value.String()
 		relayerUidGid := cmd.Flag(FlagRelayerUidGid).Value.String()
 		relayerFlags := strings.Split(cmd.Flag(FlagRelayerStartupFlags).Value.String(), " ")
+		refillThreshold, _ := cmd.Flags().GetInt64(FlagRelayerRefillThreshold)
+		refillAmount, _ := cmd.Flags().GetInt64(FlagRelayerRefillAmount)
+		refillInterval, _ := cmd.Flags().GetDuration(FlagRelayerRefillInterval)
 
 		interchain.StartChain(parentDir, configPath, &types.AppStartConfig{
 			Address: apiAddr,
@@ -91,6 +98,10 @@ local-ic start https://pastebin.com/raw/Ummk4DTM
 					UidGid:     relayerUidGid,
 				},
 				StartupFlags: relayerFlags,
+
+				RefillThreshold: refillThreshold,
+				RefillAmount:    refillAmount,
+				RefillInterval:  refillInterval,
 			},
 
 			AuthKey: cmd.Flag(FlagAuthKey).Value.String(),
@@ -127,6 +138,9 @@ func init() {
 	startCmd.Flags().String(FlagRelayerVersion, "latest", "override the default relayer version")
 	startCmd.Flags().String(FlagRelayerUidGid, "100:1000", "override the default image UID:GID")
 	startCmd.Flags().String(FlagRelayerStartupFlags, "--block-history=100", "override the default relayer startup flags")
+	startCmd.Flags().Int64(FlagRelayerRefillThreshold, 0, "refill relayer wallets from the faucet when their balance drops below this amount (0 disables)")
+	startCmd.Flags().Int64(FlagRelayerRefillAmount, 1_000_000_000, "amount sent to a relayer wallet on each refill")
+	startCmd.Flags().Duration(FlagRelayerRefillInterval, time.Minute, "how often relayer wallet balances are checked for refills")
 
 	startCmd.Flags().String(FlagAuthKey, "", "require an auth key to use the internal API")
 }
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"syscall"
 
+	sdkmath "cosmossdk.io/math"
 	"github.com/gorilla/handlers"
 	"github.com/strangelove-ventures/interchaintest/local-interchain/interchain/router"
 	"github.com/strangelove-ventures/interchaintest/local-interchain/interchain/types"
@@ -173,13 +174,22 @@ func StartChain(installDir, chainCfgFile string, ac *types.AppStartConfig) {
 		}
 	}
 
-	// Build all chains & begin.
-	err = ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+	buildOpts := interchaintest.InterchainBuildOptions{
 		TestName:         testName,
 		Client:           client,
 		NetworkID:        network,
 		SkipPathCreation: false,
-	})
+	}
+	if rlyCfg := config.Relayer; rlyCfg.RefillThreshold > 0 {
+		buildOpts.RelayerWalletRefill = &interchaintest.RelayerWalletRefill{
+			Threshold: sdkmath.NewInt(rlyCfg.RefillThreshold),
+			Amount:    sdkmath.NewInt(rlyCfg.RefillAmount),
+			Interval:  rlyCfg.RefillInterval,
+		}
+	}
+
+	// Build all chains & begin.
+	err = ic.Build(ctx, eRep, buildOpts)
 	if err != nil {
 		log.Fatalf("ic.Build: %v", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
@@ -35,6 +36,12 @@ type RestServer struct {
 type Relayer struct {
 	DockerImage  ibc.DockerImage `json:"docker_image" yaml:"docker_image"`
 	StartupFlags []string        `json:"startup_flags" yaml:"startup_flags"`
+
+	// If RefillThreshold is positive, relayer wallets are refilled from the faucet
+	// with RefillAmount whenever their balance drops below it, checked every RefillInterval.
+	RefillThreshold int64         `json:"refill_threshold,omitempty" yaml:"refill_threshold,omitempty"`
+	RefillAmount    int64         `json:"refill_amount,omitempty" yaml:"refill_amount,omitempty"`
+	RefillInterval  time.Duration `json:"refill_interval,omitempty" yaml:"refill_interval,omitempty"`
 }
 
 type IBCChannel struct {
@@ -0,0 +1,237 @@
+package interchaintest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+)
+
+// PersistentChain is implemented by chains that can attach to the nodes
+// an earlier process started in a persistent network, in place of Initialize and Start.
+// *cosmos.CosmosChain implements it.
+type PersistentChain interface {
+	Attach(ctx context.Context, testName string, cli *client.Client, networkID string) error
+}
+
+// PersistentRelayer is implemented by relayers that can take over the home directory
+// and running container of the relayer of the same name in a persistent network.
+// The built-in Docker relayers implement it.
+type PersistentRelayer interface {
+	Attach(ctx context.Context) error
+}
+
+// PersistentNetwork is a Docker network whose chains and relayers outlive the test process,
+// so that a later test can attach to them by name instead of starting them again.
+//
+// A PersistentNetwork is passed in place of a test name to chain and relayer factories,
+// so every object it holds is labelled with its name rather than the test's.
+// Chain IDs must be the same in every test using the network; the automatic suffixes of ChainSpec
+// are, as long as the chain specs are created in the same order.
+type PersistentNetwork struct {
+	name string
+
+	Client    *client.Client
+	NetworkID string
+
+	// Existing reports whether the network was created by an earlier process.
+	Existing bool
+}
+
+// PersistentNetworkSetup returns the persistent network named name, creating it if it does not exist.
+// Nothing is removed when t completes; call Remove to tear the network down.
+//
+// If any part of the setup fails, PersistentNetworkSetup panics because the test cannot continue.
+func PersistentNetworkSetup(t dockerutil.DockerSetupTestingT, name string) *PersistentNetwork {
+	t.Helper()
+	cli, networkID, existing := dockerutil.PersistentNetworkSetup(t, name)
+	return &PersistentNetwork{
+		name:      name,
+		Client:    cli,
+		NetworkID: networkID,
+		Existing:  existing,
+	}
+}
+
+// Name returns the name that labels the objects of the network, for use as a test name.
+func (n *PersistentNetwork) Name() string {
+	return dockerutil.PersistentTestName(n.name)
+}
+
+// BuildOptions returns the options for building, or attaching to, the Interchain of the network.
+func (n *PersistentNetwork) BuildOptions() InterchainBuildOptions {
+	return InterchainBuildOptions{
+		TestName:       n.Name(),
+		Client:         n.Client,
+		NetworkID:      n.NetworkID,
+		PersistentName: n.name,
+	}
+}
+
+// Remove removes every container, volume and the network itself.
+func (n *PersistentNetwork) Remove(t dockerutil.DockerSetupTestingT) {
+	dockerutil.RemovePersistentNetwork(t, n.Client, n.name)
+}
+
+// persistentState is what a persistent Interchain needs to attach, beyond what chains and relayers
+// rediscover from the labels of their own containers.
+type persistentState struct {
+	RelayerWallets []persistentRelayerWallet `json:"relayer_wallets"`
+
+	// Wallets saved with SaveWallets, keyed by chain ID.
+	Wallets map[string][]persistentWallet `json:"wallets,omitempty"`
+}
+
+type persistentRelayerWallet struct {
+	Relayer string           `json:"relayer"`
+	ChainID string           `json:"chain_id"`
+	Wallet  persistentWallet `json:"wallet"`
+}
+
+// persistentWallet is the saved form of an ibc.Wallet, and implements it once loaded.
+type persistentWallet struct {
+	Key       string `json:"key_name"`
+	Formatted string `json:"address"`
+	Bytes     []byte `json:"address_bytes"`
+	Words     string `json:"mnemonic"`
+}
+
+var _ ibc.Wallet = persistentWallet{}
+
+func newPersistentWallet(w ibc.Wallet) persistentWallet {
+	return persistentWallet{
+		Key:       w.KeyName(),
+		Formatted: w.FormattedAddress(),
+		Bytes:     w.Address(),
+		Words:     w.Mnemonic(),
+	}
+}
+
+func (w persistentWallet) KeyName() string          { return w.Key }
+func (w persistentWallet) FormattedAddress() string { return w.Formatted }
+func (w persistentWallet) Mnemonic() string         { return w.Words }
+func (w persistentWallet) Address() []byte          { return w.Bytes }
+
+// SaveWallets records wallets of chain in the persistent network of ic,
+// so that tests attaching to the network later find them with Wallets.
+// The keys of the wallets must already be in the chain's keyring, as after GetAndFundTestUsers.
+func (ic *Interchain) SaveWallets(ctx context.Context, chain ibc.Chain, wallets ...ibc.Wallet) error {
+	if ic.persistentName == "" {
+		return errors.New("SaveWallets requires an Interchain built with InterchainBuildOptions.PersistentName")
+	}
+
+	chainID := chain.Config().ChainID
+	ic.wallets[chainID] = append(ic.wallets[chainID], wallets...)
+	return ic.savePersistentState(ctx)
+}
+
+// Wallets returns the wallets of chain saved with SaveWallets,
+// by this test or by an earlier test using the same persistent network.
+func (ic *Interchain) Wallets(chain ibc.Chain) []ibc.Wallet {
+	return append([]ibc.Wallet(nil), ic.wallets[chain.Config().ChainID]...)
+}
+
+func (ic *Interchain) savePersistentState(ctx context.Context) error {
+	state := persistentState{Wallets: make(map[string][]persistentWallet, len(ic.wallets))}
+
+	for rc, w := range ic.relayerWallets {
+		state.RelayerWallets = append(state.RelayerWallets, persistentRelayerWallet{
+			Relayer: ic.relayers[rc.R],
+			ChainID: rc.C.Config().ChainID,
+			Wallet:  newPersistentWallet(w),
+		})
+	}
+	sort.Slice(state.RelayerWallets, func(i, j int) bool {
+		a, b := state.RelayerWallets[i], state.RelayerWallets[j]
+		if a.Relayer != b.Relayer {
+			return a.Relayer < b.Relayer
+		}
+		return a.ChainID < b.ChainID
+	})
+
+	for chainID, wallets := range ic.wallets {
+		for _, w := range wallets {
+			state.Wallets[chainID] = append(state.Wallets[chainID], newPersistentWallet(w))
+		}
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal persistent state: %w", err)
+	}
+	return dockerutil.SavePersistentState(ctx, ic.persistentRuntime, ic.persistentName, b)
+}
+
+// loadPersistentState returns the state saved by an earlier Build of the persistent network named name.
+func loadPersistentState(ctx context.Context, rt dockerutil.ContainerRuntime, name string) (*persistentState, error) {
+	b, found, err := dockerutil.LoadPersistentState(ctx, rt, name)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	var state persistentState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, fmt.Errorf("unmarshal state of persistent network %s: %w", name, err)
+	}
+	return &state, nil
+}
+
+// attach rediscovers the chains and relayers of ic from the persistent network of opts,
+// in place of starting them.
+func (ic *Interchain) attach(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions, state *persistentState) error {
+	ic.log.Info("Attaching to persistent network")
+
+	if err := ic.cs.Attach(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
+		return fmt.Errorf("failed to attach to chains: %w", err)
+	}
+
+	chainsByID := make(map[string]ibc.Chain, len(ic.chains))
+	for c, id := range ic.chains {
+		chainsByID[id] = c
+	}
+	relayersByName := make(map[string]ibc.Relayer, len(ic.relayers))
+	for r, name := range ic.relayers {
+		relayersByName[name] = r
+	}
+
+	for r, name := range ic.relayers {
+		pr, ok := r.(PersistentRelayer)
+		if !ok {
+			return fmt.Errorf("relayer %s of type %T cannot be attached to", name, r)
+		}
+		if err := pr.Attach(ctx); err != nil {
+			return fmt.Errorf("failed to attach to relayer %s: %w", name, err)
+		}
+	}
+
+	ic.relayerWallets = make(map[relayerChain]ibc.Wallet, len(state.RelayerWallets))
+	for _, rw := range state.RelayerWallets {
+		r, c := relayersByName[rw.Relayer], chainsByID[rw.ChainID]
+		if r == nil || c == nil {
+			// Not part of this Interchain.
+			continue
+		}
+		ic.relayerWallets[relayerChain{R: r, C: c}] = rw.Wallet
+
+		// The relayer's keys are in its home directory; it only needs to know the wallet.
+		if aw, ok := r.(interface {
+			AddWallet(chainID string, wallet ibc.Wallet)
+		}); ok {
+			aw.AddWallet(rw.ChainID, rw.Wallet)
+		}
+	}
+
+	for chainID, wallets := range state.Wallets {
+		for _, w := range wallets {
+			ic.wallets[chainID] = append(ic.wallets[chainID], w)
+		}
+	}
+
+	return ic.startTracking(ctx, rep, opts)
+}
@@ -0,0 +1,52 @@
+package interchaintest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/hermes"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/rly"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ PersistentChain   = (*cosmos.CosmosChain)(nil)
+	_ PersistentRelayer = (*rly.CosmosRelayer)(nil)
+	_ PersistentRelayer = (*hermes.Relayer)(nil)
+)
+
+func TestPersistentState_RoundTrip(t *testing.T) {
+	w := persistentWallet{
+		Key:       "user",
+		Formatted: "cosmos1abc",
+		Bytes:     []byte{1, 2, 3},
+		Words:     "abandon abandon about",
+	}
+	state := persistentState{
+		RelayerWallets: []persistentRelayerWallet{{Relayer: "r", ChainID: "gaia-1", Wallet: w}},
+		Wallets:        map[string][]persistentWallet{"gaia-1": {w}},
+	}
+
+	b, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	var got persistentState
+	require.NoError(t, json.Unmarshal(b, &got))
+	require.Equal(t, state, got)
+
+	var wallet ibc.Wallet = got.Wallets["gaia-1"][0]
+	require.Equal(t, "user", wallet.KeyName())
+	require.Equal(t, "cosmos1abc", wallet.FormattedAddress())
+	require.Equal(t, []byte{1, 2, 3}, wallet.Address())
+	require.Equal(t, "abandon abandon about", wallet.Mnemonic())
+}
+
+func TestInterchain_SaveWallets_NotPersistent(t *testing.T) {
+	ic := NewInterchain()
+	err := ic.SaveWallets(context.Background(), nil)
+	require.ErrorContains(t, err, "PersistentName")
+	require.Empty(t, ic.wallets)
+}
@@ -1,23 +1,21 @@
 package relayer
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"path"
 	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
-	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
-	"github.com/docker/docker/pkg/stdcopy"
 	"go.uber.org/zap"
 
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -34,6 +32,7 @@ type DockerRelayer struct {
 
 	networkID  string
 	client     *client.Client
+	runtime    dockerutil.ContainerRuntime
 	volumeName string
 
 	testName string
@@ -50,6 +49,8 @@ type DockerRelayer struct {
 	homeDir string
 
 	extraStartupFlags []string
+
+	resources ibc.ResourceLimits
 }
 
 var _ ibc.Relayer = (*DockerRelayer)(nil)
@@ -63,6 +64,7 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 
 		networkID: networkID,
 		client:    cli,
+		runtime:   dockerutil.RuntimeFor(cli),
 
 		// pull true by default, can be overridden with options
 		pullImage: true,
@@ -83,15 +85,15 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		return nil, fmt.Errorf("pulling container image %s: %w", containerImage.Ref(), err)
 	}
 
-	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
-		// Have to leave Driver unspecified for Docker Desktop compatibility.
+	volumeName, err := r.runtime.CreateVolume(ctx, map[string]string{
+		dockerutil.CleanupLabel: testName,
 
-		Labels: map[string]string{dockerutil.CleanupLabel: testName},
+		dockerutil.NodeOwnerLabel: r.Name(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating volume: %w", err)
 	}
-	r.volumeName = v.Name
+	r.volumeName = volumeName
 
 	// The volume is created owned by root,
 	// but we configure the relayer to run as a non-root user,
@@ -100,7 +102,7 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
 		Log: r.log,
 
-		Client: r.client,
+		Runtime: r.runtime,
 
 		VolumeName: r.volumeName,
 		ImageRef:   containerImage.Ref(),
@@ -130,7 +132,7 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 // WriteFileToHomeDir writes the given contents to a file at the relative path specified. The file is relative
 // to the home directory in the relayer container.
 func (r *DockerRelayer) WriteFileToHomeDir(ctx context.Context, relativePath string, contents []byte) error {
-	fw := dockerutil.NewFileWriter(r.log, r.client, r.testName)
+	fw := dockerutil.NewFileWriter(r.log, r.runtime, r.testName)
 	if err := fw.WriteFile(ctx, r.volumeName, relativePath, contents); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
@@ -140,7 +142,7 @@ func (r *DockerRelayer) WriteFileToHomeDir(ctx context.Context, relativePath str
 // ReadFileFromHomeDir reads a file at the relative path specified and returns the contents. The file is
 // relative to the home directory in the relayer container.
 func (r *DockerRelayer) ReadFileFromHomeDir(ctx context.Context, relativePath string) ([]byte, error) {
-	fr := dockerutil.NewFileRetriever(r.log, r.client, r.testName)
+	fr := dockerutil.NewFileRetriever(r.log, r.runtime, r.testName)
 	bytes, err := fr.SingleFileContent(ctx, r.volumeName, relativePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve %s: %w", relativePath, err)
@@ -148,9 +150,38 @@ func (r *DockerRelayer) ReadFileFromHomeDir(ctx context.Context, relativePath st
 	return bytes, nil
 }
 
+// CopyDirToVolume copies the files of the host directory srcDir selected by filter
+// to the directory at the relative path specified, which is relative to the home directory in the relayer container.
+func (r *DockerRelayer) CopyDirToVolume(ctx context.Context, srcDir, relativePath string, filter dockerutil.DirFilter) error {
+	if err := dockerutil.CopyDirToVolume(ctx, r.volumeDirOptions(filter), srcDir, relativePath); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", srcDir, err)
+	}
+	return nil
+}
+
+// CopyDirFromVolume copies the files selected by filter of the directory at the relative path specified,
+// which is relative to the home directory in the relayer container, to the host directory dstDir.
+func (r *DockerRelayer) CopyDirFromVolume(ctx context.Context, relativePath, dstDir string, filter dockerutil.DirFilter) error {
+	if err := dockerutil.CopyDirFromVolume(ctx, r.volumeDirOptions(filter), relativePath, dstDir); err != nil {
+		return fmt.Errorf("failed to retrieve %s: %w", relativePath, err)
+	}
+	return nil
+}
+
+func (r *DockerRelayer) volumeDirOptions(filter dockerutil.DirFilter) dockerutil.VolumeDirOptions {
+	return dockerutil.VolumeDirOptions{
+		Log:        r.log,
+		Runtime:    r.runtime,
+		VolumeName: r.volumeName,
+		TestName:   r.testName,
+		UidGid:     r.ContainerImage().UidGid,
+		Filter:     filter,
+	}
+}
+
 // Modify a toml config file in relayer home directory
 func (r *DockerRelayer) ModifyTomlConfigFile(ctx context.Context, relativePath string, modification testutil.Toml) error {
-	return testutil.ModifyTomlConfigFile(ctx, r.log, r.client, r.testName, r.volumeName, relativePath, modification)
+	return testutil.ModifyTomlConfigFile(ctx, r.log, r.runtime, r.testName, r.volumeName, relativePath, modification)
 }
 
 // AddWallet adds a stores a wallet for the given chain ID.
@@ -170,7 +201,7 @@ func (r *DockerRelayer) AddChainConfiguration(ctx context.Context, rep ibc.Relay
 		return fmt.Errorf("failed to generate config content: %w", err)
 	}
 
-	fw := dockerutil.NewFileWriter(r.log, r.client, r.testName)
+	fw := dockerutil.NewFileWriter(r.log, r.runtime, r.testName)
 	if err := fw.WriteFile(ctx, r.volumeName, chainConfigFile, configContent); err != nil {
 		return fmt.Errorf("failed to rly config: %w", err)
 	}
@@ -300,16 +331,25 @@ func (r *DockerRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporte
 }
 
 func (r *DockerRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) ibc.RelayerExecResult {
-	job := dockerutil.NewImage(r.log, r.client, r.networkID, r.testName, r.ContainerImage().Repository, r.ContainerImage().Version)
+	job := dockerutil.NewImage(r.log, r.runtime, r.networkID, r.testName, r.ContainerImage().Repository, r.ContainerImage().Version)
 	opts := dockerutil.ContainerOptions{
-		Env:   env,
-		Binds: r.Bind(),
+		Env:       env,
+		Binds:     r.Bind(),
+		Resources: r.resources,
 	}
 
+	ctx, span := tracing.Start(ctx, "relayer.exec",
+		tracing.AttrRelayer.String(r.Name()),
+		tracing.AttrCommand.StringSlice(cmd),
+	)
+
 	startedAt := time.Now()
 	res := job.Run(ctx, cmd, opts)
 
 	defer func() {
+		span.SetAttributes(attribute.Int("interchaintest.exit_code", res.ExitCode))
+		tracing.End(span, res.Err)
+
 		rep.TrackRelayerExec(
 			r.Name(),
 			cmd,
@@ -366,7 +406,9 @@ func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecRep
 
 	cmd := r.c.StartRelayer(r.HomeDir(), pathNames...)
 
-	r.containerLifecycle = dockerutil.NewContainerLifecycle(r.log, r.client, containerName)
+	r.containerLifecycle = dockerutil.NewContainerLifecycle(r.log, r.runtime, containerName)
+	r.containerLifecycle.SetResources(r.resources)
+	r.containerLifecycle.SetLabels(map[string]string{dockerutil.NodeOwnerLabel: r.Name()})
 
 	if err := r.containerLifecycle.CreateContainer(
 		ctx, r.testName, r.networkID, containerImage, nil,
@@ -378,6 +420,56 @@ func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecRep
 	return r.containerLifecycle.StartContainer(ctx)
 }
 
+// Attach switches r to the home volume of the relayer of the same name created by an earlier process
+// in the persistent network of r's test name, removing the volume r was created with.
+// If that relayer was started, r also manages its container, so StopRelayer stops it.
+//
+// Relayers are matched by Name, so a persistent network may hold only one relayer of each implementation.
+func (r *DockerRelayer) Attach(ctx context.Context) error {
+	volumes, err := dockerutil.OwnedVolumes(ctx, r.runtime, r.testName, r.Name())
+	if err != nil {
+		return err
+	}
+	var previous []string
+	for _, v := range volumes {
+		if v != r.volumeName {
+			previous = append(previous, v)
+		}
+	}
+	switch len(previous) {
+	case 0:
+		return fmt.Errorf("no home volume of relayer %s found in %s", r.Name(), r.testName)
+	case 1:
+	default:
+		return fmt.Errorf("found %d home volumes of relayer %s in %s, want 1", len(previous), r.Name(), r.testName)
+	}
+
+	if err := r.runtime.RemoveVolume(ctx, r.volumeName); err != nil {
+		return fmt.Errorf("remove unused volume %s: %w", r.volumeName, err)
+	}
+	r.volumeName = previous[0]
+
+	cs, err := dockerutil.LabelledContainers(ctx, r.runtime, r.testName, map[string]string{
+		dockerutil.NodeOwnerLabel: r.Name(),
+	})
+	if err != nil {
+		return err
+	}
+	if len(cs) == 0 {
+		return nil
+	}
+	r.containerLifecycle = dockerutil.NewContainerLifecycle(r.log, r.runtime, strings.TrimPrefix(cs[0].Names[0], "/"))
+	return r.containerLifecycle.Attach(ctx, cs[0].ID)
+}
+
+// Stats returns a single sample of the resource usage of the container started by StartRelayer.
+func (r *DockerRelayer) Stats(ctx context.Context) (dockerutil.ContainerStats, error) {
+	if r.containerLifecycle == nil {
+		return dockerutil.ContainerStats{}, fmt.Errorf("relayer %s is not running", r.Name())
+	}
+	return r.containerLifecycle.Stats(ctx)
+}
+
 func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
 	if r.containerLifecycle == nil {
 		return nil
@@ -386,32 +478,88 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 		return err
 	}
 
-	stdoutBuf := new(bytes.Buffer)
-	stderrBuf := new(bytes.Buffer)
-	containerID := r.containerLifecycle.ContainerID()
-	rc, err := r.client.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-		Tail:       "50",
+	return r.reportAndRemoveContainer(ctx, rep)
+}
+
+// KillRelayer terminates the relayer with SIGKILL, without giving it a chance to shut down gracefully.
+// Besides the container started through StartRelayer, every in-flight command container using the relayer's
+// home directory is killed too, so KillRelayer can interrupt e.g. a LinkPath handshake midway.
+//
+// The relayer can be started again with StartRelayer.
+func (r *DockerRelayer) KillRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	containers, err := r.runtime.ListContainers(ctx, map[string]string{dockerutil.CleanupLabel: r.testName})
+	if err != nil {
+		return fmt.Errorf("KillRelayer: listing containers: %w", err)
+	}
+	if r.containerLifecycle != nil {
+		r.containerLifecycle.ExpectExit()
+	}
+	for _, c := range containers {
+		if !mountsVolume(c, r.volumeName) {
+			continue
+		}
+		if err := r.runtime.KillContainer(ctx, c.ID); err != nil {
+			return fmt.Errorf("KillRelayer: killing container %s: %w", c.ID, err)
+		}
+	}
+
+	if r.containerLifecycle == nil {
+		return nil
+	}
+	return r.reportAndRemoveContainer(ctx, rep)
+}
+
+// mountsVolume reports whether c mounts the named volume.
+func mountsVolume(c types.Container, volumeName string) bool {
+	for _, m := range c.Mounts {
+		if m.Name == volumeName {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveHomeDirPath recursively removes the file or directory at the relative path specified,
+// which is relative to the home directory in the relayer container.
+// It is intended for simulating lost relayer state, and should only be called while the relayer is not running.
+func (r *DockerRelayer) RemoveHomeDirPath(ctx context.Context, relativePath string) error {
+	job := dockerutil.NewImage(r.log, r.runtime, r.networkID, r.testName, "busybox", "stable")
+	res := job.Run(ctx, []string{"rm", "-rf", path.Join(r.HomeDir(), relativePath)}, dockerutil.ContainerOptions{
+		Binds: r.Bind(),
+		User:  dockerutil.GetRootUserString(),
 	})
+	if res.Err != nil {
+		return fmt.Errorf("failed to remove %s: %w", relativePath, res.Err)
+	}
+	return nil
+}
+
+// CorruptHomeDirFile truncates the file at the relative path specified to half its length,
+// as if the relayer crashed partway through writing it. The file is relative to the home directory
+// in the relayer container.
+func (r *DockerRelayer) CorruptHomeDirFile(ctx context.Context, relativePath string) error {
+	contents, err := r.ReadFileFromHomeDir(ctx, relativePath)
 	if err != nil {
-		return fmt.Errorf("StopRelayer: retrieving ContainerLogs: %w", err)
+		return err
 	}
-	defer func() { _ = rc.Close() }()
+	return r.WriteFileToHomeDir(ctx, relativePath, contents[:len(contents)/2])
+}
 
-	// Logs are multiplexed into one stream; see docs for ContainerLogs.
-	_, err = stdcopy.StdCopy(stdoutBuf, stderrBuf, rc)
+// reportAndRemoveContainer reports the logs of the exited container started through StartRelayer,
+// then removes it so that the relayer may be started again.
+func (r *DockerRelayer) reportAndRemoveContainer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	containerID := r.containerLifecycle.ContainerID()
+	stdoutBytes, stderrBytes, err := r.runtime.ContainerLogs(ctx, containerID, 50)
 	if err != nil {
-		return fmt.Errorf("StopRelayer: demuxing logs: %w", err)
+		return fmt.Errorf("retrieving ContainerLogs: %w", err)
 	}
-	_ = rc.Close()
 
-	stdout := stdoutBuf.String()
-	stderr := stderrBuf.String()
+	stdout := string(stdoutBytes)
+	stderr := string(stderrBytes)
 
-	c, err := r.client.ContainerInspect(ctx, containerID)
+	c, err := r.runtime.InspectContainer(ctx, containerID)
 	if err != nil {
-		return fmt.Errorf("StopRelayer: inspecting container: %w", err)
+		return fmt.Errorf("inspecting container: %w", err)
 	}
 
 	startedAt, err := time.Parse(time.RFC3339Nano, c.State.StartedAt)
@@ -455,14 +603,14 @@ func (r *DockerRelayer) PauseRelayer(ctx context.Context) error {
 	if r.containerLifecycle == nil {
 		return fmt.Errorf("container not running")
 	}
-	return r.client.ContainerPause(ctx, r.containerLifecycle.ContainerID())
+	return r.containerLifecycle.PauseContainer(ctx)
 }
 
 func (r *DockerRelayer) ResumeRelayer(ctx context.Context) error {
 	if r.containerLifecycle == nil {
 		return fmt.Errorf("container not running")
 	}
-	return r.client.ContainerUnpause(ctx, r.containerLifecycle.ContainerID())
+	return r.containerLifecycle.UnpauseContainer(ctx)
 }
 
 func (r *DockerRelayer) ContainerImage() ibc.DockerImage {
@@ -481,14 +629,7 @@ func (r *DockerRelayer) pullContainerImageIfNecessary(containerImage ibc.DockerI
 		return nil
 	}
 
-	rc, err := r.client.ImagePull(context.TODO(), containerImage.Ref(), types.ImagePullOptions{})
-	if err != nil {
-		return err
-	}
-
-	_, _ = io.Copy(io.Discard, rc)
-	_ = rc.Close()
-	return nil
+	return dockerutil.PullImage(context.TODO(), r.client, containerImage.Ref())
 }
 
 func (r *DockerRelayer) Name() string {
@@ -25,7 +25,7 @@ func (c commander) Name() string {
 }
 
 func (c commander) DefaultContainerImage() string {
-	return defaultContainerImage
+	return DefaultContainerImage
 }
 
 func (c commander) DefaultContainerVersion() string {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 )
 
 // NewConfig returns a hermes Config with an entry for each of the provided ChainConfigs.
@@ -193,4 +195,51 @@ type Chain struct {
 	TrustingPeriod   string         `toml:"trusting_period"`
 	TrustThreshold   TrustThreshold `toml:"trust_threshold"`
 	MemoPrefix       string         `toml:"memo_prefix,omitempty"`
+	PacketFilter     *PacketFilter  `toml:"packet_filter,omitempty"`
+}
+
+// PacketFilter restricts the channels hermes relays packets on for a chain.
+// Each entry of List is a port ID and channel ID pair, either of which may be the "*" wildcard.
+type PacketFilter struct {
+	Policy string     `toml:"policy"`
+	List   [][]string `toml:"list"`
+}
+
+// newPacketFilter converts an interchaintest channel filter into a hermes packet filter.
+// As in the Go relayer, the channels are matched on any port.
+func newPacketFilter(filter ibc.ChannelFilter) (*PacketFilter, error) {
+	var policy string
+	switch filter.Rule {
+	case "allowlist":
+		policy = "allow"
+	case "denylist":
+		policy = "deny"
+	default:
+		return nil, fmt.Errorf("unsupported channel filter rule %q, must be allowlist or denylist", filter.Rule)
+	}
+
+	list := make([][]string, len(filter.ChannelList))
+	for i, channelID := range filter.ChannelList {
+		list[i] = []string{"*", channelID}
+	}
+	return &PacketFilter{Policy: policy, List: list}, nil
+}
+
+// applyChainSettings overrides the fields of c that are set in settings.
+func (c *Chain) applyChainSettings(settings ibc.PathChainSettings) {
+	if settings.MemoPrefix != nil {
+		c.MemoPrefix = *settings.MemoPrefix
+	}
+	if settings.DefaultGas != nil {
+		c.DefaultGas = int(*settings.DefaultGas)
+	}
+	if settings.MaxGas != nil {
+		c.MaxGas = int(*settings.MaxGas)
+	}
+	if settings.GasMultiplier != nil {
+		c.GasMultiplier = *settings.GasMultiplier
+	}
+	if settings.GasPrice != nil {
+		c.GasPrice.Price = *settings.GasPrice
+	}
 }
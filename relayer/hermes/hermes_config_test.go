@@ -0,0 +1,78 @@
+package hermes
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigContent_PathUpdateOptions(t *testing.T) {
+	newChainConfig := func(chainID string) ChainConfig {
+		return ChainConfig{
+			cfg: ibc.ChainConfig{
+				ChainID:       chainID,
+				Denom:         "uatom",
+				GasPrices:     "0.01uatom",
+				GasAdjustment: 1.3,
+				Bech32Prefix:  "cosmos",
+			},
+			keyName:  chainID,
+			rpcAddr:  "http://" + chainID + ":26657",
+			grpcAddr: chainID + ":9090",
+		}
+	}
+
+	r := &Relayer{
+		chainConfigs:   []ChainConfig{newChainConfig("g1"), newChainConfig("g2")},
+		chainOverrides: map[string]*chainOverride{},
+		paths: map[string]*pathConfiguration{
+			"p": {chainA: pathChainConfig{chainID: "g1"}, chainB: pathChainConfig{chainID: "g2"}},
+		},
+	}
+
+	clearInterval := uint64(10)
+	clearOnStart := false
+	memoPrefix := "racer"
+	maxGas := uint64(1_000_000)
+	require.NoError(t, r.updatePath("p", ibc.PathUpdateOptions{
+		ChannelFilter: &ibc.ChannelFilter{
+			Rule:        "allowlist",
+			ChannelList: []string{"channel-0", "channel-1"},
+		},
+		ClearInterval:    &clearInterval,
+		ClearOnStart:     &clearOnStart,
+		DstChainSettings: &ibc.PathChainSettings{MemoPrefix: &memoPrefix, MaxGas: &maxGas},
+	}))
+
+	bz, err := r.configContent()
+	require.NoError(t, err)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal(bz, &cfg))
+
+	require.Equal(t, 10, cfg.Mode.Packets.ClearInterval)
+	require.False(t, cfg.Mode.Packets.ClearOnStart)
+	require.True(t, cfg.Mode.Packets.Enabled)
+
+	require.Len(t, cfg.Chains, 2)
+	require.Equal(t, &PacketFilter{
+		Policy: "allow",
+		List:   [][]string{{"*", "channel-0"}, {"*", "channel-1"}},
+	}, cfg.Chains[0].PacketFilter)
+	require.Equal(t, "hermes", cfg.Chains[0].MemoPrefix)
+	require.Equal(t, 400000, cfg.Chains[0].MaxGas)
+
+	require.Nil(t, cfg.Chains[1].PacketFilter)
+	require.Equal(t, "racer", cfg.Chains[1].MemoPrefix)
+	require.Equal(t, 1_000_000, cfg.Chains[1].MaxGas)
+	require.Equal(t, 100000, cfg.Chains[1].DefaultGas)
+
+	t.Run("invalid filter rule", func(t *testing.T) {
+		err := r.updatePath("p", ibc.PathUpdateOptions{
+			ChannelFilter: &ibc.ChannelFilter{Rule: "blocklist"},
+		})
+		require.EqualError(t, err, `unsupported channel filter rule "blocklist", must be allowlist or denylist`)
+	})
+}
@@ -18,7 +18,7 @@ import (
 
 const (
 	hermes                  = "hermes"
-	defaultContainerImage   = "ghcr.io/informalsystems/hermes"
+	DefaultContainerImage   = "ghcr.io/informalsystems/hermes"
 	DefaultContainerVersion = "1.8.2"
 
 	hermesDefaultUidGid = "1000:1000"
@@ -42,6 +42,22 @@ type Relayer struct {
 	paths        map[string]*pathConfiguration
 	chainConfigs []ChainConfig
 	chainLocks   map[string]*sync.Mutex
+
+	// packets and chainOverrides hold the settings applied through UpdatePath,
+	// which are layered on top of the defaults each time the config file is written.
+	// packets is relayer-wide, as hermes has a single packet clearing configuration.
+	packets        *Packets
+	chainOverrides map[string]*chainOverride
+
+	// configLock serializes generating, writing and validating the config file,
+	// so that the file last written holds the latest settings.
+	configLock sync.Mutex
+}
+
+// chainOverride holds the per-chain config settings applied through UpdatePath.
+type chainOverride struct {
+	packetFilter *PacketFilter
+	settings     ibc.PathChainSettings
 }
 
 // ChainConfig holds all values required to write an entry in the "chains" section in the hermes config file.
@@ -76,24 +92,25 @@ func NewHermesRelayer(log *zap.Logger, testName string, cli *client.Client, netw
 	c.extraStartFlags = dr.GetExtraStartupFlags()
 
 	return &Relayer{
-		DockerRelayer: dr,
-		chainLocks:    map[string]*sync.Mutex{},
+		DockerRelayer:  dr,
+		chainLocks:     map[string]*sync.Mutex{},
+		chainOverrides: map[string]*chainOverride{},
 	}
 }
 
 // AddChainConfiguration is called once per chain configuration, which means that in the case of hermes, the single
 // config file is overwritten with a new entry each time this function is called.
 func (r *Relayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
-	configContent, err := r.configContent(chainConfig, keyName, rpcAddr, grpcAddr)
-	if err != nil {
-		return fmt.Errorf("failed to generate config content: %w", err)
-	}
-
-	if err := r.WriteFileToHomeDir(ctx, hermesConfigPath, configContent); err != nil {
-		return fmt.Errorf("failed to write hermes config: %w", err)
-	}
+	r.lock.Lock()
+	r.chainConfigs = append(r.chainConfigs, ChainConfig{
+		cfg:      chainConfig,
+		keyName:  keyName,
+		rpcAddr:  rpcAddr,
+		grpcAddr: grpcAddr,
+	})
+	r.lock.Unlock()
 
-	if err := r.validateConfig(ctx, rep); err != nil {
+	if err := r.writeConfig(ctx, rep); err != nil {
 		return err
 	}
 	r.lock.Lock()
@@ -296,7 +313,36 @@ func (r *Relayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, c
 	return nil
 }
 
+// UpdatePath updates the in-memory path and regenerates the hermes config file,
+// applying any channel filter, packet clearing, and per-chain settings in opts.
+// Packet clearing settings are global in hermes, so they apply to every path of the relayer.
 func (r *Relayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.PathUpdateOptions) error {
+	if err := r.updatePath(pathName, opts); err != nil {
+		return err
+	}
+	return r.writeConfig(ctx, rep)
+}
+
+// GetPathEnds returns the clients and connections of the path, as created by LinkPath or set by UpdatePath.
+func (r *Relayer) GetPathEnds(_ context.Context, pathName string) (ibc.PathEnds, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	path, ok := r.paths[pathName]
+	if !ok {
+		return ibc.PathEnds{}, fmt.Errorf("path %s not found", pathName)
+	}
+	if path.chainA.connectionID == "" || path.chainB.connectionID == "" {
+		return ibc.PathEnds{}, fmt.Errorf("path %s has no connection", pathName)
+	}
+	return ibc.PathEnds{
+		SrcClientID: path.chainA.clientID,
+		SrcConnID:   path.chainA.connectionID,
+		DstClientID: path.chainB.clientID,
+		DstConnID:   path.chainB.connectionID,
+	}, nil
+}
+
+func (r *Relayer) updatePath(pathName string, opts ibc.PathUpdateOptions) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 	// the concept of paths doesn't exist in hermes, but update our in-memory paths so we can use them elsewhere
@@ -322,9 +368,71 @@ func (r *Relayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, p
 	if opts.DstConnID != nil {
 		path.chainB.connectionID = *opts.DstConnID
 	}
+
+	// hermes filters channels per chain, so the filter applies to the channels on the src chain,
+	// matching the semantics of the Go relayer's path filter.
+	if opts.ChannelFilter != nil {
+		filter, err := newPacketFilter(*opts.ChannelFilter)
+		if err != nil {
+			return err
+		}
+		r.chainOverride(path.chainA.chainID).packetFilter = filter
+	}
+	if opts.SrcChainSettings != nil {
+		mergeChainSettings(&r.chainOverride(path.chainA.chainID).settings, *opts.SrcChainSettings)
+	}
+	if opts.DstChainSettings != nil {
+		mergeChainSettings(&r.chainOverride(path.chainB.chainID).settings, *opts.DstChainSettings)
+	}
+
+	if opts.ClearInterval != nil || opts.ClearOnStart != nil || opts.TxConfirmation != nil {
+		if r.packets == nil {
+			packets := NewConfig().Mode.Packets
+			r.packets = &packets
+		}
+		if opts.ClearInterval != nil {
+			r.packets.ClearInterval = int(*opts.ClearInterval)
+		}
+		if opts.ClearOnStart != nil {
+			r.packets.ClearOnStart = *opts.ClearOnStart
+		}
+		if opts.TxConfirmation != nil {
+			r.packets.TxConfirmation = *opts.TxConfirmation
+		}
+	}
 	return nil
 }
 
+// chainOverride returns the overrides for chainID, creating them if necessary.
+// The caller must hold r.lock.
+func (r *Relayer) chainOverride(chainID string) *chainOverride {
+	o, ok := r.chainOverrides[chainID]
+	if !ok {
+		o = &chainOverride{}
+		r.chainOverrides[chainID] = o
+	}
+	return o
+}
+
+// mergeChainSettings copies every set field of src into dst.
+func mergeChainSettings(dst *ibc.PathChainSettings, src ibc.PathChainSettings) {
+	if src.MemoPrefix != nil {
+		dst.MemoPrefix = src.MemoPrefix
+	}
+	if src.DefaultGas != nil {
+		dst.DefaultGas = src.DefaultGas
+	}
+	if src.MaxGas != nil {
+		dst.MaxGas = src.MaxGas
+	}
+	if src.GasMultiplier != nil {
+		dst.GasMultiplier = src.GasMultiplier
+	}
+	if src.GasPrice != nil {
+		dst.GasPrice = src.GasPrice
+	}
+}
+
 func (r *Relayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelID string) error {
 	r.lock.RLock()
 	path := r.paths[pathName]
@@ -368,18 +476,23 @@ func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter,
 }
 
 // configContent returns the contents of the hermes config file as a byte array. Note: as hermes expects a single file
-// rather than multiple config files, we need to maintain a list of chain configs each time they are added to write the
-// full correct file update calling Relayer.AddChainConfiguration.
-func (r *Relayer) configContent(cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
-	r.lock.Lock()
-	defer r.lock.Unlock()
-	r.chainConfigs = append(r.chainConfigs, ChainConfig{
-		cfg:      cfg,
-		keyName:  keyName,
-		rpcAddr:  rpcAddr,
-		grpcAddr: grpcAddr,
-	})
+// rather than multiple config files, we need to maintain a list of chain configs, and any overrides applied through
+// Relayer.UpdatePath, in order to write the full correct file each time it changes.
+func (r *Relayer) configContent() ([]byte, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
 	hermesConfig := NewConfig(r.chainConfigs...)
+	if r.packets != nil {
+		hermesConfig.Mode.Packets = *r.packets
+	}
+	for i := range hermesConfig.Chains {
+		o, ok := r.chainOverrides[hermesConfig.Chains[i].ID]
+		if !ok {
+			continue
+		}
+		hermesConfig.Chains[i].PacketFilter = o.packetFilter
+		hermesConfig.Chains[i].applyChainSettings(o.settings)
+	}
 	bz, err := toml.Marshal(hermesConfig)
 	if err != nil {
 		return nil, err
@@ -387,6 +500,23 @@ func (r *Relayer) configContent(cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr
 	return bz, nil
 }
 
+// writeConfig regenerates and validates the hermes config file.
+func (r *Relayer) writeConfig(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
+
+	configContent, err := r.configContent()
+	if err != nil {
+		return fmt.Errorf("failed to generate config content: %w", err)
+	}
+
+	if err := r.WriteFileToHomeDir(ctx, hermesConfigPath, configContent); err != nil {
+		return fmt.Errorf("failed to write hermes config: %w", err)
+	}
+
+	return r.validateConfig(ctx, rep)
+}
+
 // validateConfig validates the hermes config file. Any errors are propagated to the test.
 func (r *Relayer) validateConfig(ctx context.Context, rep ibc.RelayerExecReporter) error {
 	cmd := []string{hermes, "--config", fmt.Sprintf("%s/%s", r.HomeDir(), hermesConfigPath), "config", "validate"}
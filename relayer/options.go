@@ -7,6 +7,19 @@ import (
 // RelayerOpt is a functional option for configuring a relayer.
 type RelayerOpt func(relayer *DockerRelayer)
 
+// ResolveImage returns the image a relayer built with options would use,
+// which is defaultImage unless overridden by DockerImage or CustomDockerImage.
+func ResolveImage(defaultImage ibc.DockerImage, options ...RelayerOpt) ibc.DockerImage {
+	var r DockerRelayer
+	for _, opt := range options {
+		opt(&r)
+	}
+	if r.customImage != nil {
+		return *r.customImage
+	}
+	return defaultImage
+}
+
 // DockerImage overrides the default relayer docker image.
 func DockerImage(image *ibc.DockerImage) RelayerOpt {
 	return func(r *DockerRelayer) {
@@ -39,6 +52,13 @@ func ImagePull(pull bool) RelayerOpt {
 	}
 }
 
+// ResourceLimits sets the CPU and memory limits of the relayer's containers.
+func ResourceLimits(limits ibc.ResourceLimits) RelayerOpt {
+	return func(r *DockerRelayer) {
+		r.resources = limits
+	}
+}
+
 // StartupFlags overrides the default relayer startup flags.
 func StartupFlags(flags ...string) RelayerOpt {
 	return func(r *DockerRelayer) {
@@ -12,6 +12,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/relayer"
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -41,6 +42,46 @@ func NewCosmosRelayer(log *zap.Logger, testName string, cli *client.Client, netw
 	return r
 }
 
+// GetPathEnds returns the clients and connections of the path, read from the relayer's configuration file.
+func (r *CosmosRelayer) GetPathEnds(ctx context.Context, pathName string) (ibc.PathEnds, error) {
+	config, err := r.ReadFileFromHomeDir(ctx, "config/config.yaml")
+	if err != nil {
+		return ibc.PathEnds{}, err
+	}
+	return parsePathEnds(config, pathName)
+}
+
+// parsePathEnds returns the clients and connections of the path named pathName in the rly configuration file config.
+func parsePathEnds(config []byte, pathName string) (ibc.PathEnds, error) {
+	type pathEnd struct {
+		ClientID     string `yaml:"client-id"`
+		ConnectionID string `yaml:"connection-id"`
+	}
+	var cfg struct {
+		Paths map[string]struct {
+			Src pathEnd `yaml:"src"`
+			Dst pathEnd `yaml:"dst"`
+		} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(config, &cfg); err != nil {
+		return ibc.PathEnds{}, fmt.Errorf("failed to parse relayer config: %w", err)
+	}
+
+	path, ok := cfg.Paths[pathName]
+	if !ok {
+		return ibc.PathEnds{}, fmt.Errorf("path %s not found", pathName)
+	}
+	if path.Src.ConnectionID == "" || path.Dst.ConnectionID == "" {
+		return ibc.PathEnds{}, fmt.Errorf("path %s has no connection", pathName)
+	}
+	return ibc.PathEnds{
+		SrcClientID: path.Src.ClientID,
+		SrcConnID:   path.Src.ConnectionID,
+		DstClientID: path.Dst.ClientID,
+		DstConnID:   path.Dst.ConnectionID,
+	}, nil
+}
+
 type CosmosRelayerChainConfigValue struct {
 	AccountPrefix  string  `json:"account-prefix"`
 	ChainID        string  `json:"chain-id"`
@@ -0,0 +1,47 @@
+package rly
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePathEnds(t *testing.T) {
+	const config = `
+global:
+  api-listen-addr: :5183
+paths:
+  gaia-osmo:
+    src:
+      chain-id: gaia-1
+      client-id: 07-tendermint-0
+      connection-id: connection-0
+    dst:
+      chain-id: osmosis-1
+      client-id: 07-tendermint-3
+      connection-id: connection-2
+    src-channel-filter:
+      rule: ""
+      channel-list: []
+  unlinked:
+    src:
+      chain-id: gaia-1
+    dst:
+      chain-id: osmosis-1
+`
+	ends, err := parsePathEnds([]byte(config), "gaia-osmo")
+	require.NoError(t, err)
+	require.Equal(t, ibc.PathEnds{
+		SrcClientID: "07-tendermint-0",
+		SrcConnID:   "connection-0",
+		DstClientID: "07-tendermint-3",
+		DstConnID:   "connection-2",
+	}, ends)
+
+	_, err = parsePathEnds([]byte(config), "unlinked")
+	require.EqualError(t, err, "path unlinked has no connection")
+
+	_, err = parsePathEnds([]byte(config), "missing")
+	require.EqualError(t, err, "path missing not found")
+}
@@ -0,0 +1,858 @@
+// Package rlylib provides an interface to the cosmos relayer running in-process through its Go library.
+//
+// Unlike package rly, no relayer image or container is involved:
+// paths, clients, connections and channels are created by calling github.com/cosmos/relayer/v2 directly,
+// so failures are returned as Go errors instead of being parsed from the relayer's CLI output.
+package rlylib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+	rlyrelayer "github.com/cosmos/relayer/v2/relayer"
+	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
+	"github.com/cosmos/relayer/v2/relayer/processor"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/rly"
+	"go.uber.org/zap"
+)
+
+// libraryModule is the module path of the linked cosmos relayer.
+const libraryModule = "github.com/cosmos/relayer/v2"
+
+// Defaults matching the flags of the rly CLI.
+const (
+	defaultTimeout             = 10 * time.Second
+	defaultMaxRetries          = 3
+	defaultMaxClockDrift       = 10 * time.Minute
+	defaultInitialBlockHistory = 20
+	defaultMaxReceiverSize     = 150
+)
+
+var _ ibc.Relayer = &CosmosRelayer{}
+
+// CosmosRelayer is the ibc.Relayer implementation for github.com/cosmos/relayer, linked into the test binary.
+//
+// Keys are kept in a test keyring under the home directory;
+// chain and path configuration is only kept in memory.
+// Because the relayer runs on the host, chains are reached through their host-exposed ports.
+type CosmosRelayer struct {
+	log     *zap.Logger
+	homeDir string
+
+	mu      sync.Mutex
+	chains  map[string]cosmos.CosmosProviderConfig // Keyed by chain ID.
+	paths   map[string]*rlyrelayer.Path
+	wallets map[string]ibc.Wallet // Keyed by chain ID.
+
+	// Set while the relayer started through StartRelayer is running.
+	running *runningRelayer
+	// Set while the relayer is paused through PauseRelayer.
+	pausedPaths []string
+}
+
+// runningRelayer is a relayer started in the background through StartRelayer.
+type runningRelayer struct {
+	pathNames []string
+	cancel    context.CancelFunc
+	errCh     chan error
+}
+
+// NewCosmosRelayer returns a relayer keeping its keys in homeDir.
+func NewCosmosRelayer(log *zap.Logger, homeDir string) *CosmosRelayer {
+	return &CosmosRelayer{
+		log:     log,
+		homeDir: homeDir,
+		chains:  make(map[string]cosmos.CosmosProviderConfig),
+		paths:   make(map[string]*rlyrelayer.Path),
+		wallets: make(map[string]ibc.Wallet),
+	}
+}
+
+// Capabilities returns the set of capabilities of the in-process Cosmos relayer.
+func Capabilities() map[relayer.Capability]bool {
+	return rly.Capabilities()
+}
+
+// LibraryVersion returns the version of github.com/cosmos/relayer linked into the running binary,
+// or "unknown" if the build information is unavailable.
+func LibraryVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == libraryModule {
+				if dep.Replace != nil {
+					return dep.Replace.Version
+				}
+				return dep.Version
+			}
+		}
+	}
+	return "unknown"
+}
+
+// HomeDir returns the directory holding the relayer's keys.
+func (r *CosmosRelayer) HomeDir() string {
+	return r.homeDir
+}
+
+// ChainConfigToCosmosProviderConfig returns the cosmos relayer provider configuration for a chain,
+// using the same settings as rly.ChainConfigToCosmosRelayerChainConfig.
+func ChainConfigToCosmosProviderConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr string) cosmos.CosmosProviderConfig {
+	return cosmos.CosmosProviderConfig{
+		Key:            keyName,
+		ChainID:        chainConfig.ChainID,
+		RPCAddr:        rpcAddr,
+		AccountPrefix:  chainConfig.Bech32Prefix,
+		KeyringBackend: keyring.BackendTest,
+		GasAdjustment:  chainConfig.GasAdjustment,
+		GasPrices:      chainConfig.GasPrices,
+		Debug:          true,
+		Timeout:        defaultTimeout.String(),
+		OutputFormat:   "json",
+		SignModeStr:    "direct",
+	}
+}
+
+func (r *CosmosRelayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
+	cmd := []string{"rly", "chains", "add", chainConfig.ChainID}
+	start := time.Now()
+
+	var err error
+	if chainConfig.Type != "cosmos" {
+		err = fmt.Errorf("chain %s: chain type %q is not supported by the in-process relayer", chainConfig.ChainID, chainConfig.Type)
+	} else {
+		r.mu.Lock()
+		r.chains[chainConfig.ChainID] = ChainConfigToCosmosProviderConfig(chainConfig, keyName, rpcAddr)
+		r.mu.Unlock()
+	}
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, keyName, mnemonic string) error {
+	cmd := []string{"rly", "keys", "restore", cfg.ChainID, keyName}
+	start := time.Now()
+
+	address, err := r.restoreKey(ctx, cfg, keyName, mnemonic)
+	if err == nil {
+		r.mu.Lock()
+		r.wallets[cfg.ChainID] = rly.NewWallet(keyName, address, mnemonic)
+		r.mu.Unlock()
+	}
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) restoreKey(ctx context.Context, cfg ibc.ChainConfig, keyName, mnemonic string) (string, error) {
+	coinType, err := parseCoinType(cfg.CoinType)
+	if err != nil {
+		return "", err
+	}
+	c, err := r.chain(ctx, cfg.ChainID)
+	if err != nil {
+		return "", err
+	}
+	address, err := c.ChainProvider.RestoreKey(keyName, mnemonic, coinType, cfg.SigningAlgorithm)
+	if err != nil {
+		return "", fmt.Errorf("restoring key %s on chain %s: %w", keyName, cfg.ChainID, err)
+	}
+	return address, nil
+}
+
+func (r *CosmosRelayer) AddKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName, coinType, signingAlgorithm string) (ibc.Wallet, error) {
+	cmd := []string{"rly", "keys", "add", chainID, keyName}
+	start := time.Now()
+
+	wallet, err := r.addKey(ctx, chainID, keyName, coinType, signingAlgorithm)
+	if err == nil {
+		r.mu.Lock()
+		r.wallets[chainID] = wallet
+		r.mu.Unlock()
+	}
+
+	r.track(rep, cmd, start, err)
+	return wallet, err
+}
+
+func (r *CosmosRelayer) addKey(ctx context.Context, chainID, keyName, coinType, signingAlgorithm string) (ibc.Wallet, error) {
+	ct, err := parseCoinType(coinType)
+	if err != nil {
+		return nil, err
+	}
+	c, err := r.chain(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.ChainProvider.AddKey(keyName, ct, signingAlgorithm)
+	if err != nil {
+		return nil, fmt.Errorf("adding key %s on chain %s: %w", keyName, chainID, err)
+	}
+	return rly.NewWallet(keyName, out.Address, out.Mnemonic), nil
+}
+
+func (r *CosmosRelayer) GetWallet(chainID string) (ibc.Wallet, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	wallet, ok := r.wallets[chainID]
+	return wallet, ok
+}
+
+func (r *CosmosRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
+	cmd := []string{"rly", "paths", "new", srcChainID, dstChainID, pathName}
+	start := time.Now()
+
+	r.mu.Lock()
+	var err error
+	switch {
+	case r.paths[pathName] != nil:
+		err = fmt.Errorf("path %s already exists", pathName)
+	case !r.hasChain(srcChainID):
+		err = fmt.Errorf("chain %s not found", srcChainID)
+	case !r.hasChain(dstChainID):
+		err = fmt.Errorf("chain %s not found", dstChainID)
+	default:
+		r.paths[pathName] = rlyrelayer.GenPath(srcChainID, dstChainID)
+	}
+	r.mu.Unlock()
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.PathUpdateOptions) error {
+	cmd := []string{"rly", "paths", "update", pathName}
+	start := time.Now()
+
+	err := r.updatePath(pathName, opts)
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) updatePath(pathName string, opts ibc.PathUpdateOptions) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+
+	// Validate on a copy so a rejected update leaves the path untouched.
+	updated := copyPath(p)
+	if opts.ChannelFilter != nil {
+		updated.Filter = rlyrelayer.ChannelFilter{
+			Rule:        opts.ChannelFilter.Rule,
+			ChannelList: append([]string(nil), opts.ChannelFilter.ChannelList...),
+		}
+	}
+	setIfNotNil(&updated.Src.ClientID, opts.SrcClientID)
+	setIfNotNil(&updated.Src.ConnectionID, opts.SrcConnID)
+	setIfNotNil(&updated.Src.ChainID, opts.SrcChainID)
+	setIfNotNil(&updated.Dst.ClientID, opts.DstClientID)
+	setIfNotNil(&updated.Dst.ConnectionID, opts.DstConnID)
+	setIfNotNil(&updated.Dst.ChainID, opts.DstChainID)
+
+	if err := updated.ValidateChannelFilterRule(); err != nil {
+		return err
+	}
+	r.paths[pathName] = updated
+	return nil
+}
+
+func (r *CosmosRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
+	cmd := []string{"rly", "tx", "link", pathName}
+	start := time.Now()
+
+	err := r.linkPath(ctx, pathName, channelOpts, clientOpts)
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) linkPath(ctx context.Context, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
+	if err := r.createClients(ctx, pathName, clientOpts); err != nil {
+		return fmt.Errorf("error creating clients: %w", err)
+	}
+	if err := r.createConnections(ctx, pathName); err != nil {
+		return fmt.Errorf("error creating connections: %w", err)
+	}
+	return r.createChannel(ctx, pathName, channelOpts)
+}
+
+func (r *CosmosRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) error {
+	cmd := []string{"rly", "tx", "clients", pathName}
+	start := time.Now()
+
+	err := r.createClients(ctx, pathName, opts)
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) createClients(ctx context.Context, pathName string, opts ibc.CreateClientOptions) error {
+	clientOpts, err := parseClientOptions(opts)
+	if err != nil {
+		return err
+	}
+	src, dst, p, err := r.pathChains(ctx, pathName)
+	if err != nil {
+		return err
+	}
+
+	clientSrc, clientDst, err := src.CreateClients(ctx, dst,
+		true, true, opts.Override,
+		clientOpts.trustingPeriod, clientOpts.maxClockDrift, opts.TrustingPeriodPercentage,
+		"",
+	)
+	// Clients created before a failure are recorded, as rly does in its configuration file.
+	r.updatePathEnds(pathName, p.Src.ChainID, clientSrc, "")
+	r.updatePathEnds(pathName, p.Dst.ChainID, clientDst, "")
+	return err
+}
+
+func (r *CosmosRelayer) CreateClient(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string, opts ibc.CreateClientOptions) error {
+	cmd := []string{"rly", "tx", "client", srcChainID, dstChainID, pathName}
+	start := time.Now()
+
+	err := r.createClient(ctx, srcChainID, dstChainID, pathName, opts)
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) createClient(ctx context.Context, srcChainID, dstChainID, pathName string, opts ibc.CreateClientOptions) error {
+	clientOpts, err := parseClientOptions(opts)
+	if err != nil {
+		return err
+	}
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	src, err := r.chain(ctx, srcChainID)
+	if err != nil {
+		return err
+	}
+	dst, err := r.chain(ctx, dstChainID)
+	if err != nil {
+		return err
+	}
+	src.PathEnd, dst.PathEnd = p.End(srcChainID), p.End(dstChainID)
+	if src.PathEnd.ChainID == "" || dst.PathEnd.ChainID == "" {
+		return fmt.Errorf("path %s does not connect chains %s and %s", pathName, srcChainID, dstChainID)
+	}
+
+	srch, dsth, err := rlyrelayer.QueryLatestHeights(ctx, src, dst)
+	if err != nil {
+		return fmt.Errorf("failed to query latest heights: %w", err)
+	}
+	srcHeader, dstHeader, err := rlyrelayer.QueryIBCHeaders(ctx, src, dst, srch, dsth)
+	if err != nil {
+		return err
+	}
+
+	clientID, err := rlyrelayer.CreateClient(ctx, src, dst,
+		srcHeader, dstHeader,
+		true, true, opts.Override,
+		clientOpts.trustingPeriod, 0, clientOpts.maxClockDrift, opts.TrustingPeriodPercentage,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	r.updatePathEnds(pathName, srcChainID, clientID, "")
+	return nil
+}
+
+func (r *CosmosRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	cmd := []string{"rly", "tx", "connection", pathName}
+	start := time.Now()
+
+	err := r.createConnections(ctx, pathName)
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) createConnections(ctx context.Context, pathName string) error {
+	src, dst, p, err := r.pathChains(ctx, pathName)
+	if err != nil {
+		return err
+	}
+
+	connSrc, connDst, err := src.CreateOpenConnections(ctx, dst,
+		defaultMaxRetries, defaultTimeout, "", defaultInitialBlockHistory, pathName,
+	)
+	r.updatePathEnds(pathName, p.Src.ChainID, "", connSrc)
+	r.updatePathEnds(pathName, p.Dst.ChainID, "", connDst)
+	return err
+}
+
+func (r *CosmosRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+	cmd := []string{"rly", "tx", "channel", pathName}
+	start := time.Now()
+
+	err := r.createChannel(ctx, pathName, opts)
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) createChannel(ctx context.Context, pathName string, opts ibc.CreateChannelOptions) error {
+	src, dst, _, err := r.pathChains(ctx, pathName)
+	if err != nil {
+		return err
+	}
+
+	return src.CreateOpenChannels(ctx, dst,
+		defaultMaxRetries, defaultTimeout,
+		opts.SourcePortName, opts.DestPortName, opts.Order.String(), opts.Version,
+		false, "", pathName,
+	)
+}
+
+func (r *CosmosRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	cmd := []string{"rly", "tx", "update-clients", pathName}
+	start := time.Now()
+
+	src, dst, _, err := r.pathChains(ctx, pathName)
+	if err == nil {
+		err = rlyrelayer.UpdateClients(ctx, src, dst, "")
+	}
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
+	cmd := []string{"rly", "q", "channels", chainID}
+	start := time.Now()
+
+	var channels []ibc.ChannelOutput
+	c, err := r.chain(ctx, chainID)
+	if err == nil {
+		var res []*chantypes.IdentifiedChannel
+		res, err = c.ChainProvider.QueryChannels(ctx)
+		for _, ch := range res {
+			channels = append(channels, channelOutput(ch))
+		}
+	}
+
+	r.track(rep, cmd, start, err)
+	return channels, err
+}
+
+func (r *CosmosRelayer) GetConnections(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ConnectionOutputs, error) {
+	cmd := []string{"rly", "q", "connections", chainID}
+	start := time.Now()
+
+	var connections ibc.ConnectionOutputs
+	c, err := r.chain(ctx, chainID)
+	if err == nil {
+		var res []*conntypes.IdentifiedConnection
+		res, err = c.ChainProvider.QueryConnections(ctx)
+		for _, conn := range res {
+			connections = append(connections, connectionOutput(conn))
+		}
+	}
+
+	r.track(rep, cmd, start, err)
+	return connections, err
+}
+
+func (r *CosmosRelayer) GetClients(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ClientOutputs, error) {
+	cmd := []string{"rly", "q", "clients", chainID}
+	start := time.Now()
+
+	var clients ibc.ClientOutputs
+	c, err := r.chain(ctx, chainID)
+	if err == nil {
+		var res clienttypes.IdentifiedClientStates
+		res, err = c.ChainProvider.QueryClients(ctx)
+		for _, cs := range res {
+			clients = append(clients, clientOutput(cs))
+		}
+	}
+
+	r.track(rep, cmd, start, err)
+	return clients, err
+}
+
+// StartRelayer starts relaying on the given paths in a background goroutine.
+// Its context is detached from ctx, so the relayer keeps running until StopRelayer is called.
+func (r *CosmosRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	cmd := append([]string{"rly", "start"}, pathNames...)
+	start := time.Now()
+
+	err := r.start(context.WithoutCancel(ctx), pathNames)
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) start(ctx context.Context, pathNames []string) error {
+	r.mu.Lock()
+	running := r.running
+	r.mu.Unlock()
+	if running != nil {
+		return fmt.Errorf("tried to start relayer again without stopping first")
+	}
+
+	chains := make(map[string]*rlyrelayer.Chain)
+	paths := make([]rlyrelayer.NamedPath, 0, len(pathNames))
+	for _, pathName := range pathNames {
+		p, err := r.path(pathName)
+		if err != nil {
+			return err
+		}
+		for _, chainID := range []string{p.Src.ChainID, p.Dst.ChainID} {
+			if chains[chainID] != nil {
+				continue
+			}
+			c, err := r.chain(ctx, chainID)
+			if err != nil {
+				return err
+			}
+			chains[chainID] = c
+		}
+		paths = append(paths, rlyrelayer.NamedPath{Name: pathName, Path: p})
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	errCh := rlyrelayer.StartRelayer(ctx, r.log, chains, paths,
+		rlyrelayer.DefaultMaxMsgLength, defaultMaxReceiverSize, 0, "",
+		rlyrelayer.DefaultClientUpdateThreshold, rlyrelayer.DefaultFlushInterval,
+		nil, rlyrelayer.ProcessorEvents, defaultInitialBlockHistory, nil, nil,
+	)
+
+	r.mu.Lock()
+	r.running = &runningRelayer{pathNames: pathNames, cancel: cancel, errCh: errCh}
+	r.pausedPaths = nil
+	r.mu.Unlock()
+	return nil
+}
+
+// StopRelayer stops the relayer started through StartRelayer and waits for it to shut down.
+// An error the relayer stopped with before StopRelayer was called is returned.
+func (r *CosmosRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	cmd := []string{"rly", "stop"}
+	start := time.Now()
+
+	r.mu.Lock()
+	r.pausedPaths = nil
+	r.mu.Unlock()
+	err := r.stop(ctx)
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+// stop cancels the running relayer, if any, and waits for it to return.
+func (r *CosmosRelayer) stop(ctx context.Context) error {
+	r.mu.Lock()
+	running := r.running
+	r.running = nil
+	r.mu.Unlock()
+	if running == nil {
+		return nil
+	}
+
+	running.cancel()
+	select {
+	case err := <-running.errCh:
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PauseRelayer stops the relayer started through StartRelayer,
+// remembering its paths so ResumeRelayer can start it again.
+func (r *CosmosRelayer) PauseRelayer(ctx context.Context) error {
+	r.mu.Lock()
+	running := r.running
+	r.mu.Unlock()
+	if running == nil {
+		return fmt.Errorf("relayer not running")
+	}
+
+	if err := r.stop(ctx); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.pausedPaths = running.pathNames
+	r.mu.Unlock()
+	return nil
+}
+
+// ResumeRelayer starts relaying again on the paths of a relayer paused through PauseRelayer.
+func (r *CosmosRelayer) ResumeRelayer(ctx context.Context) error {
+	r.mu.Lock()
+	pathNames := r.pausedPaths
+	r.mu.Unlock()
+	if pathNames == nil {
+		return fmt.Errorf("relayer not paused")
+	}
+	return r.start(context.WithoutCancel(ctx), pathNames)
+}
+
+// Flush relays the outstanding packets of the path, limited to channelID if it is not empty, and then returns.
+func (r *CosmosRelayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	cmd := []string{"rly", "tx", "flush", pathName}
+	if channelID != "" {
+		cmd = append(cmd, channelID)
+	}
+	start := time.Now()
+
+	err := r.flush(ctx, pathName, channelID)
+
+	r.track(rep, cmd, start, err)
+	return err
+}
+
+func (r *CosmosRelayer) flush(ctx context.Context, pathName, channelID string) error {
+	src, dst, p, err := r.pathChains(ctx, pathName)
+	if err != nil {
+		return err
+	}
+	if channelID != "" {
+		p.Filter = rlyrelayer.ChannelFilter{
+			Rule:        processor.RuleAllowList,
+			ChannelList: []string{channelID},
+		}
+	}
+
+	errCh := rlyrelayer.StartRelayer(ctx, r.log,
+		map[string]*rlyrelayer.Chain{src.ChainID(): src, dst.ChainID(): dst},
+		[]rlyrelayer.NamedPath{{Name: pathName, Path: p}},
+		rlyrelayer.DefaultMaxMsgLength, defaultMaxReceiverSize, 0, "",
+		0, 0,
+		&processor.FlushLifecycle{}, rlyrelayer.ProcessorEvents, 0, nil, nil,
+	)
+
+	// The relayer returns once ctx is done, so wait for it rather than for ctx.
+	if err := <-errCh; err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// UseDockerNetwork reports false: the relayer runs on the host and reaches chains through their host-exposed ports.
+func (r *CosmosRelayer) UseDockerNetwork() bool {
+	return false
+}
+
+// Exec is not supported, as the relayer has no command line when linked in-process.
+func (r *CosmosRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) ibc.RelayerExecResult {
+	start := time.Now()
+	err := fmt.Errorf("in-process relayer cannot execute commands: %v", cmd)
+	r.track(rep, cmd, start, err)
+	return ibc.RelayerExecResult{Err: err}
+}
+
+// SetClientContractHash is not supported, as the linked relayer does not configure 08-wasm clients.
+func (r *CosmosRelayer) SetClientContractHash(ctx context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, hash string) error {
+	return fmt.Errorf("in-process relayer does not support 08-wasm clients")
+}
+
+// hasChain reports whether a configuration was added for chainID. r.mu must be held.
+func (r *CosmosRelayer) hasChain(chainID string) bool {
+	_, ok := r.chains[chainID]
+	return ok
+}
+
+// chain returns a new relayer chain for chainID, backed by a freshly initialized provider.
+// Every operation gets its own chains, the same way every rly invocation loads them from its configuration file.
+func (r *CosmosRelayer) chain(ctx context.Context, chainID string) (*rlyrelayer.Chain, error) {
+	r.mu.Lock()
+	cfg, ok := r.chains[chainID]
+	r.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("chain %s not found", chainID)
+	}
+
+	prov, err := cfg.NewProvider(r.log, r.homeDir, cfg.Debug, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("creating provider for chain %s: %w", chainID, err)
+	}
+	if err := prov.Init(ctx); err != nil {
+		return nil, fmt.Errorf("initializing provider for chain %s: %w", chainID, err)
+	}
+	return rlyrelayer.NewChain(r.log, prov, cfg.Debug), nil
+}
+
+// path returns a copy of the path named pathName.
+func (r *CosmosRelayer) path(pathName string) (*rlyrelayer.Path, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.paths[pathName]
+	if !ok {
+		return nil, fmt.Errorf("path %s not found", pathName)
+	}
+	return copyPath(p), nil
+}
+
+// pathChains returns the source and destination chains of the path named pathName,
+// with their path ends set from a copy of the path, which is returned as well.
+func (r *CosmosRelayer) pathChains(ctx context.Context, pathName string) (src, dst *rlyrelayer.Chain, p *rlyrelayer.Path, err error) {
+	p, err = r.path(pathName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if src, err = r.chain(ctx, p.Src.ChainID); err != nil {
+		return nil, nil, nil, err
+	}
+	if dst, err = r.chain(ctx, p.Dst.ChainID); err != nil {
+		return nil, nil, nil, err
+	}
+	src.PathEnd, dst.PathEnd = p.Src, p.Dst
+	return src, dst, p, nil
+}
+
+// updatePathEnds records the non-empty client and connection IDs created on chainID for the path named pathName.
+func (r *CosmosRelayer) updatePathEnds(pathName, chainID, clientID, connectionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.paths[pathName]
+	if !ok {
+		return
+	}
+	for _, end := range []*rlyrelayer.PathEnd{p.Src, p.Dst} {
+		if end.ChainID != chainID {
+			continue
+		}
+		if clientID != "" {
+			end.ClientID = clientID
+		}
+		if connectionID != "" {
+			end.ConnectionID = connectionID
+		}
+	}
+}
+
+// track reports an in-process relayer call as if cmd had been run.
+func (r *CosmosRelayer) track(rep ibc.RelayerExecReporter, cmd []string, start time.Time, err error) {
+	var stderr string
+	exitCode := 0
+	if err != nil {
+		stderr = err.Error()
+		exitCode = 1
+	}
+	rep.TrackRelayerExec("", cmd, "", stderr, exitCode, start, time.Now(), err)
+}
+
+func copyPath(p *rlyrelayer.Path) *rlyrelayer.Path {
+	src, dst := *p.Src, *p.Dst
+	return &rlyrelayer.Path{
+		Src: &src,
+		Dst: &dst,
+		Filter: rlyrelayer.ChannelFilter{
+			Rule:        p.Filter.Rule,
+			ChannelList: append([]string(nil), p.Filter.ChannelList...),
+		},
+	}
+}
+
+func setIfNotNil(dst *string, v *string) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func parseCoinType(coinType string) (uint32, error) {
+	ct, err := strconv.ParseUint(coinType, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid coin type %q: %w", coinType, err)
+	}
+	return uint32(ct), nil
+}
+
+type clientOptions struct {
+	trustingPeriod time.Duration
+	maxClockDrift  time.Duration
+}
+
+// parseClientOptions converts opts to the durations the relayer library takes,
+// applying the rly CLI defaults to empty values.
+func parseClientOptions(opts ibc.CreateClientOptions) (clientOptions, error) {
+	if err := opts.Validate(); err != nil {
+		return clientOptions{}, err
+	}
+	co := clientOptions{maxClockDrift: defaultMaxClockDrift}
+	if opts.TrustingPeriod != "" {
+		d, err := time.ParseDuration(opts.TrustingPeriod)
+		if err != nil {
+			return clientOptions{}, fmt.Errorf("invalid trusting period %q: %w", opts.TrustingPeriod, err)
+		}
+		co.trustingPeriod = d
+	}
+	if opts.MaxClockDrift != "" {
+		d, err := time.ParseDuration(opts.MaxClockDrift)
+		if err != nil {
+			return clientOptions{}, fmt.Errorf("invalid max clock drift %q: %w", opts.MaxClockDrift, err)
+		}
+		co.maxClockDrift = d
+	}
+	return co, nil
+}
+
+func channelOutput(ch *chantypes.IdentifiedChannel) ibc.ChannelOutput {
+	return ibc.ChannelOutput{
+		State:    ch.State.String(),
+		Ordering: ch.Ordering.String(),
+		Counterparty: ibc.ChannelCounterparty{
+			PortID:    ch.Counterparty.PortId,
+			ChannelID: ch.Counterparty.ChannelId,
+		},
+		ConnectionHops: ch.ConnectionHops,
+		Version:        ch.Version,
+		PortID:         ch.PortId,
+		ChannelID:      ch.ChannelId,
+	}
+}
+
+func connectionOutput(conn *conntypes.IdentifiedConnection) *ibc.ConnectionOutput {
+	counterparty := conn.Counterparty
+	return &ibc.ConnectionOutput{
+		ID:           conn.Id,
+		ClientID:     conn.ClientId,
+		Versions:     conn.Versions,
+		State:        conn.State.String(),
+		Counterparty: &counterparty,
+		DelayPeriod:  strconv.FormatUint(conn.DelayPeriod, 10),
+	}
+}
+
+// clientOutput returns the client's ID, and the chain ID of tendermint clients.
+func clientOutput(cs clienttypes.IdentifiedClientState) *ibc.ClientOutput {
+	out := &ibc.ClientOutput{ClientID: cs.ClientId}
+	if cs.ClientState == nil {
+		return out
+	}
+	if tm, ok := cs.ClientState.GetCachedValue().(*ibctm.ClientState); ok {
+		out.ClientState.ChainID = tm.ChainId
+		return out
+	}
+	var tm ibctm.ClientState
+	if cs.ClientState.TypeUrl == "/ibc.lightclients.tendermint.v1.ClientState" && tm.Unmarshal(cs.ClientState.Value) == nil {
+		out.ClientState.ChainID = tm.ChainId
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package rlylib
+
+import (
+	"context"
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func testChainConfig(chainID string) ibc.ChainConfig {
+	return ibc.ChainConfig{
+		Type:         "cosmos",
+		ChainID:      chainID,
+		Bech32Prefix: "cosmos",
+		CoinType:     "118",
+		GasPrices:    "0.01uatom",
+	}
+}
+
+func TestKeys(t *testing.T) {
+	ctx := context.Background()
+	rep := ibc.NopRelayerExecReporter{}
+	cfg := testChainConfig("gaia-1")
+
+	// Keys do not need the chain to be reachable.
+	r := NewCosmosRelayer(zaptest.NewLogger(t), t.TempDir())
+	require.NoError(t, r.AddChainConfiguration(ctx, rep, cfg, "gaia", "http://127.0.0.1:1", ""))
+	added, err := r.AddKey(ctx, rep, cfg.ChainID, "gaia", cfg.CoinType, "")
+	require.NoError(t, err)
+	require.Contains(t, added.FormattedAddress(), "cosmos1")
+
+	restorer := NewCosmosRelayer(zaptest.NewLogger(t), t.TempDir())
+	require.NoError(t, restorer.AddChainConfiguration(ctx, rep, cfg, "gaia", "http://127.0.0.1:1", ""))
+	require.NoError(t, restorer.RestoreKey(ctx, rep, cfg, "gaia", added.Mnemonic()))
+	restored, ok := restorer.GetWallet(cfg.ChainID)
+	require.True(t, ok)
+	require.Equal(t, added.FormattedAddress(), restored.FormattedAddress())
+
+	_, ok = restorer.GetWallet("osmosis-1")
+	require.False(t, ok)
+	require.ErrorContains(t, restorer.RestoreKey(ctx, rep, testChainConfig("osmosis-1"), "osmo", added.Mnemonic()), "chain osmosis-1 not found")
+}
+
+func TestPaths(t *testing.T) {
+	ctx := context.Background()
+	rep := ibc.NopRelayerExecReporter{}
+
+	r := NewCosmosRelayer(zaptest.NewLogger(t), t.TempDir())
+	require.NoError(t, r.AddChainConfiguration(ctx, rep, testChainConfig("gaia-1"), "gaia", "http://127.0.0.1:1", ""))
+	require.NoError(t, r.AddChainConfiguration(ctx, rep, testChainConfig("osmosis-1"), "osmosis", "http://127.0.0.1:2", ""))
+
+	unsupported := testChainConfig("penumbra-1")
+	unsupported.Type = "penumbra"
+	require.ErrorContains(t, r.AddChainConfiguration(ctx, rep, unsupported, "penumbra", "http://127.0.0.1:3", ""), "not supported")
+
+	require.ErrorContains(t, r.GeneratePath(ctx, rep, "gaia-1", "penumbra-1", "gaia-penumbra"), "chain penumbra-1 not found")
+	require.NoError(t, r.GeneratePath(ctx, rep, "gaia-1", "osmosis-1", "gaia-osmo"))
+	require.ErrorContains(t, r.GeneratePath(ctx, rep, "gaia-1", "osmosis-1", "gaia-osmo"), "already exists")
+
+	clientID, connID := "07-tendermint-0", "connection-0"
+	require.NoError(t, r.UpdatePath(ctx, rep, "gaia-osmo", ibc.PathUpdateOptions{
+		ChannelFilter: &ibc.ChannelFilter{Rule: "allowlist", ChannelList: []string{"channel-0"}},
+		SrcClientID:   &clientID,
+		SrcConnID:     &connID,
+	}))
+	p, err := r.path("gaia-osmo")
+	require.NoError(t, err)
+	require.Equal(t, "gaia-1", p.Src.ChainID)
+	require.Equal(t, clientID, p.Src.ClientID)
+	require.Equal(t, connID, p.Src.ConnectionID)
+	require.Empty(t, p.Dst.ClientID)
+	require.Equal(t, []string{"channel-0"}, p.Filter.ChannelList)
+
+	// A rejected update leaves the path untouched.
+	require.Error(t, r.UpdatePath(ctx, rep, "gaia-osmo", ibc.PathUpdateOptions{
+		ChannelFilter: &ibc.ChannelFilter{Rule: "bogus"},
+		DstClientID:   &clientID,
+	}))
+	p, err = r.path("gaia-osmo")
+	require.NoError(t, err)
+	require.Equal(t, "allowlist", p.Filter.Rule)
+	require.Empty(t, p.Dst.ClientID)
+
+	require.ErrorContains(t, r.UpdateClients(ctx, rep, "unknown"), "path unknown not found")
+	require.ErrorContains(t, r.ResumeRelayer(ctx), "not paused")
+	require.NoError(t, r.StopRelayer(ctx, rep))
+}
+
+func TestChannelOutput(t *testing.T) {
+	out := channelOutput(&chantypes.IdentifiedChannel{
+		State:          chantypes.OPEN,
+		Ordering:       chantypes.UNORDERED,
+		Counterparty:   chantypes.Counterparty{PortId: "transfer", ChannelId: "channel-3"},
+		ConnectionHops: []string{"connection-0"},
+		Version:        "ics20-1",
+		PortId:         "transfer",
+		ChannelId:      "channel-0",
+	})
+	require.Equal(t, ibc.ChannelOutput{
+		State:          "STATE_OPEN",
+		Ordering:       "ORDER_UNORDERED",
+		Counterparty:   ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-3"},
+		ConnectionHops: []string{"connection-0"},
+		Version:        "ics20-1",
+		PortID:         "transfer",
+		ChannelID:      "channel-0",
+	}, out)
+}
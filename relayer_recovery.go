@@ -0,0 +1,220 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// CrashableRelayer is a relayer that can be terminated abruptly and have its on-disk state damaged,
+// for crash-consistency testing. The relayers built on relayer.DockerRelayer implement it.
+type CrashableRelayer interface {
+	ibc.Relayer
+
+	// KillRelayer terminates the relayer and any in-flight relayer command with SIGKILL.
+	KillRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error
+
+	// RemoveHomeDirPath recursively removes a file or directory relative to the relayer home directory.
+	RemoveHomeDirPath(ctx context.Context, relativePath string) error
+
+	// CorruptHomeDirFile damages a file relative to the relayer home directory.
+	CorruptHomeDirFile(ctx context.Context, relativePath string) error
+}
+
+// KillRelayerDuring calls fn, and kills the relayer after delay while fn is still in flight,
+// e.g. midway through a LinkPath handshake or a batch of packets being relayed.
+// The error returned by fn is discarded, as it is expected to fail once the relayer is killed.
+// If fn returns before delay elapses, the relayer is not killed and an error is returned.
+//
+// KillRelayerDuring does not return before fn does. If ctx is done or the relayer cannot be killed,
+// the context passed to fn is canceled, so that fn stops before a later restart of the relayer.
+func KillRelayerDuring(ctx context.Context, r CrashableRelayer, rep ibc.RelayerExecReporter, delay time.Duration, fn func(ctx context.Context) error) error {
+	fnCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = fn(fnCtx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return fmt.Errorf("operation completed within %s, before the relayer could be killed", delay)
+	case <-time.After(delay):
+	}
+
+	if err := r.KillRelayer(ctx, rep); err != nil {
+		return fmt.Errorf("failed to kill relayer: %w", err)
+	}
+
+	// Wait for fn to observe the killed relayer, so it does not race with a later restart.
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
+
+// CrashRestartRelayer kills the relayer, runs each of outageFuncs in order while the relayer is down,
+// e.g. to send packets or damage the relayer home directory, then starts the relayer again on pathNames.
+func CrashRestartRelayer(
+	ctx context.Context,
+	r CrashableRelayer,
+	rep ibc.RelayerExecReporter,
+	outageFuncs []func(ctx context.Context) error,
+	pathNames ...string,
+) error {
+	if err := r.KillRelayer(ctx, rep); err != nil {
+		return fmt.Errorf("failed to kill relayer: %w", err)
+	}
+
+	for i, fn := range outageFuncs {
+		if err := fn(ctx); err != nil {
+			return fmt.Errorf("outage func %d failed: %w", i, err)
+		}
+	}
+
+	if err := r.StartRelayer(ctx, rep, pathNames...); err != nil {
+		return fmt.Errorf("failed to restart relayer: %w", err)
+	}
+	return nil
+}
+
+// WaitForPacketsRelayedOnce waits up to maxBlocks blocks on dst for every packet to be received on dst
+// and acknowledged on src, and then verifies that each was delivered exactly once on each chain.
+// Relays retried after a restart and ignored by the chain as redundant are not counted as deliveries.
+// Blocks are searched from srcStartHeight on src and from dstStartHeight on dst.
+// Only cosmos chains are supported.
+func WaitForPacketsRelayedOnce(
+	ctx context.Context,
+	src, dst ibc.Chain,
+	srcStartHeight, dstStartHeight int64,
+	maxBlocks int64,
+	packets ...ibc.Packet,
+) error {
+	srcChain, ok := src.(*cosmos.CosmosChain)
+	if !ok {
+		return fmt.Errorf("packet relay verification is not supported for chain type %T", src)
+	}
+	dstChain, ok := dst.(*cosmos.CosmosChain)
+	if !ok {
+		return fmt.Errorf("packet relay verification is not supported for chain type %T", dst)
+	}
+
+	recvs := newPacketDeliveries(packets)
+	acks := newPacketDeliveries(packets)
+	srcNext, dstNext := srcStartHeight, dstStartHeight
+
+	for i := int64(0); ; i++ {
+		var err error
+		if dstNext, err = recvs.scan(ctx, dstChain, dstNext, "/ibc.core.channel.v1.MsgRecvPacket"); err != nil {
+			return err
+		}
+		if srcNext, err = acks.scan(ctx, srcChain, srcNext, "/ibc.core.channel.v1.MsgAcknowledgement"); err != nil {
+			return err
+		}
+
+		if recvs.complete() && acks.complete() {
+			break
+		}
+		if i >= maxBlocks {
+			return fmt.Errorf(
+				"after %d blocks, %d packets were not received on %s and %d packets were not acknowledged on %s",
+				maxBlocks, recvs.missing(), dst.Config().ChainID, acks.missing(), src.Config().ChainID,
+			)
+		}
+		if err := testutil.WaitForBlocks(ctx, 1, dst); err != nil {
+			return err
+		}
+	}
+
+	return errors.Join(
+		recvs.duplicates("received", dst.Config().ChainID),
+		acks.duplicates("acknowledged", src.Config().ChainID),
+	)
+}
+
+// packetKey identifies a packet by its source channel and sequence.
+type packetKey struct {
+	srcChannel string
+	sequence   uint64
+}
+
+// packetDeliveries counts the successful deliveries of a set of packets.
+type packetDeliveries map[packetKey]int
+
+func newPacketDeliveries(packets []ibc.Packet) packetDeliveries {
+	d := make(packetDeliveries, len(packets))
+	for _, p := range packets {
+		d[packetKey{srcChannel: p.SourceChannel, sequence: p.Sequence}] = 0
+	}
+	return d
+}
+
+// scan counts the successful msgType deliveries in blocks from height next up to the current height of c,
+// returning the next height to scan.
+func (d packetDeliveries) scan(ctx context.Context, c *cosmos.CosmosChain, next int64, msgType string) (int64, error) {
+	height, err := c.Height(ctx)
+	if err != nil {
+		return next, fmt.Errorf("failed to get height of %s: %w", c.Config().ChainID, err)
+	}
+	if height < next {
+		return next, nil
+	}
+
+	relayed, err := c.RelayedPackets(ctx, next, height)
+	if err != nil {
+		return next, err
+	}
+	d.count(relayed, msgType)
+	return height + 1, nil
+}
+
+// count counts the successful msgType deliveries of tracked packets in relayed.
+// Redundant relays, which are included in blocks but not executed, are not deliveries.
+func (d packetDeliveries) count(relayed []cosmos.RelayedPacket, msgType string) {
+	for _, p := range relayed {
+		if !p.Delivered() || p.MsgType != msgType {
+			continue
+		}
+		key := packetKey{srcChannel: p.Packet.SourceChannel, sequence: p.Packet.Sequence}
+		if _, ok := d[key]; ok {
+			d[key]++
+		}
+	}
+}
+
+func (d packetDeliveries) complete() bool {
+	return d.missing() == 0
+}
+
+func (d packetDeliveries) missing() int {
+	var n int
+	for _, count := range d {
+		if count == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func (d packetDeliveries) duplicates(verb, chainID string) error {
+	var errs []error
+	for key, count := range d {
+		if count > 1 {
+			errs = append(errs, fmt.Errorf("packet %d on %s was %s %d times on %s", key.sequence, key.srcChannel, verb, count, chainID))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/hermes"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/rly"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ CrashableRelayer = (*rly.CosmosRelayer)(nil)
+	_ CrashableRelayer = (*hermes.Relayer)(nil)
+)
+
+func TestPacketDeliveries(t *testing.T) {
+	d := newPacketDeliveries([]ibc.Packet{
+		{SourceChannel: "channel-0", Sequence: 1},
+		{SourceChannel: "channel-0", Sequence: 2},
+	})
+	require.False(t, d.complete())
+	require.Equal(t, 2, d.missing())
+
+	const recv = "/ibc.core.channel.v1.MsgRecvPacket"
+	packet := func(msgType, channel string, seq uint64, result chantypes.ResponseResultType) cosmos.RelayedPacket {
+		return cosmos.RelayedPacket{
+			MsgType: msgType,
+			Packet:  ibc.Packet{SourceChannel: channel, Sequence: seq},
+			Result:  result,
+		}
+	}
+	d.count([]cosmos.RelayedPacket{
+		packet(recv, "channel-0", 1, chantypes.SUCCESS),
+		// A relay retried after a crash is included with code 0 but ignored by the chain.
+		packet(recv, "channel-0", 1, chantypes.NOOP),
+		packet(recv, "channel-0", 2, chantypes.SUCCESS),
+		packet(recv, "channel-0", 2, chantypes.SUCCESS),
+		// Failed transactions are not counted.
+		{MsgType: recv, Code: 11, Codespace: "sdk", Packet: ibc.Packet{SourceChannel: "channel-0", Sequence: 1}},
+		// Other message types and untracked packets are ignored.
+		packet("/ibc.core.channel.v1.MsgAcknowledgement", "channel-0", 1, chantypes.SUCCESS),
+		packet(recv, "channel-1", 1, chantypes.SUCCESS),
+	}, recv)
+
+	require.Len(t, d, 2)
+	require.True(t, d.complete())
+	require.EqualError(t, d.duplicates("received", "g2"), "packet 2 on channel-0 was received 2 times on g2")
+}
+
+// killErrRelayer is a CrashableRelayer failing to be killed.
+type killErrRelayer struct {
+	CrashableRelayer
+}
+
+func (killErrRelayer) KillRelayer(context.Context, ibc.RelayerExecReporter) error {
+	return errors.New("boom")
+}
+
+func TestKillRelayerDuringWaitsForFn(t *testing.T) {
+	ctx := context.Background()
+	var returned atomic.Bool
+	fn := func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		returned.Store(true)
+		return ctx.Err()
+	}
+
+	err := KillRelayerDuring(ctx, killErrRelayer{}, nil, time.Millisecond, fn)
+	require.ErrorContains(t, err, "failed to kill relayer: boom")
+	require.True(t, returned.Load(), "fn must be canceled and have returned")
+
+	returned.Store(false)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = KillRelayerDuring(ctx, killErrRelayer{}, nil, time.Hour, fn)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.True(t, returned.Load(), "fn must have returned")
+}
@@ -0,0 +1,95 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// RelayedPacket is a packet message found on a chain,
+// attributed to the relayer of the Interchain whose wallet signed it.
+type RelayedPacket struct {
+	cosmos.RelayedPacket
+
+	// Name of the relayer as given to AddRelayer,
+	// or empty if the signer is not a relayer wallet created by the Interchain.
+	Relayer string
+}
+
+// RelayedPacketReport summarizes the packet messages delivered to a chain by each relayer.
+// It is most useful when several relayers race on the same path, see AddRedundantRelayer.
+type RelayedPacketReport struct {
+	// Every packet message found, in block order.
+	Packets []RelayedPacket
+
+	// Count of successfully delivered packet messages, keyed by relayer name.
+	Delivered map[string]int
+
+	// Count of packet messages ignored by the chain because another relayer delivered them first,
+	// keyed by relayer name.
+	Redundant map[string]int
+}
+
+// Landed returns the name of the relayer that successfully delivered the message of the given type
+// for the packet identified by its source channel and sequence,
+// or false if no relayer delivered it.
+func (r RelayedPacketReport) Landed(msgType, srcChannel string, sequence uint64) (string, bool) {
+	for _, p := range r.Packets {
+		if p.Delivered() && p.MsgType == msgType && p.Packet.SourceChannel == srcChannel && p.Packet.Sequence == sequence {
+			return p.Relayer, true
+		}
+	}
+	return "", false
+}
+
+// RelayedPacketReport inspects blocks from startHeight to endHeight inclusive on chain,
+// attributing every packet message to the relayer whose wallet signed it.
+// Only cosmos chains are supported.
+//
+// RelayedPacketReport must be called after Build.
+func (ic *Interchain) RelayedPacketReport(ctx context.Context, chain ibc.Chain, startHeight, endHeight int64) (RelayedPacketReport, error) {
+	if !ic.built {
+		panic(fmt.Errorf("Interchain.RelayedPacketReport called before Build"))
+	}
+
+	cosmosChain, ok := chain.(*cosmos.CosmosChain)
+	if !ok {
+		return RelayedPacketReport{}, fmt.Errorf("relayed packet report is not supported for chain type %T", chain)
+	}
+
+	relayerNames := make(map[string]string)
+	for rc, wallet := range ic.relayerWallets {
+		if rc.C == chain {
+			relayerNames[wallet.FormattedAddress()] = ic.relayers[rc.R]
+		}
+	}
+
+	packets, err := cosmosChain.RelayedPackets(ctx, startHeight, endHeight)
+	if err != nil {
+		return RelayedPacketReport{}, err
+	}
+
+	return newRelayedPacketReport(packets, relayerNames), nil
+}
+
+// newRelayedPacketReport attributes packets to relayers by the address of their signer.
+func newRelayedPacketReport(packets []cosmos.RelayedPacket, relayerNames map[string]string) RelayedPacketReport {
+	report := RelayedPacketReport{
+		Packets:   make([]RelayedPacket, len(packets)),
+		Delivered: make(map[string]int),
+		Redundant: make(map[string]int),
+	}
+	for i, p := range packets {
+		name := relayerNames[p.Signer]
+		report.Packets[i] = RelayedPacket{RelayedPacket: p, Relayer: name}
+		switch {
+		case p.Delivered():
+			report.Delivered[name]++
+		case p.Redundant():
+			report.Redundant[name]++
+		}
+	}
+	return report
+}
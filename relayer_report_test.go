@@ -0,0 +1,48 @@
+package interchaintest
+
+import (
+	"testing"
+
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayedPacketReport(t *testing.T) {
+	const recv = "/ibc.core.channel.v1.MsgRecvPacket"
+	packet := func(signer string, seq uint64, result chantypes.ResponseResultType) cosmos.RelayedPacket {
+		return cosmos.RelayedPacket{
+			Signer:  signer,
+			MsgType: recv,
+			Packet:  ibc.Packet{SourceChannel: "channel-0", Sequence: seq},
+			Result:  result,
+		}
+	}
+
+	report := newRelayedPacketReport([]cosmos.RelayedPacket{
+		packet("cosmos1a", 1, chantypes.SUCCESS),
+		// The losing relayer's copy is included in the block with code 0, but its result is NOOP.
+		packet("cosmos1b", 1, chantypes.NOOP),
+		packet("cosmos1b", 2, chantypes.SUCCESS),
+		packet("cosmos1a", 2, chantypes.NOOP),
+		packet("cosmos1a", 3, chantypes.SUCCESS),
+		// Failed transactions are neither delivered nor redundant.
+		{Signer: "cosmos1b", MsgType: recv, Packet: ibc.Packet{SourceChannel: "channel-0", Sequence: 4}, Code: 11, Codespace: "sdk"},
+		// Signers that are not relayer wallets are reported under the empty name.
+		packet("cosmos1z", 5, chantypes.SUCCESS),
+	}, map[string]string{"cosmos1a": "rly", "cosmos1b": "hermes"})
+
+	require.Len(t, report.Packets, 7)
+	require.Equal(t, "hermes", report.Packets[1].Relayer)
+	require.Equal(t, map[string]int{"rly": 2, "hermes": 1, "": 1}, report.Delivered)
+	require.Equal(t, map[string]int{"rly": 1, "hermes": 1}, report.Redundant)
+
+	for seq, want := range map[uint64]string{1: "rly", 2: "hermes", 3: "rly"} {
+		got, ok := report.Landed(recv, "channel-0", seq)
+		require.True(t, ok, seq)
+		require.Equal(t, want, got, seq)
+	}
+	_, ok := report.Landed(recv, "channel-0", 4)
+	require.False(t, ok, "failed deliveries do not land")
+}
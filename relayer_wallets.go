@@ -0,0 +1,268 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"go.uber.org/zap"
+)
+
+// RelayerWalletRefill configures automatic top ups of relayer wallets from each chain's faucet account,
+// which keeps long-running networks relaying after the genesis funds are spent on fees.
+//
+// Refills are sent from the faucet key, so tests funding users from the faucet concurrently
+// may observe account sequence mismatches.
+type RelayerWalletRefill struct {
+	// Balance, in the chain's base denom, below which a relayer wallet is refilled.
+	Threshold sdkmath.Int
+
+	// Amount, in the chain's base denom, sent to a relayer wallet on each refill.
+	Amount sdkmath.Int
+
+	// How often relayer balances are checked. Defaults to one minute.
+	Interval time.Duration
+}
+
+func (r RelayerWalletRefill) validate() error {
+	if r.Threshold.IsNil() || !r.Threshold.IsPositive() {
+		return fmt.Errorf("relayer wallet refill threshold must be positive, got %v", r.Threshold)
+	}
+	if r.Amount.IsNil() || !r.Amount.IsPositive() {
+		return fmt.Errorf("relayer wallet refill amount must be positive, got %v", r.Amount)
+	}
+	return nil
+}
+
+// needed reports whether a relayer wallet holding balance is to be refilled.
+func (r RelayerWalletRefill) needed(balance sdkmath.Int) bool {
+	return balance.LT(r.Threshold)
+}
+
+// RelayerSpend describes the balance changes of one relayer wallet on one chain since Build.
+type RelayerSpend struct {
+	// Name of the relayer as given to AddRelayer.
+	Relayer string
+
+	ChainID string
+	Denom   string
+
+	// Balance after the chains started and the current balance.
+	StartBalance, EndBalance sdkmath.Int
+
+	// Total sent to the wallet by automatic refills.
+	Refilled sdkmath.Int
+
+	// Total transaction fees paid by the wallet in the blocks between the two balances.
+	FeesPaid sdkmath.Int
+
+	// Total received by the wallet other than refills, e.g. fee middleware incentives.
+	Rewards sdkmath.Int
+}
+
+// RelayerSpend reports, for every relayer wallet, the fees paid and rewards earned since Build.
+//
+// Fees are read from the transaction index of cosmos chains.
+// For other chains only the net balance change is known,
+// so it is reported as fees paid if negative and as rewards if positive.
+//
+// RelayerSpend must be called after Build.
+func (ic *Interchain) RelayerSpend(ctx context.Context) ([]RelayerSpend, error) {
+	if !ic.built {
+		panic(fmt.Errorf("Interchain.RelayerSpend called before Build"))
+	}
+
+	ic.refillMu.Lock()
+	refilled := make(map[relayerChain]sdkmath.Int, len(ic.relayerRefills))
+	for rc, amount := range ic.relayerRefills {
+		refilled[rc] = amount
+	}
+	ic.refillMu.Unlock()
+
+	out := make([]RelayerSpend, 0, len(ic.relayerWallets))
+	for rc, wallet := range ic.relayerWallets {
+		start, ok := ic.relayerStartBalances[rc]
+		if !ok {
+			// Build did not reach the point of starting the chains.
+			continue
+		}
+
+		c := rc.C
+		denom := c.Config().Denom
+		end, err := getRelayerBalance(ctx, c, wallet.FormattedAddress())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance of relayer %s on chain %s: %w", ic.relayers[rc.R], ic.chains[c], err)
+		}
+
+		spend := RelayerSpend{
+			Relayer:      ic.relayers[rc.R],
+			ChainID:      c.Config().ChainID,
+			Denom:        denom,
+			StartBalance: start.Amount,
+			EndBalance:   end.Amount,
+			Refilled:     sdkmath.ZeroInt(),
+			FeesPaid:     sdkmath.ZeroInt(),
+			Rewards:      sdkmath.ZeroInt(),
+		}
+		if amount, ok := refilled[rc]; ok {
+			spend.Refilled = amount
+		}
+
+		if cosmosChain, ok := c.(*cosmos.CosmosChain); ok {
+			fees, err := cosmosChain.FeesPaid(ctx, wallet.FormattedAddress(), denom, start.Height, end.Height)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get fees paid by relayer %s on chain %s: %w", ic.relayers[rc.R], ic.chains[c], err)
+			}
+			spend.settle(fees, true)
+		} else {
+			spend.settle(sdkmath.Int{}, false)
+		}
+
+		out = append(out, spend)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Relayer != out[j].Relayer {
+			return out[i].Relayer < out[j].Relayer
+		}
+		return out[i].ChainID < out[j].ChainID
+	})
+	return out, nil
+}
+
+// settle sets the fees paid and rewards of s from its balances and refills.
+// If the fees paid are not known, the change in balance not explained by refills
+// is reported as fees paid if negative and as rewards if positive.
+func (s *RelayerSpend) settle(fees sdkmath.Int, feesKnown bool) {
+	delta := s.EndBalance.Sub(s.StartBalance).Sub(s.Refilled)
+	switch {
+	case feesKnown:
+		s.FeesPaid = fees
+		s.Rewards = delta.Add(fees)
+	case delta.IsNegative():
+		s.FeesPaid = delta.Neg()
+	default:
+		s.Rewards = delta
+	}
+}
+
+// relayerBalance is the balance of a relayer wallet in the chain's base denom at a block height.
+type relayerBalance struct {
+	Amount sdkmath.Int
+	Height int64
+}
+
+// getRelayerBalance returns the balance of address in the base denom of c and the height it was read at,
+// so that fees can be counted over the same blocks as the balance change.
+// The balance is read again if a block was committed while reading it, up to a few times.
+func getRelayerBalance(ctx context.Context, c ibc.Chain, address string) (relayerBalance, error) {
+	var bal relayerBalance
+	for attempt := 0; attempt < 3; attempt++ {
+		before, err := c.Height(ctx)
+		if err != nil {
+			return relayerBalance{}, err
+		}
+		amount, err := c.GetBalance(ctx, address, c.Config().Denom)
+		if err != nil {
+			return relayerBalance{}, err
+		}
+		after, err := c.Height(ctx)
+		if err != nil {
+			return relayerBalance{}, err
+		}
+		bal = relayerBalance{Amount: amount, Height: after}
+		if before == after {
+			break
+		}
+	}
+	return bal, nil
+}
+
+// snapshotRelayerBalances populates ic.relayerStartBalances.
+func (ic *Interchain) snapshotRelayerBalances(ctx context.Context) error {
+	ic.relayerStartBalances = make(map[relayerChain]relayerBalance, len(ic.relayerWallets))
+	for rc, wallet := range ic.relayerWallets {
+		bal, err := getRelayerBalance(ctx, rc.C, wallet.FormattedAddress())
+		if err != nil {
+			return fmt.Errorf("failed to get balance of relayer %s on chain %s: %w", ic.relayers[rc.R], ic.chains[rc.C], err)
+		}
+		ic.relayerStartBalances[rc] = bal
+	}
+	return nil
+}
+
+// startRelayerWalletRefill starts a background worker refilling relayer wallets,
+// which is stopped by Close.
+func (ic *Interchain) startRelayerWalletRefill(ctx context.Context, refill RelayerWalletRefill) {
+	if refill.Interval <= 0 {
+		refill.Interval = time.Minute
+	}
+
+	// The worker outlives the Build call, so it is only cancelled through Close.
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	ic.stopRefill = func() {
+		cancel()
+		<-done
+	}
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(refill.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ic.refillRelayerWallets(ctx, refill)
+			}
+		}
+	}()
+}
+
+// refillRelayerWallets tops up every relayer wallet below the refill threshold.
+// Failures are logged rather than returned, so a transient error does not stop future refills.
+func (ic *Interchain) refillRelayerWallets(ctx context.Context, refill RelayerWalletRefill) {
+	for rc, wallet := range ic.relayerWallets {
+		c := rc.C
+		denom := c.Config().Denom
+		log := ic.log.With(
+			zap.String("relayer", ic.relayers[rc.R]),
+			zap.String("chain_id", c.Config().ChainID),
+			zap.String("address", wallet.FormattedAddress()),
+		)
+
+		bal, err := c.GetBalance(ctx, wallet.FormattedAddress(), denom)
+		if err != nil {
+			log.Info("Failed to get relayer wallet balance", zap.Error(err))
+			continue
+		}
+		if !refill.needed(bal) {
+			continue
+		}
+
+		if err := c.SendFunds(ctx, FaucetAccountKeyName, ibc.WalletAmount{
+			Address: wallet.FormattedAddress(),
+			Denom:   denom,
+			Amount:  refill.Amount,
+		}); err != nil {
+			log.Info("Failed to refill relayer wallet", zap.Error(err))
+			continue
+		}
+		log.Info("Refilled relayer wallet", zap.String("balance", bal.String()), zap.String("amount", refill.Amount.String()))
+
+		ic.refillMu.Lock()
+		if prev, ok := ic.relayerRefills[rc]; ok {
+			ic.relayerRefills[rc] = prev.Add(refill.Amount)
+		} else {
+			ic.relayerRefills[rc] = refill.Amount
+		}
+		ic.refillMu.Unlock()
+	}
+}
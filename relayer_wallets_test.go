@@ -0,0 +1,102 @@
+package interchaintest
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayerWalletRefill(t *testing.T) {
+	refill := RelayerWalletRefill{Threshold: sdkmath.NewInt(1_000), Amount: sdkmath.NewInt(5_000)}
+	require.NoError(t, refill.validate())
+	require.True(t, refill.needed(sdkmath.NewInt(999)))
+	require.False(t, refill.needed(sdkmath.NewInt(1_000)))
+	require.False(t, refill.needed(sdkmath.NewInt(10_000)))
+
+	for name, r := range map[string]RelayerWalletRefill{
+		"unset threshold": {Amount: sdkmath.NewInt(5_000)},
+		"unset amount":    {Threshold: sdkmath.NewInt(1_000)},
+		"zero amount":     {Threshold: sdkmath.NewInt(1_000), Amount: sdkmath.ZeroInt()},
+		"negative":        {Threshold: sdkmath.NewInt(-1), Amount: sdkmath.NewInt(5_000)},
+	} {
+		require.Error(t, r.validate(), name)
+	}
+
+	err := NewInterchain().Build(context.Background(), nil, InterchainBuildOptions{
+		RelayerWalletRefill: &RelayerWalletRefill{Threshold: sdkmath.NewInt(1_000)},
+	})
+	require.EqualError(t, err, "relayer wallet refill amount must be positive, got <nil>")
+}
+
+func TestRelayerSpendSettle(t *testing.T) {
+	newSpend := func(start, end, refilled int64) RelayerSpend {
+		return RelayerSpend{
+			StartBalance: sdkmath.NewInt(start),
+			EndBalance:   sdkmath.NewInt(end),
+			Refilled:     sdkmath.NewInt(refilled),
+			FeesPaid:     sdkmath.ZeroInt(),
+			Rewards:      sdkmath.ZeroInt(),
+		}
+	}
+
+	t.Run("known fees", func(t *testing.T) {
+		// 1000 - 300 fees + 500 refill + 50 rewards.
+		s := newSpend(1_000, 1_250, 500)
+		s.settle(sdkmath.NewInt(300), true)
+		require.Equal(t, sdkmath.NewInt(300), s.FeesPaid)
+		require.Equal(t, sdkmath.NewInt(50), s.Rewards)
+	})
+
+	t.Run("balance decrease", func(t *testing.T) {
+		s := newSpend(1_000, 900, 200)
+		s.settle(sdkmath.Int{}, false)
+		require.Equal(t, sdkmath.NewInt(300), s.FeesPaid)
+		require.True(t, s.Rewards.IsZero())
+	})
+
+	t.Run("balance increase", func(t *testing.T) {
+		s := newSpend(1_000, 1_100, 0)
+		s.settle(sdkmath.Int{}, false)
+		require.True(t, s.FeesPaid.IsZero())
+		require.Equal(t, sdkmath.NewInt(100), s.Rewards)
+	})
+}
+
+// heightChain is an ibc.Chain returning the given heights in turn,
+// and a balance growing by 100 on every read.
+type heightChain struct {
+	ibc.Chain
+	heights []int64
+	reads   int
+}
+
+func (c *heightChain) Config() ibc.ChainConfig { return ibc.ChainConfig{Denom: "uatom"} }
+
+func (c *heightChain) Height(ctx context.Context) (int64, error) {
+	h := c.heights[0]
+	c.heights = c.heights[1:]
+	return h, nil
+}
+
+func (c *heightChain) GetBalance(ctx context.Context, address, denom string) (sdkmath.Int, error) {
+	c.reads++
+	return sdkmath.NewInt(int64(100 * c.reads)), nil
+}
+
+func TestGetRelayerBalance(t *testing.T) {
+	ctx := context.Background()
+
+	c := &heightChain{heights: []int64{5, 5}}
+	bal, err := getRelayerBalance(ctx, c, "addr")
+	require.NoError(t, err)
+	require.Equal(t, relayerBalance{Amount: sdkmath.NewInt(100), Height: 5}, bal)
+
+	// A block committed during the read makes the balance be read again.
+	c = &heightChain{heights: []int64{5, 6, 6, 6}}
+	bal, err = getRelayerBalance(ctx, c, "addr")
+	require.NoError(t, err)
+	require.Equal(t, relayerBalance{Amount: sdkmath.NewInt(200), Height: 6}, bal)
+}
@@ -2,6 +2,7 @@ package interchaintest
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
@@ -9,6 +10,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v8/relayer/hermes"
 	"github.com/strangelove-ventures/interchaintest/v8/relayer/hyperspace"
 	"github.com/strangelove-ventures/interchaintest/v8/relayer/rly"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer/rlylib"
 	"go.uber.org/zap"
 )
 
@@ -76,11 +78,26 @@ func (f *builtinRelayerFactory) Build(
 		r := hermes.NewHermesRelayer(f.log, t.Name(), cli, networkID, f.options...)
 		f.setRelayerVersion(r.ContainerImage())
 		return r
+	case ibc.CosmosRlyInProcess:
+		return rlylib.NewCosmosRelayer(f.log, relayerHomeDir(t))
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
 }
 
+// relayerHomeDir returns a directory for a relayer running on the host,
+// removed at the end of the test if t is a testing.TB.
+func relayerHomeDir(t TestName) string {
+	if tb, ok := t.(interface{ TempDir() string }); ok {
+		return tb.TempDir()
+	}
+	dir, err := os.MkdirTemp("", "interchaintest-relayer-")
+	if err != nil {
+		panic(err) // TODO: return
+	}
+	return dir
+}
+
 func (f *builtinRelayerFactory) setRelayerVersion(di ibc.DockerImage) {
 	f.version = di.Version
 }
@@ -97,9 +114,30 @@ func (f *builtinRelayerFactory) Name() string {
 			return "hermes@" + f.version
 		}
 		return "hermes@" + hermes.DefaultContainerVersion
+	case ibc.CosmosRlyInProcess:
+		return "rlylib@" + rlylib.LibraryVersion()
+	default:
+		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
+	}
+}
+
+// Images returns the image of the relayers built by this factory.
+func (f *builtinRelayerFactory) Images() []ibc.DockerImage {
+	var def ibc.DockerImage
+	switch f.impl {
+	case ibc.CosmosRly:
+		def = ibc.DockerImage{Repository: rly.DefaultContainerImage, Version: rly.DefaultContainerVersion}
+	case ibc.Hermes:
+		def = ibc.DockerImage{Repository: hermes.DefaultContainerImage, Version: hermes.DefaultContainerVersion}
+	case ibc.Hyperspace:
+		def = ibc.DockerImage{Repository: hyperspace.HyperspaceDefaultContainerImage, Version: hyperspace.HyperspaceDefaultContainerVersion}
+	case ibc.CosmosRlyInProcess:
+		// The relayer is linked into the test binary.
+		return nil
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
+	return []ibc.DockerImage{relayer.ResolveImage(def, f.options...)}
 }
 
 // Capabilities returns the set of capabilities for the
@@ -111,6 +149,8 @@ func (f builtinRelayerFactory) Capabilities() map[relayer.Capability]bool {
 	case ibc.Hermes:
 		// TODO: specify capability for hermes.
 		return rly.Capabilities()
+	case ibc.CosmosRlyInProcess:
+		return rlylib.Capabilities()
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -2,6 +2,7 @@ package interchaintest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"go.uber.org/zap/zaptest"
 )
 
 const (
@@ -37,6 +39,33 @@ func DockerSetup(t dockerutil.DockerSetupTestingT) (*client.Client, string) {
 	return dockerutil.DockerSetup(t)
 }
 
+// SuperviseContainers returns a copy of ctx that is canceled as soon as a chain node, sidecar or relayer
+// container of client exits without being stopped by the test, and fails t with the container's exit code and last logs.
+// Pass the returned context to the interchain and to calls such as testutil.WaitForBlocks,
+// so that a crashed validator fails the test right away instead of letting it hang until its timeout.
+//
+// Chains expected to halt, such as at an upgrade height, should stop their nodes through StopAllNodes
+// before the halt height, or not be supervised.
+func SuperviseContainers(t *testing.T, ctx context.Context, client *client.Client) context.Context {
+	t.Helper()
+	ctx, cancel := dockerutil.Supervise(ctx, zaptest.NewLogger(t), dockerutil.RuntimeFor(client))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		var exitErr *dockerutil.ContainerExitError
+		if errors.As(context.Cause(ctx), &exitErr) {
+			t.Error(exitErr)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return ctx
+}
+
 // startup both chains
 // creates wallets in the relayer for src and dst chain
 // funds relayer src and dst wallets on respective chain in genesis
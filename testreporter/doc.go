@@ -73,4 +73,14 @@
 //
 // If you use a plain require.NoError(t, err) call,
 // the report will note that the test failed, but the report will not include the error line.
+//
+// The JSON report can be converted for CI systems with a Summary,
+// either from a completed report file read with ReadMessages,
+// or directly from a Reporter with TrackSummary:
+//
+//	summary := reporter.TrackSummary()
+//	code := m.Run()
+//	_ = reporter.Close()
+//	_ = summary.WriteJUnit(junitFile)
+//	_ = summary.WriteHTML(htmlFile)
 package testreporter
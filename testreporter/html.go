@@ -0,0 +1,57 @@
+package testreporter
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+//go:embed report.html
+var reportHTML string
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": func(d time.Duration) string { return d.Round(time.Millisecond).String() },
+	"command":  func(cmd []string) string { return strings.Join(cmd, " ") },
+	"status":   testStatus,
+	"time": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	},
+}).Parse(reportHTML))
+
+// WriteHTML writes the summary to w as a standalone HTML page.
+func (s *Summary) WriteHTML(w io.Writer) error {
+	data := struct {
+		StartedAt time.Time
+		Duration  time.Duration
+		Counts    SummaryCounts
+		Tests     []TestResult
+	}{
+		StartedAt: s.StartedAt(),
+		Duration:  s.Duration(),
+		Counts:    s.Counts(),
+		Tests:     s.Tests(),
+	}
+	if err := reportTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("execute html template: %w", err)
+	}
+	return nil
+}
+
+func testStatus(tr TestResult) string {
+	switch {
+	case !tr.Finished:
+		return "unfinished"
+	case tr.Failed:
+		return "failed"
+	case tr.Skipped:
+		return "skipped"
+	default:
+		return "passed"
+	}
+}
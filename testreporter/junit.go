@@ -0,0 +1,161 @@
+package testreporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// JUnit XML types, following the schema understood by common CI systems.
+type (
+	junitTestSuites struct {
+		XMLName  xml.Name         `xml:"testsuites"`
+		Name     string           `xml:"name,attr"`
+		Tests    int              `xml:"tests,attr"`
+		Failures int              `xml:"failures,attr"`
+		Errors   int              `xml:"errors,attr"`
+		Skipped  int              `xml:"skipped,attr"`
+		Time     string           `xml:"time,attr"`
+		Suites   []junitTestSuite `xml:"testsuite"`
+	}
+
+	junitTestSuite struct {
+		Name      string          `xml:"name,attr"`
+		Tests     int             `xml:"tests,attr"`
+		Failures  int             `xml:"failures,attr"`
+		Errors    int             `xml:"errors,attr"`
+		Skipped   int             `xml:"skipped,attr"`
+		Time      string          `xml:"time,attr"`
+		Timestamp string          `xml:"timestamp,attr,omitempty"`
+		Cases     []junitTestCase `xml:"testcase"`
+	}
+
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		Classname string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Skipped   *junitMessage `xml:"skipped"`
+		Failure   *junitMessage `xml:"failure"`
+		Error     *junitMessage `xml:"error"`
+		SystemOut string        `xml:"system-out,omitempty"`
+	}
+
+	junitMessage struct {
+		Message string `xml:"message,attr"`
+		Body    string `xml:",chardata"`
+	}
+)
+
+// WriteJUnit writes the summary to w as JUnit XML.
+//
+// Every test is a testcase whose classname is its top-level test.
+// Failed tests include their tracked error messages, skipped tests include the skip reason,
+// and tests that never finished are reported as errors.
+// Relayer commands executed during the test are attached as system-out,
+// followed by the paths of collected artifacts in the [[ATTACHMENT|path]] form recognized by several CI systems.
+func (s *Summary) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name: "interchaintest",
+		Time: junitSeconds(s.Duration()),
+	}
+	if start := s.StartedAt(); !start.IsZero() {
+		suite.Timestamp = start.UTC().Format("2006-01-02T15:04:05")
+	}
+
+	for _, tr := range s.Tests() {
+		tc := junitTestCase{
+			Name:      tr.Name,
+			Classname: strings.SplitN(tr.Name, "/", 2)[0],
+			Time:      junitSeconds(tr.Duration()),
+			SystemOut: relayerExecLog(tr.RelayerExecs) + attachments(tr.Artifacts),
+		}
+		switch {
+		case !tr.Finished:
+			suite.Errors++
+			tc.Error = &junitMessage{Message: "test did not finish", Body: errorsText(tr.Errors)}
+		case tr.Failed:
+			suite.Failures++
+			tc.Failure = &junitMessage{Message: "test failed", Body: errorsText(tr.Errors)}
+		case tr.Skipped:
+			suite.Skipped++
+			tc.Skipped = &junitMessage{Message: tr.SkipReason}
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	doc := junitTestSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode junit xml: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func errorsText(msgs []TestErrorMessage) string {
+	var sb strings.Builder
+	for i, m := range msgs {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(m.Message)
+	}
+	return sb.String()
+}
+
+// relayerExecLog formats the relayer commands as a plain text log.
+func relayerExecLog(execs []RelayerExecMessage) string {
+	var sb strings.Builder
+	for _, m := range execs {
+		fmt.Fprintf(&sb, "$ %s\n", strings.Join(m.Command, " "))
+		fmt.Fprintf(&sb, "# container=%s exit_code=%d duration=%s\n", m.ContainerName, m.ExitCode, m.FinishedAt.Sub(m.StartedAt))
+		if m.Error != "" {
+			fmt.Fprintf(&sb, "# error: %s\n", m.Error)
+		}
+		writeIndented(&sb, "stdout", m.Stdout)
+		writeIndented(&sb, "stderr", m.Stderr)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func attachments(artifacts []ArtifactMessage) string {
+	var sb strings.Builder
+	for _, a := range artifacts {
+		fmt.Fprintf(&sb, "[[ATTACHMENT|%s]]\n", a.Path)
+	}
+	return sb.String()
+}
+
+func writeIndented(sb *strings.Builder, label, s string) {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return
+	}
+	fmt.Fprintf(sb, "# %s:\n", label)
+	for _, line := range strings.Split(s, "\n") {
+		sb.WriteString("  ")
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+}
@@ -133,6 +133,21 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// ArtifactMessage records a file collected for a test, such as a container log
+// or node config collected after a failure.
+type ArtifactMessage struct {
+	Name string // Test name, but "Name" for consistency.
+
+	When time.Time
+
+	Path string
+	Size int64
+}
+
+func (m ArtifactMessage) typ() string {
+	return "Artifact"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -199,6 +214,10 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "Artifact":
+		x := ArtifactMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
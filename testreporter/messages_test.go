@@ -39,6 +39,7 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{Message: testreporter.ArtifactMessage{Name: "foo", When: time.Now(), Path: "/tmp/foo/logs/node.log", Size: 1024}},
 	}
 
 	for _, tc := range tcs {
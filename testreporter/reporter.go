@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 )
 
@@ -27,6 +28,10 @@ type Reporter struct {
 	in chan Message
 
 	writerDone chan error
+
+	subsMu  sync.Mutex
+	nextSub int
+	subs    map[int]func(Message)
 }
 
 func NewReporter(w io.WriteCloser) *Reporter {
@@ -35,6 +40,8 @@ func NewReporter(w io.WriteCloser) *Reporter {
 
 		in:         make(chan Message, 256), // Arbitrary size that seems unlikely to be filled.
 		writerDone: make(chan error, 1),
+
+		subs: make(map[int]func(Message)),
 	}
 
 	go r.write()
@@ -54,6 +61,7 @@ func (r *Reporter) write() {
 		if err := enc.Encode(JSONMessage(m)); err != nil {
 			panic(fmt.Errorf("reporter failed to encode message; tests cannot continue: %w", err))
 		}
+		r.publish(m)
 	}
 
 	r.writerDone <- r.w.Close()
@@ -69,6 +77,39 @@ func (r *Reporter) Close() error {
 	return <-r.writerDone
 }
 
+// Subscribe calls fn with every message tracked by r, after the message is written.
+// Messages for all tests are delivered, in the order they were tracked.
+// fn is called from the single goroutine writing messages, so it should return quickly.
+//
+// The returned function stops calling fn, and is safe to be called multiple times.
+func (r *Reporter) Subscribe(fn func(Message)) (unsubscribe func()) {
+	r.subsMu.Lock()
+	defer r.subsMu.Unlock()
+
+	id := r.nextSub
+	r.nextSub++
+	r.subs[id] = fn
+
+	return func() {
+		r.subsMu.Lock()
+		defer r.subsMu.Unlock()
+		delete(r.subs, id)
+	}
+}
+
+func (r *Reporter) publish(m Message) {
+	r.subsMu.Lock()
+	fns := make([]func(Message), 0, len(r.subs))
+	for _, fn := range r.subs {
+		fns = append(fns, fn)
+	}
+	r.subsMu.Unlock()
+
+	for _, fn := range fns {
+		fn(m)
+	}
+}
+
 // trackTest tracks the test start and finish time.
 // It also records which labels are present on the test.
 func (r *Reporter) TrackTest(t T) {
@@ -130,6 +171,11 @@ type RelayerExecReporter struct {
 	testName string
 }
 
+// Reporter returns the Reporter that created r.
+func (r *RelayerExecReporter) Reporter() *Reporter {
+	return r.r
+}
+
 // TrackRelayerExec tracks the execution of an individual relayer command.
 func (r *RelayerExecReporter) TrackRelayerExec(
 	containerName string,
@@ -156,6 +202,17 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 	}
 }
 
+// TrackArtifact tracks a file collected for the test, such as a log collected after a failure.
+// Its signature matches the callback accepted by dockerutil.OnArtifact.
+func (r *RelayerExecReporter) TrackArtifact(path string, size int64) {
+	r.r.in <- ArtifactMessage{
+		Name: r.testName,
+		When: time.Now(),
+		Path: path,
+		Size: size,
+	}
+}
+
 // TestifyT returns a TestifyReporter which will track logged errors in test.
 // Typically you will use this with the New method on the require or assert package:
 //
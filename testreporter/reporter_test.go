@@ -259,6 +259,46 @@ func TestReporter_RelayerExec(t *testing.T) {
 	require.Empty(t, diff)
 }
 
+func TestReporter_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	r := testreporter.NewNopReporter()
+
+	var got []testreporter.Message
+	unsubscribe := r.Subscribe(func(m testreporter.Message) {
+		// BeginSuite may or may not be written before subscribing.
+		if _, ok := m.(testreporter.BeginSuiteMessage); !ok {
+			got = append(got, m)
+		}
+	})
+
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+	r.RelayerExecReporter(mt).Reporter().TrackTest(mocktesting.NewT("other_test"))
+
+	// Synchronize with the writer goroutine through a subscription,
+	// so that unsubscribe happens after the messages above are delivered.
+	delivered := make(chan struct{})
+	stopSync := r.Subscribe(func(m testreporter.Message) {
+		if _, ok := m.(testreporter.TestErrorMessage); ok {
+			close(delivered)
+		}
+	})
+	r.TestifyT(mocktesting.NewT("sync")).Errorf("sync")
+	<-delivered
+	unsubscribe()
+	unsubscribe() // Safe to call multiple times.
+	stopSync()
+
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	require.Len(t, got, 3)
+	require.Equal(t, "my_test", got[0].(testreporter.BeginTestMessage).Name)
+	require.Equal(t, "other_test", got[1].(testreporter.BeginTestMessage).Name)
+	require.Equal(t, "sync", got[2].(testreporter.TestErrorMessage).Name)
+}
+
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
 func requireTimeInRange(t *testing.T, actual, notBefore, notAfter time.Time) {
 	t.Helper()
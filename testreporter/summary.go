@@ -0,0 +1,231 @@
+package testreporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReadMessages decodes the stream of messages written by a Reporter.
+func ReadMessages(r io.Reader) ([]Message, error) {
+	var msgs []Message
+
+	dec := json.NewDecoder(r)
+	for {
+		var wm WrappedMessage
+		if err := dec.Decode(&wm); err != nil {
+			if errors.Is(err, io.EOF) {
+				return msgs, nil
+			}
+			return msgs, fmt.Errorf("decode message %d: %w", len(msgs)+1, err)
+		}
+		msgs = append(msgs, wm.Message)
+	}
+}
+
+// TestResult is the outcome of a single test, assembled from all the messages tracked for that test.
+type TestResult struct {
+	Name string
+
+	StartedAt, FinishedAt time.Time
+
+	// Paused is the time the test spent waiting for its turn to run in parallel.
+	Paused time.Duration
+
+	// Finished is false if the test began but its FinishTestMessage was never tracked,
+	// which typically means the test binary panicked or timed out.
+	Finished bool
+
+	Failed, Skipped bool
+
+	SkipReason string
+
+	Errors []TestErrorMessage
+
+	RelayerExecs []RelayerExecMessage
+
+	Artifacts []ArtifactMessage
+
+	pausedAt time.Time
+}
+
+// Duration is the time the test spent running, excluding time paused for parallel execution.
+// Tests that never finished have no duration.
+func (r TestResult) Duration() time.Duration {
+	if !r.Finished || r.StartedAt.IsZero() {
+		return 0
+	}
+	d := r.FinishedAt.Sub(r.StartedAt) - r.Paused
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// Summary aggregates a stream of messages into per-test results.
+//
+// A Summary may be built from a decoded report with NewSummary,
+// or directly from a live Reporter with (*Reporter).TrackSummary.
+type Summary struct {
+	mu sync.Mutex
+
+	startedAt, finishedAt time.Time
+
+	tests map[string]*TestResult
+}
+
+// NewSummary returns a Summary of msgs.
+func NewSummary(msgs []Message) *Summary {
+	s := new(Summary)
+	for _, m := range msgs {
+		s.Add(m)
+	}
+	return s
+}
+
+// Add incorporates m into the summary.
+// Add is safe for concurrent use and is suitable as a (*Reporter).Subscribe callback.
+func (s *Summary) Add(m Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch m := m.(type) {
+	case BeginSuiteMessage:
+		s.startedAt = m.StartedAt
+	case FinishSuiteMessage:
+		s.finishedAt = m.FinishedAt
+	case BeginTestMessage:
+		s.test(m.Name).StartedAt = m.StartedAt
+	case FinishTestMessage:
+		tr := s.test(m.Name)
+		tr.FinishedAt = m.FinishedAt
+		tr.Finished = true
+		tr.Failed = m.Failed
+		tr.Skipped = m.Skipped
+	case PauseTestMessage:
+		s.test(m.Name).pausedAt = m.When
+	case ContinueTestMessage:
+		tr := s.test(m.Name)
+		if !tr.pausedAt.IsZero() {
+			tr.Paused += m.When.Sub(tr.pausedAt)
+			tr.pausedAt = time.Time{}
+		}
+	case TestErrorMessage:
+		tr := s.test(m.Name)
+		tr.Errors = append(tr.Errors, m)
+	case TestSkipMessage:
+		s.test(m.Name).SkipReason = m.Message
+	case RelayerExecMessage:
+		tr := s.test(m.Name)
+		tr.RelayerExecs = append(tr.RelayerExecs, m)
+	case ArtifactMessage:
+		tr := s.test(m.Name)
+		tr.Artifacts = append(tr.Artifacts, m)
+	}
+}
+
+func (s *Summary) test(name string) *TestResult {
+	if s.tests == nil {
+		s.tests = make(map[string]*TestResult)
+	}
+	tr, ok := s.tests[name]
+	if !ok {
+		tr = &TestResult{Name: name}
+		s.tests[name] = tr
+	}
+	return tr
+}
+
+// Tests returns a copy of the results of every test in the summary, ordered by start time and then by name.
+func (s *Summary) Tests() []TestResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]TestResult, 0, len(s.tests))
+	for _, tr := range s.tests {
+		out = append(out, *tr)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].StartedAt.Equal(out[j].StartedAt) {
+			return out[i].StartedAt.Before(out[j].StartedAt)
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// StartedAt returns the time the suite began.
+// If the BeginSuiteMessage was not observed, the earliest test start time is used instead.
+func (s *Summary) StartedAt() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.startedAt.IsZero() {
+		return s.startedAt
+	}
+	var t time.Time
+	for _, tr := range s.tests {
+		if !tr.StartedAt.IsZero() && (t.IsZero() || tr.StartedAt.Before(t)) {
+			t = tr.StartedAt
+		}
+	}
+	return t
+}
+
+// Duration returns the wall time of the suite.
+// If the FinishSuiteMessage was not observed, the latest test finish time is used instead.
+func (s *Summary) Duration() time.Duration {
+	start := s.StartedAt()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	end := s.finishedAt
+	if end.IsZero() {
+		for _, tr := range s.tests {
+			if tr.FinishedAt.After(end) {
+				end = tr.FinishedAt
+			}
+		}
+	}
+	if start.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// SummaryCounts tallies test outcomes.
+type SummaryCounts struct {
+	Tests, Passed, Failed, Skipped, Unfinished int
+}
+
+// Counts tallies the outcomes of all tests in the summary.
+func (s *Summary) Counts() SummaryCounts {
+	var c SummaryCounts
+	for _, tr := range s.Tests() {
+		c.Tests++
+		switch {
+		case !tr.Finished:
+			c.Unfinished++
+		case tr.Failed:
+			c.Failed++
+		case tr.Skipped:
+			c.Skipped++
+		default:
+			c.Passed++
+		}
+	}
+	return c
+}
+
+// TrackSummary returns a Summary that is updated with every message tracked by r.
+// Once r is closed, the summary is complete and may be written with WriteJUnit or WriteHTML.
+func (r *Reporter) TrackSummary() *Summary {
+	s := new(Summary)
+	r.Subscribe(s.Add)
+	return s
+}
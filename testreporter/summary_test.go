@@ -0,0 +1,195 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/mocktesting"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+func summaryFixture() []testreporter.Message {
+	t0 := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(sec int) time.Time { return t0.Add(time.Duration(sec) * time.Second) }
+
+	return []testreporter.Message{
+		testreporter.BeginSuiteMessage{StartedAt: at(0)},
+
+		testreporter.BeginTestMessage{Name: "TestPass", StartedAt: at(1)},
+		testreporter.PauseTestMessage{Name: "TestPass", When: at(1)},
+		testreporter.ContinueTestMessage{Name: "TestPass", When: at(4)},
+		testreporter.RelayerExecMessage{
+			Name: "TestPass", StartedAt: at(5), FinishedAt: at(6),
+			ContainerName: "rly-1", Command: []string{"rly", "tx", "link"},
+			Stdout: "linked\n", Stderr: "warn <x>\n",
+		},
+		testreporter.FinishTestMessage{Name: "TestPass", FinishedAt: at(7)},
+
+		testreporter.BeginTestMessage{Name: "TestFail/sub", StartedAt: at(2)},
+		testreporter.TestErrorMessage{Name: "TestFail/sub", When: at(3), Message: "expected 1, got 2"},
+		testreporter.ArtifactMessage{Name: "TestFail/sub", When: at(3), Path: "/tmp/artifacts/logs/node.log", Size: 42},
+		testreporter.FinishTestMessage{Name: "TestFail/sub", FinishedAt: at(3), Failed: true},
+
+		testreporter.BeginTestMessage{Name: "TestSkip", StartedAt: at(3)},
+		testreporter.TestSkipMessage{Name: "TestSkip", When: at(3), Message: "not today"},
+		testreporter.FinishTestMessage{Name: "TestSkip", FinishedAt: at(3), Skipped: true},
+
+		testreporter.BeginTestMessage{Name: "TestHang", StartedAt: at(4)},
+
+		testreporter.FinishSuiteMessage{FinishedAt: at(10)},
+	}
+}
+
+func TestReadMessages(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+	mt := mocktesting.NewT("my_test")
+	r.TrackTest(mt)
+	mt.RunCleanups()
+	require.NoError(t, r.Close())
+
+	msgs, err := testreporter.ReadMessages(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, msgs, 4)
+	require.IsType(t, testreporter.BeginTestMessage{}, msgs[1])
+
+	_, err = testreporter.ReadMessages(strings.NewReader(`{"Type":"Bogus","Message":{}}`))
+	require.ErrorContains(t, err, "decode message 1")
+}
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	s := testreporter.NewSummary(summaryFixture())
+
+	require.Equal(t, 10*time.Second, s.Duration())
+	require.Equal(t, testreporter.SummaryCounts{Tests: 4, Passed: 1, Failed: 1, Skipped: 1, Unfinished: 1}, s.Counts())
+
+	tests := s.Tests()
+	require.Len(t, tests, 4)
+
+	var names []string
+	for _, tr := range tests {
+		names = append(names, tr.Name)
+	}
+	require.Equal(t, []string{"TestPass", "TestFail/sub", "TestSkip", "TestHang"}, names)
+
+	pass := tests[0]
+	require.Equal(t, 3*time.Second, pass.Paused)
+	require.Equal(t, 3*time.Second, pass.Duration())
+	require.Len(t, pass.RelayerExecs, 1)
+
+	fail := tests[1]
+	require.True(t, fail.Failed)
+	require.Len(t, fail.Errors, 1)
+	require.Len(t, fail.Artifacts, 1)
+	require.Equal(t, time.Second, fail.Duration())
+
+	require.Equal(t, "not today", tests[2].SkipReason)
+
+	require.False(t, tests[3].Finished)
+	require.Zero(t, tests[3].Duration())
+}
+
+func TestSummary_WriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	s := testreporter.NewSummary(summaryFixture())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, s.WriteJUnit(buf))
+
+	var got struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Errors   int `xml:"errors,attr"`
+		Skipped  int `xml:"skipped,attr"`
+		Suite    struct {
+			Timestamp string `xml:"timestamp,attr"`
+			Time      string `xml:"time,attr"`
+			Cases     []struct {
+				Name      string `xml:"name,attr"`
+				Classname string `xml:"classname,attr"`
+				Time      string `xml:"time,attr"`
+				Skipped   *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"skipped"`
+				Failure *struct {
+					Body string `xml:",chardata"`
+				} `xml:"failure"`
+				Error *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"error"`
+				SystemOut string `xml:"system-out"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &got))
+
+	require.Equal(t, 4, got.Tests)
+	require.Equal(t, 1, got.Failures)
+	require.Equal(t, 1, got.Errors)
+	require.Equal(t, 1, got.Skipped)
+	require.Equal(t, "2023-01-02T03:04:05", got.Suite.Timestamp)
+	require.Equal(t, "10.000", got.Suite.Time)
+	require.Len(t, got.Suite.Cases, 4)
+
+	pass := got.Suite.Cases[0]
+	require.Equal(t, "3.000", pass.Time)
+	require.Contains(t, pass.SystemOut, "$ rly tx link\n")
+	require.Contains(t, pass.SystemOut, "container=rly-1 exit_code=0")
+	require.Contains(t, pass.SystemOut, "  warn <x>\n")
+
+	fail := got.Suite.Cases[1]
+	require.Equal(t, "TestFail", fail.Classname)
+	require.NotNil(t, fail.Failure)
+	require.Equal(t, "expected 1, got 2", fail.Failure.Body)
+	require.Equal(t, "[[ATTACHMENT|/tmp/artifacts/logs/node.log]]\n", fail.SystemOut)
+
+	require.NotNil(t, got.Suite.Cases[2].Skipped)
+	require.Equal(t, "not today", got.Suite.Cases[2].Skipped.Message)
+
+	require.NotNil(t, got.Suite.Cases[3].Error)
+}
+
+func TestSummary_WriteHTML(t *testing.T) {
+	t.Parallel()
+
+	s := testreporter.NewSummary(summaryFixture())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, s.WriteHTML(buf))
+
+	html := buf.String()
+	require.Contains(t, html, "4 tests")
+	require.Contains(t, html, `<td class="failed">failed</td>`)
+	require.Contains(t, html, "$ rly tx link")
+	require.Contains(t, html, "warn &lt;x&gt;")
+	require.Contains(t, html, "not today")
+	require.Contains(t, html, "/tmp/artifacts/logs/node.log")
+}
+
+// Check that a summary tracked from a live reporter is complete once the reporter is closed.
+func TestReporter_TrackSummary(t *testing.T) {
+	t.Parallel()
+
+	r := testreporter.NewReporter(nopCloser{Writer: new(bytes.Buffer)})
+	s := r.TrackSummary()
+
+	mt := mocktesting.NewT("my_test")
+	mt.Simulate(func() {
+		r.TrackTest(mt)
+		r.TrackSkip(mt, "skipping")
+	})
+	require.NoError(t, r.Close())
+
+	require.Equal(t, testreporter.SummaryCounts{Tests: 1, Skipped: 1}, s.Counts())
+	require.Equal(t, "skipping", s.Tests()[0].SkipReason)
+	require.False(t, s.StartedAt().IsZero())
+}
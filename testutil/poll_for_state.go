@@ -8,6 +8,8 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var ErrNotFound = errors.New("not found")
@@ -17,11 +19,17 @@ type BlockPoller[T any] struct {
 	PollFunc      func(ctx context.Context, height int64) (T, error)
 }
 
-func (p BlockPoller[T]) DoPoll(ctx context.Context, startHeight, maxHeight int64) (T, error) {
+func (p BlockPoller[T]) DoPoll(ctx context.Context, startHeight, maxHeight int64) (_ T, err error) {
 	if maxHeight < startHeight {
 		panic("maxHeight must be greater than or equal to startHeight")
 	}
 
+	ctx, span := tracing.Start(ctx, "wait.poll",
+		attribute.Int64("interchaintest.start_height", startHeight),
+		attribute.Int64("interchaintest.max_height", maxHeight),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	var (
 		pollErr error
 		zero    T
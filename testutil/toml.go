@@ -7,7 +7,6 @@ import (
 	"reflect"
 
 	"github.com/BurntSushi/toml"
-	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"go.uber.org/zap"
 )
@@ -47,13 +46,13 @@ func RecursiveModifyToml(c map[string]any, modifications Toml) error {
 func ModifyTomlConfigFile(
 	ctx context.Context,
 	logger *zap.Logger,
-	dockerClient *client.Client,
+	rt dockerutil.ContainerRuntime,
 	testName string,
 	volumeName string,
 	filePath string,
 	modifications Toml,
 ) error {
-	fr := dockerutil.NewFileRetriever(logger, dockerClient, testName)
+	fr := dockerutil.NewFileRetriever(logger, rt, testName)
 	config, err := fr.SingleFileContent(ctx, volumeName, filePath)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve %s: %w", filePath, err)
@@ -73,7 +72,7 @@ func ModifyTomlConfigFile(
 		return fmt.Errorf("failed to encode %s: %w", filePath, err)
 	}
 
-	fw := dockerutil.NewFileWriter(logger, dockerClient, testName)
+	fw := dockerutil.NewFileWriter(logger, rt, testName)
 	if err := fw.WriteFile(ctx, volumeName, filePath, buf.Bytes()); err != nil {
 		return fmt.Errorf("overwriting %s: %w", filePath, err)
 	}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -15,10 +17,13 @@ type ChainHeighter interface {
 
 // WaitForBlocks blocks until all chains reach a block height delta equal to or greater than the delta argument.
 // If a ChainHeighter does not monotonically increase the height, this function may block program execution indefinitely.
-func WaitForBlocks(ctx context.Context, delta int, chains ...ChainHeighter) error {
+func WaitForBlocks(ctx context.Context, delta int, chains ...ChainHeighter) (err error) {
 	if len(chains) == 0 {
 		panic("missing chains")
 	}
+
+	ctx, span := tracing.Start(ctx, "wait.blocks", attribute.Int("interchaintest.delta", delta))
+	defer func() { tracing.End(span, err) }()
 	eg, egCtx := errgroup.WithContext(ctx)
 	for i := range chains {
 		chain := chains[i]
@@ -74,10 +79,14 @@ func NodesInSync(ctx context.Context, chain ChainHeighter, nodes []ChainHeighter
 }
 
 // WaitForInSync blocks until all nodes have heights greater than or equal to the chain height.
-func WaitForInSync(ctx context.Context, chain ChainHeighter, nodes ...ChainHeighter) error {
+func WaitForInSync(ctx context.Context, chain ChainHeighter, nodes ...ChainHeighter) (err error) {
 	if len(nodes) == 0 {
 		panic("missing nodes")
 	}
+
+	ctx, span := tracing.Start(ctx, "wait.in_sync")
+	defer func() { tracing.End(span, err) }()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -102,6 +111,10 @@ func (h *height) WaitForDelta(ctx context.Context, delta int) error {
 	for h.delta() < delta {
 		cur, err := h.Chain.Height(ctx)
 		if err != nil {
+			// Report why the context was canceled, such as a container that died, rather than the failed query.
+			if cause := context.Cause(ctx); cause != nil {
+				return cause
+			}
 			return err
 		}
 		// We assume the chain will eventually return a non-zero height, otherwise
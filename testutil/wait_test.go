@@ -71,6 +71,16 @@ func TestWaitForBlocks(t *testing.T) {
 		require.EqualError(t, err, "boom")
 	})
 
+	t.Run("canceled with cause", func(t *testing.T) {
+		cause := errors.New("validator crashed")
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(cause)
+		chain := mockChainHeighter{Err: context.Canceled}
+		err := WaitForBlocks(ctx, 1, &chain)
+
+		require.ErrorIs(t, err, cause)
+	})
+
 	t.Run("0 height", func(t *testing.T) {
 		const delta = 1
 		// Set height to -1 because the mock chain auto-increments the height resulting in starting height of 0.
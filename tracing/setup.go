@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// DefaultServiceName is the service name reported for spans when Config.ServiceName is empty.
+const DefaultServiceName = "interchaintest"
+
+// Config determines where Setup exports spans.
+// If both OTLPEndpoint and File are empty, Setup does nothing.
+type Config struct {
+	// OTLPEndpoint is the host:port or URL of an OTLP/HTTP collector, e.g. localhost:4318.
+	OTLPEndpoint string
+
+	// File is a path where spans are written as a stream of JSON objects.
+	File string
+
+	ServiceName string
+}
+
+// ConfigFromEnv returns a Config from environment variables.
+//
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, or else OTEL_EXPORTER_OTLP_ENDPOINT, sets OTLPEndpoint;
+// the OTLP exporter also honors the other standard OTEL_EXPORTER_OTLP_* variables, such as headers.
+// ICTEST_TRACE_FILE sets File, and OTEL_SERVICE_NAME sets ServiceName.
+func ConfigFromEnv() Config {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	return Config{
+		OTLPEndpoint: endpoint,
+		File:         os.Getenv("ICTEST_TRACE_FILE"),
+		ServiceName:  os.Getenv("OTEL_SERVICE_NAME"),
+	}
+}
+
+// Setup installs a global tracer provider that exports spans as configured by cfg.
+//
+// The returned shutdown function flushes any buffered spans and releases the exporters;
+// call it before the process exits, or spans may be lost.
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	var opts []sdktrace.TracerProviderOption
+	var closers []func(context.Context) error
+
+	if cfg.OTLPEndpoint != "" {
+		exp, err := otlptracehttp.New(ctx, otlpOptions(cfg.OTLPEndpoint)...)
+		if err != nil {
+			return nil, fmt.Errorf("create otlp exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+
+	if cfg.File != "" {
+		f, err := os.Create(cfg.File)
+		if err != nil {
+			return nil, fmt.Errorf("create trace file: %w", err)
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("create file exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+		closers = append(closers, func(context.Context) error { return f.Close() })
+	}
+
+	if len(opts) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	name := cfg.ServiceName
+	if name == "" {
+		name = DefaultServiceName
+	}
+	opts = append(opts, sdktrace.WithResource(resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(name),
+	)))
+
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		// Shutting down the provider flushes the exporters, which must happen before the file is closed.
+		errs := []error{tp.Shutdown(ctx)}
+		for _, c := range closers {
+			errs = append(errs, c(ctx))
+		}
+		return errors.Join(errs...)
+	}, nil
+}
+
+// otlpOptions accepts either a bare host:port, which is assumed to be an insecure local collector,
+// or a full URL.
+func otlpOptions(endpoint string) []otlptracehttp.Option {
+	if !strings.Contains(endpoint, "://") {
+		return []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint), otlptracehttp.WithInsecure()}
+	}
+	return []otlptracehttp.Option{otlptracehttp.WithEndpointURL(endpoint)}
+}
@@ -0,0 +1,61 @@
+// Package tracing emits OpenTelemetry spans for interchaintest operations,
+// such as image pulls, container lifecycles, genesis assembly, relayer commands, tx broadcasts and polling waits.
+//
+// Spans are created with the global tracer provider, which is a no-op until one is installed.
+// Call Setup, typically from TestMain, to export spans to an OTLP collector or a JSON file:
+//
+//	func TestMain(m *testing.M) {
+//	  shutdown, err := tracing.Setup(context.Background(), tracing.ConfigFromEnv())
+//	  if err != nil {
+//	    panic(err)
+//	  }
+//	  code := m.Run()
+//	  _ = shutdown(context.Background())
+//	  os.Exit(code)
+//	}
+//
+// Spans started from a context carrying a span, such as the ctx passed to (*interchaintest.Interchain).Build,
+// are nested under it, so the time spent in each phase of a build is visible in a single trace.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies the tracer used for all interchaintest spans.
+const InstrumentationName = "github.com/strangelove-ventures/interchaintest"
+
+// Attribute keys shared by spans across packages.
+const (
+	AttrChainID   = attribute.Key("interchaintest.chain_id")
+	AttrTestName  = attribute.Key("interchaintest.test_name")
+	AttrImage     = attribute.Key("interchaintest.image")
+	AttrContainer = attribute.Key("interchaintest.container")
+	AttrCommand   = attribute.Key("interchaintest.command")
+	AttrRelayer   = attribute.Key("interchaintest.relayer")
+	AttrTxHash    = attribute.Key("interchaintest.tx_hash")
+)
+
+// Start starts a span named name, as a child of any span in ctx.
+// The returned context carries the new span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if not nil, on span and ends the span.
+// It is typically deferred with a named error return:
+//
+//	ctx, span := tracing.Start(ctx, "docker.image.pull")
+//	defer func() { tracing.End(span, err) }()
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,99 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// These tests replace the global tracer provider, so they must not run in parallel.
+
+func TestStartEnd(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	ctx, parent := tracing.Start(context.Background(), "parent", tracing.AttrChainID.String("gaia-1"))
+	_, child := tracing.Start(ctx, "child")
+	tracing.End(child, errors.New("boom"))
+	tracing.End(parent, nil)
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+
+	gotChild, gotParent := spans[0], spans[1]
+	require.Equal(t, "child", gotChild.Name)
+	require.Equal(t, gotParent.SpanContext.SpanID(), gotChild.Parent.SpanID())
+	require.Equal(t, codes.Error, gotChild.Status.Code)
+	require.Equal(t, "boom", gotChild.Status.Description)
+	require.Len(t, gotChild.Events, 1)
+
+	require.Equal(t, "parent", gotParent.Name)
+	require.Equal(t, codes.Unset, gotParent.Status.Code)
+	require.Contains(t, gotParent.Attributes, tracing.AttrChainID.String("gaia-1"))
+}
+
+func TestSetup_File(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	path := filepath.Join(t.TempDir(), "trace.json")
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{File: path, ServiceName: "my-tests"})
+	require.NoError(t, err)
+
+	_, span := tracing.Start(context.Background(), "docker.image.pull", tracing.AttrImage.String("busybox:stable"))
+	tracing.End(span, nil)
+
+	require.NoError(t, shutdown(context.Background()))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var got struct {
+		Name       string
+		Attributes []struct {
+			Key   string
+			Value struct{ Value any }
+		}
+		Resource []struct {
+			Key   string
+			Value struct{ Value any }
+		}
+	}
+	require.NoError(t, json.NewDecoder(f).Decode(&got))
+	require.Equal(t, "docker.image.pull", got.Name)
+	require.Len(t, got.Attributes, 1)
+	require.Equal(t, "interchaintest.image", got.Attributes[0].Key)
+	require.Equal(t, "busybox:stable", got.Attributes[0].Value.Value)
+
+	var service any
+	for _, kv := range got.Resource {
+		if kv.Key == "service.name" {
+			service = kv.Value.Value
+		}
+	}
+	require.Equal(t, "my-tests", service)
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+	require.Equal(t, prev, otel.GetTracerProvider())
+}